@@ -360,6 +360,7 @@ private constructor(
                 return Optional.empty()
             }
             component.translationUnits.add(frontend.parse(sourceLocation))
+            component.translationUnits.addAll(frontend.additionalTranslationUnits)
         } catch (ex: TranslationException) {
             log.error("An error occurred during parsing of ${sourceLocation.name}: ${ex.message}")
             if (config.failOnError) {
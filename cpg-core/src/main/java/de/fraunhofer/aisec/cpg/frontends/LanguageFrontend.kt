@@ -66,6 +66,13 @@ abstract class LanguageFrontend(
 
     var currentTU: TranslationUnitDeclaration? = null
 
+    /**
+     * Translation units, which are created while parsing a file in addition to its own one, e.g.,
+     * of dependencies, which are not among the source locations. They are added to the component
+     * of the parsed file.
+     */
+    val additionalTranslationUnits = mutableListOf<TranslationUnitDeclaration>()
+
     @Throws(TranslationException::class)
     fun parseAll(): List<TranslationUnitDeclaration> {
         val units = ArrayList<TranslationUnitDeclaration>()
@@ -28,6 +28,7 @@ package de.fraunhofer.aisec.cpg.frontends
 import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
 import de.fraunhofer.aisec.cpg.graph.declarations.RecordDeclaration
 import de.fraunhofer.aisec.cpg.graph.declarations.TranslationUnitDeclaration
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.ArrayRangeExpression
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
 import de.fraunhofer.aisec.cpg.graph.types.Type
 import de.fraunhofer.aisec.cpg.passes.CallResolver
@@ -162,3 +163,22 @@ interface HasShortCircuitOperators : LanguageTrait {
     // '||', 'or', 'v'
     val disjunctiveOperators: List<String>
 }
+
+/**
+ * A language trait, that specifies that the callee of a call can be computed by any expression,
+ * e.g. in `f()()` or when immediately calling a lambda, which is evaluated before the arguments.
+ */
+interface HasComputedCallees : LanguageTrait
+
+/**
+ * A language trait, that specifies that this language has slice expressions, e.g. `a[low:high]`
+ * in Go, which are represented as an [ArrayRangeExpression] whose bounds are evaluated in order.
+ */
+interface HasSliceExpressions : LanguageTrait
+
+/**
+ * A language trait, that specifies that the names of namespaces are already fully qualified, e.g.
+ * `github.com/org/repo` for a Go package, even if the namespace is nested in the one of its parent
+ * path. Therefore, they are not prefixed by the name of the enclosing namespace.
+ */
+interface HasQualifiedNamespaces : LanguageTrait
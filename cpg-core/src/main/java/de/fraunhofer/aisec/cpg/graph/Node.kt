@@ -120,6 +120,14 @@ open class Node : IVisitable<Node>, Persistable, LanguageProvider, ScopeProvider
     /** Location of the finding in source code. */
     @Convert(LocationConverter::class) var location: PhysicalLocation? = null
 
+    /**
+     * The location of this node in the file, from which it was actually parsed, if it differs from
+     * [location]. This is the case for generated code, whose [location] points to its original
+     * source, e.g., by a `//line` directive in Golang. It is currently not persisted in the graph
+     * database.
+     */
+    @Transient var physicalLocation: PhysicalLocation? = null
+
     /**
      * Name of the containing file. It can be null for artificially created nodes or if just
      * analyzing snippets of code without an associated file name.
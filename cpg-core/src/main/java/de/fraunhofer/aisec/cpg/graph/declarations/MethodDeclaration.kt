@@ -73,4 +73,12 @@ open class MethodDeclaration : FunctionDeclaration() {
      * scoping aspect of the super-call accordingly.
      */
     @field:SubGraph("AST") var receiver: VariableDeclaration? = null
+
+    /**
+     * Whether the [receiver] is a pointer to the object, on which the method is called, rather than
+     * a copy of it, e.g., `func (s *Server) Handle()` in Golang. Only methods with a pointer
+     * receiver can modify the object. In Golang, they are also not part of the method set of the
+     * value type, which matters when checking whether a type implements an interface.
+     */
+    var hasPointerReceiver = false
 }
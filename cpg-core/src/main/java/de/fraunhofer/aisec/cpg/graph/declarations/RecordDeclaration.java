@@ -41,6 +41,7 @@ import java.util.stream.Collectors;
 import java.util.stream.Stream;
 import org.apache.commons.lang3.builder.ToStringBuilder;
 import org.jetbrains.annotations.NotNull;
+import org.jetbrains.annotations.Nullable;
 import org.neo4j.ogm.annotation.Relationship;
 import org.neo4j.ogm.annotation.Transient;
 import org.slf4j.Logger;
@@ -98,6 +99,13 @@ public class RecordDeclaration extends Declaration implements DeclarationHolder,
   @org.neo4j.ogm.annotation.Relationship
   private Set<ValueDeclaration> staticImports = new HashSet<>();
 
+  /**
+   * The type, on which a defined type is based, e.g. <code>[]string</code> for <code>
+   * type Names []string</code> in Golang. The record shares the representation of this type, but
+   * not its methods.
+   */
+  @Nullable private Type underlyingType;
+
   /**
    * It is important to set this name to a full qualified name (FQN).
    *
@@ -378,6 +386,15 @@ public class RecordDeclaration extends Declaration implements DeclarationHolder,
     this.superClasses.add(superClass);
   }
 
+  @Nullable
+  public Type getUnderlyingType() {
+    return underlyingType;
+  }
+
+  public void setUnderlyingType(@Nullable Type underlyingType) {
+    this.underlyingType = underlyingType;
+  }
+
   public Set<Type> getExternalSubTypes() {
     return this.externalSubTypes;
   }
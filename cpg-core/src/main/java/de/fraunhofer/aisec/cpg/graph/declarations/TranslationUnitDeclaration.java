@@ -70,6 +70,12 @@ public class TranslationUnitDeclaration extends Declaration
   @NotNull
   private @SubGraph("AST") List<PropertyEdge<Statement>> statements = new ArrayList<>();
 
+  /**
+   * Whether the unit was generated by a tool, e.g., a file marked by a {@code // Code generated ...
+   * DO NOT EDIT.} comment in Golang.
+   */
+  private boolean isGenerated = false;
+
   /**
    * Returns the i-th declaration as a specific class, if it can be cast
    *
@@ -159,6 +165,14 @@ public class TranslationUnitDeclaration extends Declaration
     addIfNotContains(declarations, declaration);
   }
 
+  public boolean isGenerated() {
+    return isGenerated;
+  }
+
+  public void setGenerated(boolean generated) {
+    isGenerated = generated;
+  }
+
   @Override
   public @NotNull List<PropertyEdge<Statement>> getStatementEdges() {
     return this.statements;
@@ -28,7 +28,10 @@ package de.fraunhofer.aisec.cpg.graph.statements.expressions;
 import de.fraunhofer.aisec.cpg.graph.SubGraph;
 import java.util.Objects;
 
-/** Expressions of the form floor ... ceiling */
+/**
+ * Expressions of the form floor ... ceiling. Some languages, such as Go, additionally support a
+ * maximum, which limits the capacity of a three-index slice <code>s[low:high:max]</code>.
+ */
 public class ArrayRangeExpression extends Expression {
 
   @SubGraph("AST")
@@ -37,6 +40,9 @@ public class ArrayRangeExpression extends Expression {
   @SubGraph("AST")
   private Expression ceiling;
 
+  @SubGraph("AST")
+  private Expression max;
+
   public Expression getCeiling() {
     return ceiling;
   }
@@ -53,6 +59,14 @@ public class ArrayRangeExpression extends Expression {
     this.floor = floor;
   }
 
+  public Expression getMax() {
+    return max;
+  }
+
+  public void setMax(Expression max) {
+    this.max = max;
+  }
+
   @Override
   public boolean equals(Object o) {
     if (this == o) {
@@ -64,11 +78,12 @@ public class ArrayRangeExpression extends Expression {
     ArrayRangeExpression that = (ArrayRangeExpression) o;
     return super.equals(that)
         && Objects.equals(floor, that.floor)
-        && Objects.equals(ceiling, that.ceiling);
+        && Objects.equals(ceiling, that.ceiling)
+        && Objects.equals(max, that.max);
   }
 
   @Override
   public int hashCode() {
-    return super.hashCode();
+    return Objects.hash(super.hashCode(), floor, ceiling, max);
   }
 }
@@ -59,6 +59,11 @@ public class ArraySubscriptionExpression extends Expression implements TypeListe
   }
 
   private Type getSubscriptType(Type arrayType) {
+    // A range subscript, such as a slice expression, yields a sub-array of the same type
+    if (subscriptExpression instanceof ArrayRangeExpression) {
+      return arrayType;
+    }
+
     return arrayType.dereference();
   }
 
@@ -54,7 +54,7 @@ public class BinaryOperator extends Expression implements TypeListener, Assignme
   /** Required for compound BinaryOperators. This should not be stored in the graph */
   @Transient
   public static final List<String> compoundOperators =
-      List.of("*=", "/=", "%=", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=");
+      List.of("*=", "/=", "%=", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "&^=");
 
   public Expression getLhs() {
     return lhs;
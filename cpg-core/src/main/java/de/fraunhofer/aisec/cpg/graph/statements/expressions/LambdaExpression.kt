@@ -29,6 +29,7 @@ import de.fraunhofer.aisec.cpg.graph.HasType
 import de.fraunhofer.aisec.cpg.graph.SubGraph
 import de.fraunhofer.aisec.cpg.graph.TypeManager
 import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
+import de.fraunhofer.aisec.cpg.graph.declarations.ValueDeclaration
 import de.fraunhofer.aisec.cpg.graph.types.FunctionPointerType
 import de.fraunhofer.aisec.cpg.graph.types.Type
 
@@ -51,6 +52,24 @@ class LambdaExpression : Expression(), HasType.TypeListener {
             value?.registerTypeListener(this)
         }
 
+    /**
+     * The variables, which are declared outside of the lambda, but used within it, i.e., the ones
+     * it captures.
+     */
+    var capturedVariables: MutableList<ValueDeclaration> = mutableListOf()
+
+    /**
+     * Whether the [capturedVariables] are captured by reference, so that modifications within the
+     * lambda are visible outside of it and vice versa. Otherwise, they are copied.
+     */
+    var capturesByReference = false
+
+    fun addCapturedVariable(variable: ValueDeclaration) {
+        if (variable !in capturedVariables) {
+            capturedVariables.add(variable)
+        }
+    }
+
     override fun typeChanged(src: HasType?, root: MutableList<HasType>?, oldType: Type?) {
         if (!TypeManager.isTypeSystemActive()) {
             return
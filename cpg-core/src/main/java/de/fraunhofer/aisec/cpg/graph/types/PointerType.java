@@ -26,6 +26,7 @@
 package de.fraunhofer.aisec.cpg.graph.types;
 
 import java.util.Objects;
+import org.jetbrains.annotations.Nullable;
 import org.neo4j.ogm.annotation.Relationship;
 
 /**
@@ -45,6 +46,12 @@ public class PointerType extends Type implements SecondOrderType {
 
   private PointerOrigin pointerOrigin;
 
+  /**
+   * The number of elements of an array with a fixed length, such as int[3] in C/C++ or [3]int in
+   * Go. It is null for arrays without a known length and for pointers.
+   */
+  @Nullable private Long arrayLength;
+
   private PointerType() {}
 
   public PointerType(Type elementType, PointerOrigin pointerOrigin) {
@@ -106,7 +113,9 @@ public class PointerType extends Type implements SecondOrderType {
 
   @Override
   public Type duplicate() {
-    return new PointerType(this, this.elementType.duplicate(), this.pointerOrigin);
+    PointerType duplicate = new PointerType(this, this.elementType.duplicate(), this.pointerOrigin);
+    duplicate.arrayLength = this.arrayLength;
+    return duplicate;
   }
 
   public boolean isArray() {
@@ -149,6 +158,15 @@ public class PointerType extends Type implements SecondOrderType {
     this.elementType = elementType;
   }
 
+  @Nullable
+  public Long getArrayLength() {
+    return arrayLength;
+  }
+
+  public void setArrayLength(@Nullable Long arrayLength) {
+    this.arrayLength = arrayLength;
+  }
+
   @Override
   public boolean equals(Object o) {
     if (this == o) return true;
@@ -156,11 +174,12 @@ public class PointerType extends Type implements SecondOrderType {
     if (!super.equals(o)) return false;
     PointerType that = (PointerType) o;
     return Objects.equals(elementType, that.elementType)
-        && Objects.equals(pointerOrigin, that.pointerOrigin);
+        && Objects.equals(pointerOrigin, that.pointerOrigin)
+        && Objects.equals(arrayLength, that.arrayLength);
   }
 
   @Override
   public int hashCode() {
-    return Objects.hash(super.hashCode(), elementType, pointerOrigin);
+    return Objects.hash(super.hashCode(), elementType, pointerOrigin, arrayLength);
   }
 }
@@ -253,10 +253,11 @@ class DFGPass : Pass() {
 
     /**
      * Adds the DFG edge for a [LambdaExpression]. The data flow from the function representing the
-     * lambda to the expression.
+     * lambda to the expression. Additionally, the captured variables flow into the lambda.
      */
     private fun handleLambdaExpression(node: LambdaExpression) {
         node.function?.let { node.addPrevDFG(it) }
+        node.capturedVariables.forEach { node.addPrevDFG(it) }
     }
 
     /**
@@ -393,7 +394,8 @@ class DFGPass : Pass() {
             ">>=",
             "&=",
             "^=",
-            "|=" -> {
+            "|=",
+            "&^=" -> {
                 node.lhs?.let {
                     node.addPrevDFG(it)
                     node.addNextDFG(it)
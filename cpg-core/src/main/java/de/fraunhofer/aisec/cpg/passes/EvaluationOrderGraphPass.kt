@@ -27,7 +27,9 @@ package de.fraunhofer.aisec.cpg.passes
 
 import de.fraunhofer.aisec.cpg.TranslationResult
 import de.fraunhofer.aisec.cpg.frontends.CallableInterface
+import de.fraunhofer.aisec.cpg.frontends.HasComputedCallees
 import de.fraunhofer.aisec.cpg.frontends.HasShortCircuitOperators
+import de.fraunhofer.aisec.cpg.frontends.HasSliceExpressions
 import de.fraunhofer.aisec.cpg.frontends.ProcessedListener
 import de.fraunhofer.aisec.cpg.graph.Node
 import de.fraunhofer.aisec.cpg.graph.StatementHolder
@@ -110,6 +112,9 @@ open class EvaluationOrderGraphPass : Pass() {
         map[ArraySubscriptionExpression::class.java] = CallableInterface {
             handleArraySubscriptionExpression(it as ArraySubscriptionExpression)
         }
+        map[ArrayRangeExpression::class.java] = CallableInterface {
+            handleArrayRangeExpression(it as ArrayRangeExpression)
+        }
         map[ArrayCreationExpression::class.java] = CallableInterface {
             handleArrayCreationExpression(it as ArrayCreationExpression)
         }
@@ -421,6 +426,18 @@ open class EvaluationOrderGraphPass : Pass() {
             createEOG(node.base!!)
         }
 
+        // evaluate the callee as well, if the function is computed by an expression, e.g. in
+        // f()() or when immediately calling a lambda, rather than referred to by its name
+        val callee = node.callee
+        if (
+            node.language is HasComputedCallees &&
+                (callee is CallExpression ||
+                    callee is LambdaExpression ||
+                    callee is ArraySubscriptionExpression)
+        ) {
+            createEOG(callee)
+        }
+
         // first the arguments
         for (arg in node.arguments) {
             createEOG(arg)
@@ -454,6 +471,19 @@ open class EvaluationOrderGraphPass : Pass() {
         pushToEOG(node)
     }
 
+    protected fun handleArrayRangeExpression(node: ArrayRangeExpression) {
+        // other languages, e.g. C/C++ with its designated initializers, do not evaluate the range
+        if (node.language !is HasSliceExpressions) {
+            LOGGER.info("Parsing of type " + node.javaClass + " is not supported (yet)")
+            return
+        }
+
+        node.floor?.let { createEOG(it) }
+        node.ceiling?.let { createEOG(it) }
+        node.max?.let { createEOG(it) }
+        pushToEOG(node)
+    }
+
     protected fun handleArrayCreationExpression(node: ArrayCreationExpression) {
         for (dimension in node.dimensions) {
             dimension?.let { createEOG(it) }
@@ -346,6 +346,10 @@ open class VariableUsageResolver : SymbolResolverPass() {
     ) {
         if (current !is MemberExpression) return
 
+        // Members, which the frontend already resolved to a method, e.g. method values in Go, are
+        // not fields
+        if (current.refersTo is FunctionDeclaration) return
+
         // log.error("Resolve field usage: " + " " + curClass + " " + current)
 
         var baseTarget: Declaration? = null
@@ -25,7 +25,9 @@
  */
 package de.fraunhofer.aisec.cpg.passes.scopes
 
+import de.fraunhofer.aisec.cpg.frontends.HasQualifiedNamespaces
 import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.declarations.NamespaceDeclaration
 
 /**
  * A scope which acts as a namespace with a certain prefix. This could be a package or other
@@ -36,7 +38,10 @@ open class NameScope(node: Node, currentPrefix: String, var delimiter: String) :
     var namePrefix: String = ""
 
     init {
-        if (currentPrefix.isNotEmpty()) {
+        if (node is NamespaceDeclaration && node.language is HasQualifiedNamespaces) {
+            // The name is already fully qualified, even if it is nested in another namespace.
+            namePrefix = node.name
+        } else if (currentPrefix.isNotEmpty()) {
             var nodeName = node.name
             // If the name already contains some form of prefix we have to remove it.
             nodeName =
@@ -37,6 +37,12 @@ public class Region implements Comparable<Region> {
   private int endLine;
   private int endColumn;
 
+  /** The (zero-based) byte offset of the start of the region in the file, if known. */
+  private int startOffset = -1;
+
+  /** The (zero-based) byte offset of the end of the region in the file, if known. */
+  private int endOffset = -1;
+
   public Region(int startLine, int startColumn, int endLine, int endColumn) {
     this.startLine = startLine;
     this.startColumn = startColumn;
@@ -44,6 +50,13 @@ public class Region implements Comparable<Region> {
     this.endColumn = endColumn;
   }
 
+  public Region(
+      int startLine, int startColumn, int endLine, int endColumn, int startOffset, int endOffset) {
+    this(startLine, startColumn, endLine, endColumn);
+    this.startOffset = startOffset;
+    this.endOffset = endOffset;
+  }
+
   public Region() {
     this(-1, -1, -1, -1);
   }
@@ -80,6 +93,22 @@ public class Region implements Comparable<Region> {
     this.endColumn = endColumn;
   }
 
+  public int getStartOffset() {
+    return startOffset;
+  }
+
+  public void setStartOffset(int startOffset) {
+    this.startOffset = startOffset;
+  }
+
+  public int getEndOffset() {
+    return endOffset;
+  }
+
+  public void setEndOffset(int endOffset) {
+    this.endOffset = endOffset;
+  }
+
   @Override
   public String toString() {
     String sb = startLine + ":" + startColumn + "-" + endLine + ":" + endColumn;
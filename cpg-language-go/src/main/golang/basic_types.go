@@ -26,15 +26,13 @@
 package cpg
 
 import (
-	"log"
-
 	"tekao.net/jnigi"
 )
 
 func NewString(s string) *jnigi.ObjectRef {
 	o, err := env.NewObject("java/lang/String", []byte(s))
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 
 	}
 
@@ -45,7 +43,7 @@ func NewBoolean(b bool) *jnigi.ObjectRef {
 	// TODO: Use Boolean.valueOf
 	o, err := env.NewObject("java/lang/Boolean", b)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return o
@@ -55,7 +53,7 @@ func NewInteger(i int) *jnigi.ObjectRef {
 	// TODO: Use Integer.valueOf
 	o, err := env.NewObject("java/lang/Integer", i)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return o
@@ -65,7 +63,37 @@ func NewDouble(d float64) *jnigi.ObjectRef {
 	// TODO: Use Integer.valueOf
 	o, err := env.NewObject("java/lang/Double", d)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
+	}
+
+	return o
+}
+
+func NewLong(l int64) *jnigi.ObjectRef {
+	o, err := env.NewObject("java/lang/Long", l)
+	if err != nil {
+		panic(err)
+	}
+
+	return o
+}
+
+func NewBigInteger(s string) *jnigi.ObjectRef {
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	o, err := env.NewObject("java/math/BigInteger", str)
+	if err != nil {
+		panic(err)
+	}
+
+	return o
+}
+
+func NewComplex(real float64, imag float64) *jnigi.ObjectRef {
+	o, err := env.NewObject("de/fraunhofer/aisec/cpg/frontends/golang/Complex", real, imag)
+	if err != nil {
+		panic(err)
 	}
 
 	return o
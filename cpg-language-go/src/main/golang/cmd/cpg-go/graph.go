@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Node is a node of the graph. Its type corresponds to the class of the
+// respective node in the CPG.
+type Node struct {
+	ID       int       `json:"id"`
+	Type     string    `json:"type"`
+	Name     string    `json:"name"`
+	Location *Location `json:"location,omitempty"`
+}
+
+type Location struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"startLine"`
+	StartColumn int    `json:"startColumn"`
+	EndLine     int    `json:"endLine"`
+	EndColumn   int    `json:"endColumn"`
+}
+
+// Edge is a directed edge of the graph. Its type corresponds to the name of the
+// respective edge in the CPG, e.g. AST or INVOKES.
+type Edge struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Type string `json:"type"`
+}
+
+type graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+
+	fset *token.FileSet
+
+	// the nodes of the type checker objects, used to resolve calls
+	objects map[types.Object]*Node
+
+	// calls of each function, which are resolved once all functions are known
+	calls map[*Node][]types.Object
+
+	// files, which were already handled by another variant of their package
+	files map[string]bool
+}
+
+func newGraph(fset *token.FileSet) *graph {
+	return &graph{
+		Nodes:   []*Node{},
+		Edges:   []*Edge{},
+		fset:    fset,
+		objects: map[types.Object]*Node{},
+		calls:   map[*Node][]types.Object{},
+		files:   map[string]bool{},
+	}
+}
+
+func (g *graph) newNode(typ string, name string, astNode ast.Node) *Node {
+	n := &Node{
+		ID:   len(g.Nodes),
+		Type: typ,
+		Name: name,
+	}
+
+	if astNode != nil && astNode.Pos().IsValid() {
+		start := g.fset.Position(astNode.Pos())
+		end := g.fset.Position(astNode.End())
+
+		n.Location = &Location{
+			File:        start.Filename,
+			StartLine:   start.Line,
+			StartColumn: start.Column,
+			EndLine:     end.Line,
+			EndColumn:   end.Column,
+		}
+	}
+
+	g.Nodes = append(g.Nodes, n)
+
+	return n
+}
+
+func (g *graph) addEdge(from *Node, to *Node, typ string) {
+	g.Edges = append(g.Edges, &Edge{From: from.ID, To: to.ID, Type: typ})
+}
+
+func (g *graph) handlePackage(p *packages.Package) {
+	var namespaces = map[*ast.File]*Node{}
+
+	// types need to be known before their methods, which can be declared in
+	// any file of the package
+	for _, file := range p.Syntax {
+		path := g.fset.Position(file.Package).Filename
+		if g.files[path] {
+			continue
+		}
+
+		g.files[path] = true
+
+		tu := g.newNode("TranslationUnitDeclaration", path, file)
+
+		namespace := g.newNode("NamespaceDeclaration", p.Name, nil)
+		g.addEdge(tu, namespace, "AST")
+		namespaces[file] = namespace
+
+		for _, decl := range file.Decls {
+			if v, ok := decl.(*ast.GenDecl); ok {
+				g.handleGenDecl(p, namespace, v)
+			}
+		}
+	}
+
+	for _, file := range p.Syntax {
+		namespace, ok := namespaces[file]
+		if !ok {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			if v, ok := decl.(*ast.FuncDecl); ok {
+				g.handleFuncDecl(p, namespace, v)
+			}
+		}
+	}
+}
+
+func (g *graph) handleFuncDecl(p *packages.Package, parent *Node, funcDecl *ast.FuncDecl) {
+	var f *Node
+
+	if funcDecl.Recv != nil {
+		f = g.newNode("MethodDeclaration", funcDecl.Name.Name, funcDecl)
+
+		// methods are part of the record of their receiver, if we know it
+		if record := g.receiverRecord(p, funcDecl); record != nil {
+			parent = record
+		}
+	} else {
+		f = g.newNode("FunctionDeclaration", funcDecl.Name.Name, funcDecl)
+	}
+
+	g.addEdge(parent, f, "AST")
+	g.register(p, funcDecl.Name, f)
+
+	for _, field := range funcDecl.Type.Params.List {
+		for _, name := range field.Names {
+			param := g.newNode("ParamVariableDeclaration", name.Name, name)
+			g.addEdge(f, param, "AST")
+			g.register(p, name, param)
+		}
+	}
+
+	if funcDecl.Body == nil || p.TypesInfo == nil {
+		return
+	}
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		var ident *ast.Ident
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			ident = fun
+		case *ast.SelectorExpr:
+			ident = fun.Sel
+		}
+
+		if ident != nil {
+			if fn, ok := p.TypesInfo.Uses[ident].(*types.Func); ok {
+				g.calls[f] = append(g.calls[f], fn.Origin())
+			}
+		}
+
+		return true
+	})
+}
+
+// receiverRecord returns the record of the receiver of a method.
+func (g *graph) receiverRecord(p *packages.Package, funcDecl *ast.FuncDecl) *Node {
+	if p.TypesInfo == nil {
+		return nil
+	}
+
+	fn, ok := p.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	recv := fn.Type().(*types.Signature).Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	return g.objects[named.Obj()]
+}
+
+func (g *graph) handleGenDecl(p *packages.Package, parent *Node, genDecl *ast.GenDecl) {
+	for _, spec := range genDecl.Specs {
+		switch v := spec.(type) {
+		case *ast.TypeSpec:
+			g.handleTypeSpec(p, parent, v)
+		case *ast.ValueSpec:
+			for _, name := range v.Names {
+				d := g.newNode("VariableDeclaration", name.Name, name)
+				g.addEdge(parent, d, "AST")
+				g.register(p, name, d)
+			}
+		}
+	}
+}
+
+func (g *graph) handleTypeSpec(p *packages.Package, parent *Node, typeSpec *ast.TypeSpec) {
+	// records are named by their fully qualified name, like in the CPG
+	record := g.newNode("RecordDeclaration", p.PkgPath+"."+typeSpec.Name.Name, typeSpec)
+	g.addEdge(parent, record, "AST")
+	g.register(p, typeSpec.Name, record)
+
+	switch t := typeSpec.Type.(type) {
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			for _, name := range field.Names {
+				f := g.newNode("FieldDeclaration", name.Name, name)
+				g.addEdge(record, f, "AST")
+				g.register(p, name, f)
+			}
+		}
+	case *ast.InterfaceType:
+		for _, method := range t.Methods.List {
+			for _, name := range method.Names {
+				m := g.newNode("MethodDeclaration", name.Name, name)
+				g.addEdge(record, m, "AST")
+				g.register(p, name, m)
+			}
+		}
+	}
+}
+
+// register remembers n as the node of the object, which is defined by ident.
+func (g *graph) register(p *packages.Package, ident *ast.Ident, n *Node) {
+	if p.TypesInfo == nil {
+		return
+	}
+
+	if obj := p.TypesInfo.Defs[ident]; obj != nil {
+		g.objects[obj] = n
+	}
+}
+
+// resolveCalls adds an INVOKES edge from each function to the functions it calls.
+func (g *graph) resolveCalls() {
+	for _, f := range g.Nodes {
+		for _, obj := range g.calls[f] {
+			if callee, ok := g.objects[obj]; ok {
+				g.addEdge(f, callee, "INVOKES")
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// module writes a module with the given files, keyed by their slash-separated
+// path, into a new temporary directory and returns it.
+func module(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/m\n\ngo 1.19\n"
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+// translate runs the command for dir and decodes the graph it writes.
+func translate(t *testing.T, dir string, tests bool, tags string) *graph {
+	t.Helper()
+
+	output := filepath.Join(t.TempDir(), "graph.json")
+	if err := run(dir, output, tests, tags); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var g graph
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+
+	return &g
+}
+
+// node returns the only node with the given type and name.
+func (g *graph) node(t *testing.T, typ string, name string) *Node {
+	t.Helper()
+
+	var found *Node
+	for _, n := range g.Nodes {
+		if n.Type == typ && n.Name == name {
+			if found != nil {
+				t.Fatalf("more than one %s %s", typ, name)
+			}
+
+			found = n
+		}
+	}
+
+	if found == nil {
+		t.Fatalf("no %s %s", typ, name)
+	}
+
+	return found
+}
+
+// hasEdge returns, whether there is an edge of the given type between the nodes.
+func (g *graph) hasEdge(from *Node, to *Node, typ string) bool {
+	for _, e := range g.Edges {
+		if e.From == from.ID && e.To == to.ID && e.Type == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+const serverSource = `package m
+
+var defaultAddr = ":8080"
+
+type Handler interface {
+	Handle(path string)
+}
+
+type Server struct {
+	addr string
+}
+
+func (s *Server) Serve(h Handler) {
+	h.Handle(s.addr)
+	log(s.addr)
+}
+
+func log(msg string) {}
+
+func Run() {
+	s := &Server{addr: defaultAddr}
+	s.Serve(nil)
+}
+`
+
+func TestGraph(t *testing.T) {
+	dir := module(t, map[string]string{"server.go": serverSource})
+	g := translate(t, dir, false, "")
+
+	tu := g.node(t, "TranslationUnitDeclaration", filepath.Join(dir, "server.go"))
+	if tu.Location == nil || tu.Location.StartLine != 1 {
+		t.Errorf("unexpected location of the translation unit: %+v", tu.Location)
+	}
+
+	namespace := g.node(t, "NamespaceDeclaration", "m")
+	server := g.node(t, "RecordDeclaration", "example.com/m.Server")
+	handler := g.node(t, "RecordDeclaration", "example.com/m.Handler")
+	serve := g.node(t, "MethodDeclaration", "Serve")
+	run := g.node(t, "FunctionDeclaration", "Run")
+	handle := g.node(t, "MethodDeclaration", "Handle")
+
+	edges := []struct {
+		from *Node
+		to   *Node
+		typ  string
+	}{
+		{tu, namespace, "AST"},
+		{namespace, g.node(t, "VariableDeclaration", "defaultAddr"), "AST"},
+		{namespace, server, "AST"},
+		{namespace, handler, "AST"},
+		{server, g.node(t, "FieldDeclaration", "addr"), "AST"},
+		{handler, handle, "AST"},
+		// methods are part of the record of their receiver
+		{server, serve, "AST"},
+		{serve, g.node(t, "ParamVariableDeclaration", "h"), "AST"},
+		{namespace, run, "AST"},
+		{run, serve, "INVOKES"},
+		{serve, g.node(t, "FunctionDeclaration", "log"), "INVOKES"},
+		// calls of interface methods invoke the method of the interface
+		{serve, handle, "INVOKES"},
+	}
+
+	for _, e := range edges {
+		if !g.hasEdge(e.from, e.to, e.typ) {
+			t.Errorf("missing %s edge from %s %s to %s %s", e.typ, e.from.Type, e.from.Name, e.to.Type, e.to.Name)
+		}
+	}
+}
+
+func TestGraphFiles(t *testing.T) {
+	dir := module(t, map[string]string{
+		"server.go":      serverSource,
+		"server_test.go": "package m\n\nfunc TestServe() {}\n",
+		"e2e_test.go":    "package m_test\n\nfunc TestE2E() {}\n",
+		"integration.go": "//go:build integration\n\npackage m\n\nfunc Integration() {}\n",
+	})
+
+	tests := []struct {
+		name  string
+		tests bool
+		tags  string
+		want  []string
+	}{
+		{
+			name: "default",
+			want: []string{"server.go"},
+		},
+		{
+			name:  "tests",
+			tests: true,
+			want:  []string{"server.go", "server_test.go", "e2e_test.go"},
+		},
+		{
+			name: "tags",
+			tags: "integration",
+			want: []string{"server.go", "integration.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := translate(t, dir, tt.tests, tt.tags)
+
+			var files []string
+			for _, n := range g.Nodes {
+				if n.Type == "TranslationUnitDeclaration" {
+					files = append(files, filepath.Base(n.Name))
+				}
+			}
+
+			// each file is only translated once, although it is part of
+			// several variants of its package
+			if len(files) != len(tt.want) {
+				t.Fatalf("translated %v, want %v", files, tt.want)
+			}
+
+			for _, want := range tt.want {
+				g.node(t, "TranslationUnitDeclaration", filepath.Join(dir, want))
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+// Command cpg-go translates a Go module into a graph of its declarations and
+// writes it as JSON. In contrast to the frontend, it does not require a JVM
+// and can therefore be used to debug the Go side in isolation or by consumers,
+// which are not based on the JVM.
+//
+// Usage:
+//
+//	cpg-go [-o output] [-tests] [-tags tag,...] [directory]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	var (
+		output = flag.String("o", "", "the file to write the graph to, instead of the standard output")
+		tests  = flag.Bool("tests", false, "also translate test files and test packages")
+		tags   = flag.String("tags", "", "a comma-separated list of additional build tags")
+	)
+
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if err := run(dir, *output, *tests, *tags); err != nil {
+		fmt.Fprintf(os.Stderr, "cpg-go: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, output string, tests bool, tags string) (err error) {
+	var flags []string
+	if tags != "" {
+		flags = append(flags, "-tags="+tags)
+	}
+
+	fset := token.NewFileSet()
+
+	pkgs, err := packages.Load(&packages.Config{
+		Fset: fset,
+		Dir:  dir,
+		Mode: packages.NeedFiles | packages.NeedSyntax | packages.NeedImports |
+			packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo,
+		Tests:      tests,
+		BuildFlags: flags,
+	}, "./...")
+	if err != nil {
+		return fmt.Errorf("could not load packages: %w", err)
+	}
+
+	var g = newGraph(fset)
+	for _, p := range pkgs {
+		// skip the generated main packages of tests
+		if strings.HasSuffix(p.ID, ".test") {
+			continue
+		}
+
+		g.handlePackage(p)
+	}
+
+	g.resolveCalls()
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("could not create output file: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(g)
+}
@@ -26,9 +26,6 @@
 package cpg
 
 import (
-	"log"
-	"runtime/debug"
-
 	"tekao.net/jnigi"
 )
 
@@ -42,14 +39,17 @@ type FieldDeclaration Declaration
 type VariableDeclaration Declaration
 type ParamVariableDeclaration Declaration
 type NamespaceDeclaration Declaration
+type TypedefDeclaration Declaration
 
 const DeclarationsPackage = GraphPackage + "/declarations"
 const DeclarationClass = DeclarationsPackage + "/Declaration"
 const RecordDeclarationClass = DeclarationsPackage + "/RecordDeclaration"
 const FunctionDeclarationClass = DeclarationsPackage + "/FunctionDeclaration"
 const VariableDeclarationClass = DeclarationsPackage + "/VariableDeclaration"
+const ValueDeclarationClass = DeclarationsPackage + "/ValueDeclaration"
 const IncludeDeclarationClass = DeclarationsPackage + "/IncludeDeclaration"
 const TranslationUnitDeclarationClass = DeclarationsPackage + "/TranslationUnitDeclaration"
+const TypedefDeclarationClass = DeclarationsPackage + "/TypedefDeclaration"
 
 func (n *NamespaceDeclaration) SetName(s string) error {
 	return (*Node)(n).SetName(s)
@@ -60,7 +60,10 @@ func (n *IncludeDeclaration) SetName(s string) error {
 }
 
 func (n *IncludeDeclaration) SetFilename(s string) error {
-	return (*jnigi.ObjectRef)(n).SetField(env, "filename", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(n).SetField(env, "filename", str)
 }
 
 func (f *FunctionDeclaration) SetName(s string) error {
@@ -96,6 +99,10 @@ func (f *FunctionDeclaration) SetBody(s *Statement) (err error) {
 	return
 }
 
+func (f *FunctionDeclaration) SetIsDefinition(b bool) {
+	(*jnigi.ObjectRef)(f).CallMethod(env, "setDefinition", nil, b)
+}
+
 func (m *MethodDeclaration) SetName(s string) error {
 	return (*Node)(m).SetName(s)
 }
@@ -108,13 +115,16 @@ func (m *MethodDeclaration) SetReceiver(v *VariableDeclaration) error {
 	return (*jnigi.ObjectRef)(m).SetField(env, "receiver", (*jnigi.ObjectRef)(v))
 }
 
+func (m *MethodDeclaration) SetHasPointerReceiver(b bool) {
+	(*jnigi.ObjectRef)(m).CallMethod(env, "setHasPointerReceiver", nil, b)
+}
+
 func (m *MethodDeclaration) GetReceiver() *VariableDeclaration {
 	o := jnigi.NewObjectRef(VariableDeclarationClass)
 	err := (*jnigi.ObjectRef)(m).GetField(env, "receiver", o)
 
 	if err != nil {
-		log.Fatal(err)
-		debug.PrintStack()
+		panic(err)
 	}
 
 	return (*VariableDeclaration)(o)
@@ -129,7 +139,10 @@ func (p *ParamVariableDeclaration) SetName(s string) error {
 }
 
 func (p *ParamVariableDeclaration) SetVariadic(b bool) {
-	(*jnigi.ObjectRef)(p).CallMethod(env, "setVariadic", nil, NewBoolean(b))
+	boolean := NewBoolean(b)
+	defer env.DeleteLocalRef(boolean)
+
+	(*jnigi.ObjectRef)(p).CallMethod(env, "setVariadic", nil, boolean)
 }
 
 func (f *FieldDeclaration) SetName(s string) error {
@@ -141,7 +154,10 @@ func (f *FieldDeclaration) SetType(t *Type) {
 }
 
 func (f *FieldDeclaration) SetIsEmbeddedField(b bool) error {
-	return (*jnigi.ObjectRef)(f).CallMethod(env, "setIsEmbeddedField", nil, NewBoolean(b))
+	boolean := NewBoolean(b)
+	defer env.DeleteLocalRef(boolean)
+
+	return (*jnigi.ObjectRef)(f).CallMethod(env, "setIsEmbeddedField", nil, boolean)
 }
 
 func (v *VariableDeclaration) SetType(t *Type) {
@@ -168,21 +184,34 @@ func (v *VariableDeclaration) Declaration() *Declaration {
 
 func (t *TranslationUnitDeclaration) GetIncludeByName(s string) *IncludeDeclaration {
 	var i = jnigi.NewObjectRef(IncludeDeclarationClass)
-	err := (*jnigi.ObjectRef)(t).CallMethod(env, "getIncludeByName", i, NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	err := (*jnigi.ObjectRef)(t).CallMethod(env, "getIncludeByName", i, str)
 	if err != nil {
-		log.Fatal(err)
-		debug.PrintStack()
+		panic(err)
 	}
 
 	return (*IncludeDeclaration)(i)
 }
 
+func (t *TranslationUnitDeclaration) SetGenerated(b bool) {
+	(*jnigi.ObjectRef)(t).CallMethod(env, "setGenerated", nil, b)
+}
+
+func (t *TranslationUnitDeclaration) AddStatement(s *Statement) {
+	(*jnigi.ObjectRef)(t).CallMethod(env, "addStatement", nil, (*jnigi.ObjectRef)(s).Cast(StatementClass))
+}
+
 func (r *RecordDeclaration) SetName(s string) error {
 	return (*Node)(r).SetName(s)
 }
 
 func (r *RecordDeclaration) SetKind(s string) error {
-	return (*jnigi.ObjectRef)(r).SetField(env, "kind", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(r).SetField(env, "kind", str)
 }
 
 func (r *RecordDeclaration) AddMethod(m *MethodDeclaration) (err error) {
@@ -197,10 +226,30 @@ func (r *RecordDeclaration) AddSuperClass(t *Type) (err error) {
 	return
 }
 
+func (r *RecordDeclaration) HasSuperClass(t *Type) bool {
+	var superClasses = jnigi.NewObjectRef("java/util/List")
+	err := (*jnigi.ObjectRef)(r).CallMethod(env, "getSuperClasses", superClasses)
+	if err != nil {
+		panic(err)
+	}
+
+	var contains bool
+	err = superClasses.CallMethod(env, "contains", &contains, (*jnigi.ObjectRef)(t).Cast("java/lang/Object"))
+	if err != nil {
+		panic(err)
+	}
+
+	return contains
+}
+
 func (r *RecordDeclaration) AddExternalSubType(t *Type) (err error) {
 	return (*jnigi.ObjectRef)(r).CallMethod(env, "addExternalSubType", nil, (*jnigi.ObjectRef)(t).Cast(TypeClass))
 }
 
+func (r *RecordDeclaration) SetUnderlyingType(t *Type) {
+	(*jnigi.ObjectRef)(r).CallMethod(env, "setUnderlyingType", nil, (*jnigi.ObjectRef)(t).Cast(TypeClass))
+}
+
 func (r *RecordDeclaration) AddField(f *FieldDeclaration) {
 	(*jnigi.ObjectRef)(r).CallMethod(env, "addField", nil, (*jnigi.ObjectRef)(f))
 }
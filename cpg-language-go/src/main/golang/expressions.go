@@ -26,8 +26,6 @@
 package cpg
 
 import (
-	"log"
-
 	"tekao.net/jnigi"
 )
 
@@ -59,6 +57,7 @@ type CastExpression Expression
 type NewExpression Expression
 type ArrayCreationExpression Expression
 type ArraySubscriptionExpression Expression
+type ArrayRangeExpression Expression
 type ConstructExpression Expression
 type InitializerListExpression Expression
 type MemberCallExpression CallExpression
@@ -71,6 +70,7 @@ type DeclaredReferenceExpression Expression
 type KeyValueExpression Expression
 type TupleExpression Expression
 type DestructureTupleExpression Expression
+type TypeExpression Expression
 
 func (e *Expression) SetType(t *Type) {
 	(*HasType)(e).SetType(t)
@@ -81,7 +81,14 @@ func (c *CallExpression) SetName(s string) {
 }
 
 func (c *CallExpression) SetFqn(s string) {
-	(*jnigi.ObjectRef)(c).SetField(env, "fqn", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(c).SetField(env, "fqn", str)
+}
+
+func (c *CallExpression) SetCallee(e *Expression) {
+	(*jnigi.ObjectRef)(c).CallMethod(env, "setCallee", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
 
 func (c *CastExpression) SetExpression(e *Expression) {
@@ -116,6 +123,14 @@ func (l *LambdaExpression) SetFunction(f *FunctionDeclaration) {
 	(*jnigi.ObjectRef)(l).SetField(env, "function", (*jnigi.ObjectRef)(f).Cast(FunctionDeclarationClass))
 }
 
+func (l *LambdaExpression) AddCapturedVariable(d *Declaration) {
+	(*jnigi.ObjectRef)(l).CallMethod(env, "addCapturedVariable", nil, (*jnigi.ObjectRef)(d).Cast(ValueDeclarationClass))
+}
+
+func (l *LambdaExpression) SetCapturesByReference(b bool) {
+	(*jnigi.ObjectRef)(l).CallMethod(env, "setCapturesByReference", nil, b)
+}
+
 func (m *MemberExpression) SetBase(e *Expression) {
 	(*jnigi.ObjectRef)(m).SetField(env, "base", (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
@@ -124,7 +139,7 @@ func (m *MemberExpression) GetBase() *Expression {
 	var expr Expression
 	err := (*jnigi.ObjectRef)(m).GetField(env, "base", &expr)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return &expr
@@ -146,6 +161,10 @@ func (c *CallExpression) AddArgument(e *Expression) {
 	(*jnigi.ObjectRef)(c).CallMethod(env, "addArgument", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
 
+func (c *CallExpression) AddTemplateParameter(n *Node) {
+	(*jnigi.ObjectRef)(c).CallMethod(env, "addTemplateParameter", nil, (*jnigi.ObjectRef)(n).Cast(NodeClass))
+}
+
 func (b *BinaryOperator) SetLHS(e *Expression) {
 	(*jnigi.ObjectRef)(b).CallMethod(env, "setLhs", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
@@ -155,7 +174,10 @@ func (b *BinaryOperator) SetRHS(e *Expression) {
 }
 
 func (b *BinaryOperator) SetOperatorCode(s string) (err error) {
-	return (*jnigi.ObjectRef)(b).SetField(env, "operatorCode", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(b).SetField(env, "operatorCode", str)
 }
 
 func (u *UnaryOperator) SetInput(e *Expression) {
@@ -163,7 +185,10 @@ func (u *UnaryOperator) SetInput(e *Expression) {
 }
 
 func (u *UnaryOperator) SetOperatorCode(s string) (err error) {
-	return (*jnigi.ObjectRef)(u).SetField(env, "operatorCode", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(u).SetField(env, "operatorCode", str)
 }
 
 func (l *Literal) SetType(t *Type) {
@@ -203,10 +228,29 @@ func (r *ArraySubscriptionExpression) SetSubscriptExpression(e *Expression) {
 	(*jnigi.ObjectRef)(r).CallMethod(env, "setSubscriptExpression", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
 
+func (r *ArrayRangeExpression) SetFloor(e *Expression) {
+	(*jnigi.ObjectRef)(r).CallMethod(env, "setFloor", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
+}
+
+func (r *ArrayRangeExpression) SetCeiling(e *Expression) {
+	(*jnigi.ObjectRef)(r).CallMethod(env, "setCeiling", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
+}
+
+func (r *ArrayRangeExpression) SetMax(e *Expression) {
+	(*jnigi.ObjectRef)(r).CallMethod(env, "setMax", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
+}
+
 func (c *ConstructExpression) AddArgument(e *Expression) {
 	(*jnigi.ObjectRef)(c).CallMethod(env, "addArgument", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass))
 }
 
+func (c *ConstructExpression) AddNamedArgument(e *Expression, name string) {
+	str := NewString(name)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(c).CallMethod(env, "addArgument", nil, (*jnigi.ObjectRef)(e).Cast(ExpressionClass), str)
+}
+
 func (c *ConstructExpression) AddPrevDFG(n *Node) {
 	(*jnigi.ObjectRef)(c).CallMethod(env, "addPrevDFG", nil, (*jnigi.ObjectRef)(n).Cast(NodeClass))
 }
@@ -234,7 +278,10 @@ func (t *TupleExpression) AddMember(e *Expression) {
 }
 
 func (t *DestructureTupleExpression) SetTupleIndex(ix int) {
-	(*jnigi.ObjectRef)(t).CallMethod(env, "setTupleIndex", nil, NewInteger(ix))
+	integer := NewInteger(ix)
+	defer env.DeleteLocalRef(integer)
+
+	(*jnigi.ObjectRef)(t).CallMethod(env, "setTupleIndex", nil, integer)
 }
 
 func (t *DestructureTupleExpression) SetRefersTo(e *Expression) {
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// builtinFunction describes a predeclared function of Go. Since the types of
+// its parameters depend on the call, they are unknown. The last parameter can
+// be variadic.
+type builtinFunction struct {
+	name     string
+	params   []string
+	variadic bool
+	results  []ast.Expr
+}
+
+// builtinFunctions are the predeclared functions, which are declared in the
+// global scope, so that calls to them can be resolved. The functions new and
+// make are not included, since they are handled as expressions.
+var builtinFunctions = []builtinFunction{
+	{name: "append", params: []string{"slice", "elems"}, variadic: true, results: []ast.Expr{&ast.BadExpr{}}},
+	{name: "cap", params: []string{"v"}, results: []ast.Expr{ast.NewIdent("int")}},
+	{name: "close", params: []string{"c"}},
+	{name: "copy", params: []string{"dst", "src"}, results: []ast.Expr{ast.NewIdent("int")}},
+	{name: "delete", params: []string{"m", "key"}},
+	{name: "len", params: []string{"v"}, results: []ast.Expr{ast.NewIdent("int")}},
+	{name: "panic", params: []string{"v"}},
+	{name: "recover", results: []ast.Expr{&ast.InterfaceType{Methods: &ast.FieldList{}}}},
+}
+
+// HandleBuiltins declares the predeclared functions in the global scope. They
+// are placed in a translation unit of their own, named after the pseudo-package
+// builtin, which documents them in the standard library.
+func (this *GoLanguageFrontend) HandleBuiltins(fset *token.FileSet) (tu *cpg.TranslationUnitDeclaration, err error) {
+	defer recoverError(&err)
+
+	tu = this.NewTranslationUnitDeclaration(fset, nil, "builtin")
+
+	scope := this.GetScopeManager()
+	scope.ResetToGlobal((*cpg.Node)(tu))
+	this.CurrentTU = tu
+
+	for _, builtin := range builtinFunctions {
+		var params []*ast.Field
+		for i, name := range builtin.params {
+			var typ ast.Expr = &ast.BadExpr{}
+			if builtin.variadic && i == len(builtin.params)-1 {
+				typ = &ast.Ellipsis{Elt: typ}
+			}
+
+			params = append(params, &ast.Field{Names: []*ast.Ident{ast.NewIdent(name)}, Type: typ})
+		}
+
+		var results []*ast.Field
+		for _, typ := range builtin.results {
+			results = append(results, &ast.Field{Type: typ})
+		}
+
+		f := this.NewFunctionDeclaration(fset, nil, builtin.name)
+		(*cpg.Node)(f).SetImplicit(true)
+
+		scope.EnterScope((*cpg.Node)(f))
+		this.addFuncTypeData(f, fset, &ast.FuncDecl{
+			Name: ast.NewIdent(builtin.name),
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{List: params},
+				Results: &ast.FieldList{List: results},
+			},
+		})
+		scope.LeaveScope((*cpg.Node)(f))
+
+		err = scope.AddDeclaration((*cpg.Declaration)(f))
+		if err != nil {
+			return nil, fmt.Errorf("could not add declaration: %w", err)
+		}
+	}
+
+	return
+}
+
+// builtinName returns the name of the predeclared function, which is called by
+// callExpr, or an empty string, if it calls something else. Without type
+// information, we can only check the name of the function.
+func (this *GoLanguageFrontend) builtinName(callExpr *ast.CallExpr) string {
+	fun := callExpr.Fun
+	for {
+		paren, ok := fun.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+
+		fun = paren.X
+	}
+
+	ident, ok := fun.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+
+	if this.Package != nil {
+		if obj, ok := this.Package.TypesInfo.Uses[ident]; ok {
+			if _, ok := obj.(*types.Builtin); !ok {
+				return ""
+			}
+		}
+	}
+
+	for _, builtin := range builtinFunctions {
+		if builtin.name == ident.Name {
+			return ident.Name
+		}
+	}
+
+	return ""
+}
+
+// handleBuiltinDataFlow adds the data flow through the arguments of calls to
+// the predeclared functions, which copy data between slices
+func (this *GoLanguageFrontend) handleBuiltinDataFlow(name string, c *cpg.CallExpression, args []*cpg.Expression) {
+	switch name {
+	case "append":
+		// the resulting slice contains the elements of all arguments
+		for _, arg := range args {
+			(*cpg.Node)(c).AddPrevDFG((*cpg.Node)(arg))
+		}
+	case "copy":
+		// the elements of the source are copied into the destination
+		if len(args) == 2 {
+			(*cpg.Node)(args[0]).AddPrevDFG((*cpg.Node)(args[1]))
+		}
+	}
+}
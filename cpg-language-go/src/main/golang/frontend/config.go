@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"os"
+	"path"
+	"strings"
+)
+
+// Config holds the configuration of the frontend. It is passed as a JSON
+// string from the JVM, where it is specified by the GoConfiguration class.
+type Config struct {
+	// IncludeTests specifies, whether test files and packages, including
+	// external test packages, are translated as well. Otherwise, test files
+	// are excluded entirely.
+	IncludeTests bool `json:"includeTests"`
+
+	// BuildTags are the additional build tags, which are considered when
+	// selecting the files of a package
+	BuildTags []string `json:"buildTags"`
+
+	// GOOS and GOARCH specify the target platform, which is considered when
+	// selecting the files of a package. If empty, the platform of the host
+	// (or the one of the environment) is used.
+	GOOS   string `json:"goos"`
+	GOARCH string `json:"goarch"`
+
+	// Vendor specifies, whether dependencies are loaded from the vendor
+	// directory. This is already the default of the go command, if the vendor
+	// directory is consistent with the go.mod file.
+	Vendor bool `json:"vendor"`
+
+	// TranslateVendor specifies, whether the packages in the vendor directory
+	// are translated as well. Otherwise, they are only used to resolve imports.
+	TranslateVendor bool `json:"translateVendor"`
+
+	// TypeCheck specifies, whether the packages are type checked. Without type
+	// information, the frontend falls back to heuristics, e.g. to resolve
+	// references.
+	TypeCheck bool `json:"typeCheck"`
+
+	// SymbolLimit is the maximum number of package-level symbols, which are
+	// declared before any file content is handled. Zero means no limit.
+	SymbolLimit int `json:"symbolLimit"`
+
+	// Dependencies are the import path patterns of external packages, e.g.,
+	// "github.com/aws/aws-sdk-go-v2/...", which are loaded from the module
+	// cache in addition to the packages of the project. Only their exported
+	// declarations are translated, without any function bodies, so that calls
+	// into these packages can be resolved.
+	Dependencies []string `json:"dependencies"`
+
+	// Ignore are the glob patterns of directories, which are skipped when
+	// discovering the packages of the project. Patterns without a slash are
+	// matched against the name of a directory, others against its path
+	// relative to the top level.
+	Ignore []string `json:"ignore"`
+
+	// Files are the files, which were selected for translation by the JVM. If
+	// specified, only their packages are loaded, instead of discovering all
+	// packages of the project by walking through its directories.
+	Files []string `json:"files"`
+
+	// InterfaceDispatch specifies, whether calls of methods through an
+	// interface are linked to all implementations of the method in the loaded
+	// packages, which is done by the ResolveGoInterfaceDispatch pass. This
+	// requires type checking.
+	InterfaceDispatch bool `json:"interfaceDispatch"`
+
+	// NestedNamespaces specifies, whether the namespace of a package is nested
+	// in one namespace per segment of its path, e.g., github.com/org/repo in
+	// github.com/org and github.com, similar to the packages of Java.
+	// Otherwise, there is a single namespace named after the package path.
+	NestedNamespaces bool `json:"nestedNamespaces"`
+
+	// ReleaseSyntax specifies, whether the syntax tree of a file, as well as the
+	// type information of its nodes, is released once the file was translated.
+	// This reduces the memory consumption for large projects, since otherwise
+	// they are retained until the state of the project is reset. A file that is
+	// translated again afterwards is parsed on its own, without type information.
+	ReleaseSyntax bool `json:"releaseSyntax"`
+
+	// FollowSymlinks specifies, whether symbolic links to directories are
+	// followed when discovering the packages of the project. Links pointing
+	// outside of the top level, as well as cycles of links, are never followed.
+	FollowSymlinks bool `json:"followSymlinks"`
+
+	// LogLevel is the minimum level of the messages, which are passed to the
+	// logger of the JVM, i.e., "debug", "info", "warn", "error" or "off". Other
+	// messages are dropped before they are even formatted.
+	LogLevel string `json:"logLevel"`
+
+	// SkipGenerated specifies, whether generated files, i.e., the ones marked by
+	// a "Code generated ... DO NOT EDIT." comment, are skipped. They are still
+	// type checked, since the other files of their package depend on them, but
+	// only result in an empty translation unit.
+	SkipGenerated bool `json:"skipGenerated"`
+
+	// Overlay contains the sources of files, keyed by their path, which differ
+	// from the files on disk, e.g., the unsaved buffers of an editor. The
+	// packages are loaded with these sources instead of the files on disk.
+	Overlay map[string]string `json:"overlay"`
+}
+
+// logLevels are the levels of the log messages, in ascending order of severity
+var logLevels = map[string]int{
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+	"off":   4,
+}
+
+// DefaultIgnore are the directories, which are ignored by default. Like the go
+// command, we skip testdata as well as hidden directories and the ones starting
+// with an underscore. Additionally, node_modules is often huge and contains no
+// Go code.
+var DefaultIgnore = []string{"testdata", ".*", "_*", "node_modules"}
+
+// DefaultConfig returns the configuration, which is used if none is specified.
+func DefaultConfig() *Config {
+	return &Config{
+		TypeCheck: true,
+		LogLevel:  "info",
+		// copied, since the JSON decoder reuses the backing array of a slice
+		Ignore: append([]string(nil), DefaultIgnore...),
+	}
+}
+
+// ParseConfig parses the JSON representation of a configuration. Options that
+// are not specified keep their default value.
+func ParseConfig(s string) (*Config, error) {
+	config := DefaultConfig()
+
+	if strings.TrimSpace(s) == "" {
+		return config, nil
+	}
+
+	if err := json.Unmarshal([]byte(s), config); err != nil {
+		return nil, fmt.Errorf("could not parse configuration: %w", err)
+	}
+
+	config.LogLevel = strings.ToLower(config.LogLevel)
+	if _, ok := logLevels[config.LogLevel]; !ok {
+		return nil, fmt.Errorf("unknown log level: %s", config.LogLevel)
+	}
+
+	return config, nil
+}
+
+// Env returns the environment of the build system, which is used to load the
+// packages. It is nil, if the environment of the process can be used as is.
+func (c *Config) Env() (env []string) {
+	if c.GOOS == "" && c.GOARCH == "" {
+		return nil
+	}
+
+	env = os.Environ()
+
+	if c.GOOS != "" {
+		env = append(env, "GOOS="+c.GOOS)
+	}
+
+	if c.GOARCH != "" {
+		env = append(env, "GOARCH="+c.GOARCH)
+	}
+
+	return
+}
+
+// Platform returns the effective target platform.
+func (c *Config) Platform() (goos string, goarch string) {
+	goos, goarch = c.GOOS, c.GOARCH
+
+	if goos == "" {
+		goos = build.Default.GOOS
+	}
+
+	if goarch == "" {
+		goarch = build.Default.GOARCH
+	}
+
+	return
+}
+
+// BuildContext returns the build context of the target platform and the build
+// tags, which selects the files of a package by their build constraints.
+func (c *Config) BuildContext() build.Context {
+	ctxt := build.Default
+	ctxt.GOOS, ctxt.GOARCH = c.Platform()
+	ctxt.BuildTags = c.BuildTags
+
+	return ctxt
+}
+
+// BuildFlags returns the flags, which need to be passed to the build system in
+// order to load the packages according to the configuration.
+func (c *Config) BuildFlags() (flags []string) {
+	if len(c.BuildTags) > 0 {
+		flags = append(flags, "-tags="+strings.Join(c.BuildTags, ","))
+	}
+
+	if c.Vendor {
+		flags = append(flags, "-mod=vendor")
+	}
+
+	return
+}
+
+// IsIgnored returns, whether the directory with the given slash-separated path,
+// relative to the top level, matches one of the ignore patterns.
+func (c *Config) IsIgnored(rel string) bool {
+	for _, pattern := range c.Ignore {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReachesSymbolLimit checks, whether n declared symbols reach the symbol limit.
+// Without a configuration, there is no limit.
+func (c *Config) ReachesSymbolLimit(n int) bool {
+	return c != nil && c.SymbolLimit > 0 && n >= c.SymbolLimit
+}
+
+// IsLogged returns, whether messages with the given level are passed to the
+// logger. Without a configuration, e.g., before it was parsed, all are.
+func (c *Config) IsLogged(level string) bool {
+	if c == nil {
+		return true
+	}
+
+	return logLevels[level] >= logLevels[c.LogLevel]
+}
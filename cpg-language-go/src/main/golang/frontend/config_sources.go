@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Secrets and connection strings are usually read from the environment, the
+// command line or configuration files. Calls, which read a setting, are marked
+// by a "config" annotation, whose members contain the "source", i.e., env,
+// flag, viper or envconfig, the "key" of the setting, if it is a constant, and
+// the "type" of the struct, if the settings are read into one.
+
+// configSource describes a call, which reads a setting from a source. The key
+// of the setting and the struct, into which settings are read, are passed as
+// the arguments at keyArg and typeArg, if they are not negative.
+type configSource struct {
+	source  string
+	keyArg  int
+	typeArg int
+}
+
+// flagTypes are the types of command line flags, e.g. String in flag.String
+// and flag.StringVar, which are declared by the flag and pflag packages.
+var flagTypes = map[string]bool{}
+
+func init() {
+	for _, t := range strings.Fields(`String Int Int8 Int16 Int32 Int64 Uint Uint8 Uint16 Uint32 Uint64
+		Bool Float32 Float64 Duration Func BoolFunc Text IP IPMask IPNet Count BytesHex BytesBase64
+		StringSlice StringArray StringToString StringToInt StringToInt64 IntSlice Int32Slice
+		Int64Slice UintSlice BoolSlice Float32Slice Float64Slice DurationSlice IPSlice`) {
+		flagTypes[t] = true
+	}
+}
+
+// configSourceOf returns the configSource of fn, if it reads a setting.
+func configSourceOf(fn *types.Func) (source configSource, ok bool) {
+	lf := libraryFuncOf(fn)
+
+	switch lf.pkg {
+	case "os", "syscall":
+		if lf.recv == "" && (lf.name == "Getenv" || lf.name == "LookupEnv") {
+			return configSource{"env", 0, -1}, true
+		}
+	case "flag", "github.com/spf13/pflag":
+		if lf.recv != "" && lf.recv != "FlagSet" {
+			return
+		}
+
+		keyArg, found := flagKeyArg(lf.name)
+
+		// pflag's variants with a shorthand, e.g. StringVarP(&s, name,
+		// shorthand, value, usage), while IP itself is no variant of I
+		if !found && lf.pkg == "github.com/spf13/pflag" && strings.HasSuffix(lf.name, "P") {
+			keyArg, found = flagKeyArg(strings.TrimSuffix(lf.name, "P"))
+		}
+
+		if found {
+			return configSource{"flag", keyArg, -1}, true
+		}
+	case "github.com/spf13/viper":
+		if lf.recv != "" && lf.recv != "Viper" {
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(lf.name, "Get"), lf.name == "IsSet", lf.name == "Sub":
+			return configSource{"viper", 0, -1}, true
+		case lf.name == "UnmarshalKey":
+			return configSource{"viper", 0, 1}, true
+		case lf.name == "Unmarshal", lf.name == "UnmarshalExact":
+			return configSource{"viper", -1, 0}, true
+		}
+	case "github.com/kelseyhightower/envconfig":
+		// the prefix of the environment variables, e.g. Process("app", &spec)
+		if lf.recv == "" && (lf.name == "Process" || lf.name == "MustProcess") {
+			return configSource{"envconfig", 0, 1}, true
+		}
+	}
+
+	return
+}
+
+// flagKeyArg returns the argument, which holds the name of the flag declared by
+// the function with the given name, e.g. String(name, value, usage) or
+// StringVar(&s, name, value, usage), if it declares a flag.
+func flagKeyArg(name string) (arg int, ok bool) {
+	switch {
+	case name == "Var":
+		return 1, true
+	case strings.HasSuffix(name, "Var") && flagTypes[strings.TrimSuffix(name, "Var")]:
+		return 1, true
+	case flagTypes[name]:
+		return 0, true
+	}
+
+	return
+}
+
+// handleConfigCall adds the "config" annotation to the call c of fn, if it
+// reads a setting.
+func (this *GoLanguageFrontend) handleConfigCall(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func) {
+	source, ok := configSourceOf(fn)
+	if !ok {
+		return
+	}
+
+	values := [][2]string{{"source", source.source}}
+
+	if source.keyArg >= 0 && source.keyArg < len(callExpr.Args) {
+		if key, ok := this.stringConstant(callExpr.Args[source.keyArg]); ok {
+			values = append(values, [2]string{"key", key})
+		}
+	}
+
+	if source.typeArg >= 0 && source.typeArg < len(callExpr.Args) && this.Package != nil && this.Package.TypesInfo != nil {
+		if t := this.Package.TypesInfo.TypeOf(callExpr.Args[source.typeArg]); t != nil {
+			values = append(values, [2]string{"type", types.TypeString(t, nil)})
+		}
+	}
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{this.newStringAnnotation(fset, "config", values)})
+}
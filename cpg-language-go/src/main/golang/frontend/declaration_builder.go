@@ -55,7 +55,7 @@ func (frontend *GoLanguageFrontend) NewMethodDeclaration(fset *token.FileSet, as
 }
 
 func (frontend *GoLanguageFrontend) NewRecordDeclaration(fset *token.FileSet, astNode ast.Node, name string, kind string) *cpg.RecordDeclaration {
-	return (*cpg.RecordDeclaration)(frontend.NewDeclaration("RecordDeclaration", fset, astNode, name, cpg.NewString(kind)))
+	return (*cpg.RecordDeclaration)(frontend.NewDeclaration("RecordDeclaration", fset, astNode, name, kind))
 }
 
 func (frontend *GoLanguageFrontend) NewVariableDeclaration(fset *token.FileSet, astNode ast.Node, name string) *cpg.VariableDeclaration {
@@ -70,23 +70,27 @@ func (frontend *GoLanguageFrontend) NewFieldDeclaration(fset *token.FileSet, ast
 	return (*cpg.FieldDeclaration)(frontend.NewDeclaration("FieldDeclaration", fset, astNode, name))
 }
 
-func (frontend *GoLanguageFrontend) NewDeclaration(typ string, fset *token.FileSet, astNode ast.Node, name string, args ...any) *jnigi.ObjectRef {
-	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.DeclarationsPackage, typ))
+func (frontend *GoLanguageFrontend) NewProblemDeclaration(fset *token.FileSet, astNode ast.Node, problem string) *cpg.Declaration {
+	return (*cpg.Declaration)(frontend.NewDeclaration("ProblemDeclaration", fset, astNode, problem))
+}
+
+// NewTypedefDeclaration creates a typedef, which is named after its alias
+// rather than taking a name of its own, like the other declarations do.
+func (frontend *GoLanguageFrontend) NewTypedefDeclaration(fset *token.FileSet, astNode ast.Node, targetType *cpg.Type, alias *cpg.Type) *cpg.TypedefDeclaration {
+	var node = jnigi.NewObjectRef(cpg.TypedefDeclarationClass)
 
-	// Prepend the frontend and the name as the receiver and the first argument
-	args = append([]any{frontend.Cast(MetadataProviderClass), cpg.NewString(name)}, args...)
+	frontend.setMetadata(fset, astNode)
+	frontend.callBuilder("DeclarationBuilderKt", "TypedefDeclaration", node, targetType.Cast(cpg.TypeClass), alias.Cast(cpg.TypeClass))
 
-	err := env.CallStaticMethod(
-		cpg.GraphPackage+"/DeclarationBuilderKt",
-		fmt.Sprintf("new%s", typ), node,
-		args...,
-	)
-	if err != nil {
-		panic(err)
-	}
+	return (*cpg.TypedefDeclaration)(node)
+}
+
+func (frontend *GoLanguageFrontend) NewDeclaration(typ string, fset *token.FileSet, astNode ast.Node, name string, args ...any) *jnigi.ObjectRef {
+	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.DeclarationsPackage, typ))
 
-	updateCode(fset, (*cpg.Node)(node), astNode)
-	updateLocation(fset, (*cpg.Node)(node), astNode)
+	// the name is always the first argument
+	frontend.setMetadata(fset, astNode)
+	frontend.callBuilder("DeclarationBuilderKt", typ, node, append([]any{name}, args...)...)
 
 	return node
 }
@@ -0,0 +1,334 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// directive is a directive comment, e.g. //go:generate, with its name and
+// arguments, see parseDirective.
+type directive struct {
+	comment *ast.Comment
+	name    string
+	args    []string
+}
+
+// fileDirectives are the directives of a file, which are collected once per
+// file, instead of scanning all its comments for each declaration.
+type fileDirectives struct {
+	file *ast.File
+
+	// the directives, keyed by the top-level declaration they belong to, see
+	// commentOwners, or nil for the ones of the file itself
+	owned map[ast.Decl][]directive
+
+	// the go:linkname directives, keyed by the local name of the symbol
+	linknames map[string]directive
+}
+
+// fileDirectives returns the directives of the current file, which are
+// collected on first use.
+func (this *GoLanguageFrontend) fileDirectives(fset *token.FileSet) *fileDirectives {
+	if this.directives != nil && this.directives.file == this.File {
+		return this.directives
+	}
+
+	d := &fileDirectives{
+		file:      this.File,
+		owned:     map[ast.Decl][]directive{},
+		linknames: map[string]directive{},
+	}
+
+	owners := newCommentOwners(fset, this.File)
+
+	for _, group := range this.File.Comments {
+		for _, c := range group.List {
+			name, args, ok := parseDirective(c.Text)
+			if !ok {
+				continue
+			}
+
+			// only the first directive for a symbol is used
+			if name == "go:linkname" && len(args) > 0 {
+				if _, found := d.linknames[args[0]]; !found {
+					d.linknames[args[0]] = directive{c, name, args}
+				}
+			}
+
+			// the build constraint of the file is already part of its "build"
+			// annotation, see handleBuildConstraints
+			if name == "go:build" && c.Pos() < this.File.Package {
+				continue
+			}
+
+			owner := owners.owner(group)
+			d.owned[owner] = append(d.owned[owner], directive{c, name, args})
+		}
+	}
+
+	this.directives = d
+
+	return d
+}
+
+// handleDirectives creates an annotation for each directive comment, such as
+// //go:generate or //nolint, that belongs to decl. If decl is nil, the
+// directives of the file itself are handled, i.e., the ones that do not belong
+// to any declaration.
+func (this *GoLanguageFrontend) handleDirectives(fset *token.FileSet, decl ast.Decl) (annotations []*cpg.Annotation) {
+	if this.File == nil {
+		return
+	}
+
+	for _, d := range this.fileDirectives(fset).owned[decl] {
+		annotations = append(annotations, this.newDirectiveAnnotation(fset, d.comment, d.name, d.args))
+	}
+
+	return
+}
+
+// newDirectiveAnnotation creates an annotation named after the directive. Its
+// arguments are stored as a list of strings in the "arguments" member.
+func (this *GoLanguageFrontend) newDirectiveAnnotation(fset *token.FileSet, c *ast.Comment, name string, args []string) *cpg.Annotation {
+	list := this.NewInitializerListExpression(fset, c)
+	for _, arg := range args {
+		lit := this.NewLiteral(fset, c, cpg.NewString(arg), this.parseType("string"))
+
+		list.AddInitializer((*cpg.Expression)(lit))
+	}
+
+	a := this.NewAnnotation(fset, c, name)
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, c, "arguments", (*cpg.Expression)(list)),
+	})
+
+	return a
+}
+
+// handleLinkname creates a "linkname" annotation for the package-level symbol
+// with the given name, if a //go:linkname directive anywhere in the file refers
+// to it. Its member "target" contains the symbol in another package, to which
+// the local name is linked, if specified. Otherwise, the directive only marks the
+// symbol as accessible from other packages.
+func (this *GoLanguageFrontend) handleLinkname(fset *token.FileSet, name string) *cpg.Annotation {
+	if this.File == nil {
+		return nil
+	}
+
+	d, ok := this.fileDirectives(fset).linknames[name]
+	if !ok {
+		return nil
+	}
+
+	a := this.NewAnnotation(fset, d.comment, "linkname")
+
+	if len(d.args) > 1 {
+		lit := this.NewLiteral(fset, d.comment, cpg.NewString(d.args[1]), this.parseType("string"))
+
+		a.SetMembers([]*cpg.AnnotationMember{
+			this.NewAnnotationMember(fset, d.comment, "target", (*cpg.Expression)(lit)),
+		})
+	}
+
+	return a
+}
+
+// commentOwners determines the top-level declarations of a file, to which its
+// comment groups belong, see owner. The declarations are indexed once per
+// file, by their documentation and by the line they end on.
+type commentOwners struct {
+	fset  *token.FileSet
+	decls []ast.Decl
+
+	// the declarations, keyed by their documentation
+	docs map[*ast.CommentGroup]ast.Decl
+
+	// the first declaration, which ends on a line, keyed by the line
+	endLines map[int]ast.Decl
+
+	// the index of each declaration within decls
+	index map[ast.Decl]int
+}
+
+func newCommentOwners(fset *token.FileSet, file *ast.File) *commentOwners {
+	o := &commentOwners{
+		fset:     fset,
+		decls:    file.Decls,
+		docs:     map[*ast.CommentGroup]ast.Decl{},
+		endLines: map[int]ast.Decl{},
+		index:    map[ast.Decl]int{},
+	}
+
+	for i, decl := range file.Decls {
+		o.index[decl] = i
+
+		switch v := decl.(type) {
+		case *ast.FuncDecl:
+			if v.Doc != nil {
+				o.docs[v.Doc] = decl
+			}
+		case *ast.GenDecl:
+			if v.Doc != nil {
+				o.docs[v.Doc] = decl
+			}
+		}
+
+		line := fset.Position(decl.End()).Line
+		if _, found := o.endLines[line]; !found {
+			o.endLines[line] = decl
+		}
+	}
+
+	return o
+}
+
+// owner returns the top-level declaration that group belongs to, i.e., whose
+// documentation it is, that encloses it or that ends on the line the group
+// starts on. If several declarations qualify, the first one in the file wins.
+// Otherwise, the group belongs to the file itself and nil is returned.
+func (o *commentOwners) owner(group *ast.CommentGroup) (owner ast.Decl) {
+	// the declarations are sorted, so the first one ending after the group is
+	// the only one, which might enclose it
+	var candidates = []ast.Decl{o.docs[group]}
+
+	i := sort.Search(len(o.decls), func(i int) bool {
+		return o.decls[i].End() > group.Pos()
+	})
+	if i < len(o.decls) && o.decls[i].Pos() <= group.Pos() {
+		candidates = append(candidates, o.decls[i])
+	}
+
+	if decl := o.endLines[o.fset.Position(group.Pos()).Line]; decl != nil && group.Pos() >= decl.End() {
+		candidates = append(candidates, decl)
+	}
+
+	for _, decl := range candidates {
+		if decl != nil && (owner == nil || o.index[decl] < o.index[owner]) {
+			owner = decl
+		}
+	}
+
+	return
+}
+
+// parseDirective returns the name and arguments of a directive comment, i.e., a
+// line comment without a space after the slashes, such as //go:noinline,
+// //go:generate or //nolint:errcheck, or a legacy // +build constraint.
+func parseDirective(text string) (name string, args []string, ok bool) {
+	if text == "// +build" || strings.HasPrefix(text, "// +build ") {
+		return "+build", strings.Fields(text[len("// +build"):]), true
+	}
+
+	if !strings.HasPrefix(text, "//") {
+		return "", nil, false
+	}
+
+	text = text[2:]
+	if text == "" || text[0] == ' ' || text[0] == '\t' {
+		return "", nil, false
+	}
+
+	name, rest, _ := strings.Cut(text, " ")
+
+	switch {
+	case name == "nolint" || strings.HasPrefix(name, "nolint:"):
+		// the linters are separated by commas, an explanation may follow
+		// as another comment
+		if _, linters, found := strings.Cut(name, ":"); found {
+			args = strings.Split(linters, ",")
+		}
+
+		return "nolint", args, true
+	case name == "line" || name == "export" || name == "extern":
+		return name, strings.Fields(rest), true
+	case isDirectiveName(name):
+		// the constraint is an expression, which should not be split
+		if name == "go:build" {
+			if rest = strings.TrimSpace(rest); rest != "" {
+				args = []string{rest}
+			}
+
+			return name, args, true
+		}
+
+		return name, splitDirectiveArgs(rest), true
+	}
+
+	return "", nil, false
+}
+
+// isDirectiveName checks, whether name has the form of a directive that is
+// reserved for tools, e.g. go:embed, i.e., a namespace and a name separated by
+// a colon, the same way go/ast recognizes them.
+func isDirectiveName(name string) bool {
+	ns, _, found := strings.Cut(name, ":")
+	if !found || ns == "" || len(ns) == len(name)-1 {
+		return false
+	}
+
+	for _, r := range ns + name[len(ns)+1:len(ns)+2] {
+		if !('a' <= r && r <= 'z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitDirectiveArgs splits the arguments of a directive at spaces, keeping
+// double-quoted strings together, as go generate does.
+func splitDirectiveArgs(s string) (args []string) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return
+		}
+
+		if s[0] == '"' {
+			if quoted, err := strconv.QuotedPrefix(s); err == nil {
+				arg, _ := strconv.Unquote(quoted)
+				args = append(args, arg)
+				s = s[len(quoted):]
+
+				continue
+			}
+		}
+
+		end := strings.IndexAny(s, " \t")
+		if end == -1 {
+			end = len(s)
+		}
+
+		args = append(args, s[:end])
+		s = s[end:]
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Data leaves a process, after it is serialized, e.g., by json.Marshal, and
+// sent, e.g., by an HTTP client. Calls, which serialize a value, are marked by
+// a "serialization" annotation, whose members contain the "format" and the
+// "type" of the serialized value. Calls, which send data out of the process,
+// are marked by an "egress" annotation, whose members contain the "kind" of the
+// channel, e.g. http, and the "type" of the sent value, if there is one. Both
+// are the sinks of a data-flow analysis.
+
+// libraryCall describes the meaning of a call of a library function and the
+// index of its argument, which holds the value of interest, if there is one.
+type libraryCall struct {
+	kind string
+	arg  int
+}
+
+// serializationCalls are the functions, which serialize a value, by the
+// format they serialize to.
+var serializationCalls = newLibraryCalls([]libraryCallSpec{
+	{"json", "encoding/json", []string{""}, []string{"Marshal", "MarshalIndent"}, 0},
+	{"json", "encoding/json", []string{"Encoder"}, []string{"Encode"}, 0},
+	{"xml", "encoding/xml", []string{""}, []string{"Marshal", "MarshalIndent"}, 0},
+	{"xml", "encoding/xml", []string{"Encoder"}, []string{"Encode", "EncodeElement"}, 0},
+	{"gob", "encoding/gob", []string{"Encoder"}, []string{"Encode"}, 0},
+	{"proto", "google.golang.org/protobuf/proto", []string{"", "MarshalOptions"}, []string{"Marshal"}, 0},
+	{"proto", "github.com/golang/protobuf/proto", []string{""}, []string{"Marshal"}, 0},
+	{"yaml", "gopkg.in/yaml.v2", []string{""}, []string{"Marshal"}, 0},
+	{"yaml", "gopkg.in/yaml.v3", []string{""}, []string{"Marshal"}, 0},
+	{"yaml", "gopkg.in/yaml.v3", []string{"Encoder"}, []string{"Encode"}, 0},
+	{"yaml", "sigs.k8s.io/yaml", []string{""}, []string{"Marshal"}, 0},
+})
+
+// egressCalls are the functions, which send data out of the process, by the
+// kind of the channel they send it through. A negative argument means, that no
+// value is sent, but, e.g., only a request to a URL.
+var egressCalls = newLibraryCalls([]libraryCallSpec{
+	{"http", "net/http", []string{"Client"}, []string{"Do"}, 0},
+	{"http", "net/http", []string{"", "Client"}, []string{"Get", "Head"}, -1},
+	{"http", "net/http", []string{"", "Client"}, []string{"Post"}, 2},
+	{"http", "net/http", []string{"", "Client"}, []string{"PostForm"}, 1},
+	{"smtp", "net/smtp", []string{""}, []string{"SendMail"}, 4},
+	{"s3", "github.com/aws/aws-sdk-go-v2/service/s3", []string{"Client"}, []string{"PutObject"}, 1},
+	{"s3", "github.com/aws/aws-sdk-go/service/s3", []string{"S3"}, []string{"PutObject"}, 0},
+	{"s3", "github.com/aws/aws-sdk-go/service/s3", []string{"S3"}, []string{"PutObjectWithContext"}, 1},
+	{"s3", "github.com/aws/aws-sdk-go/service/s3/s3manager", []string{"Uploader"}, []string{"Upload"}, 0},
+	{"s3", "github.com/aws/aws-sdk-go/service/s3/s3manager", []string{"Uploader"}, []string{"UploadWithContext"}, 1},
+	{"kafka", "github.com/segmentio/kafka-go", []string{"Writer"}, []string{"WriteMessages"}, 1},
+	{"kafka", "github.com/Shopify/sarama", []string{"SyncProducer"}, []string{"SendMessage", "SendMessages"}, 0},
+	{"kafka", "github.com/IBM/sarama", []string{"SyncProducer"}, []string{"SendMessage", "SendMessages"}, 0},
+	{"kafka", "github.com/confluentinc/confluent-kafka-go/kafka", []string{"Producer"}, []string{"Produce"}, 0},
+})
+
+// libraryCallSpec specifies the libraryCall of the functions with the given
+// names, which are declared in a package and with the receivers, where an
+// empty receiver means a package-level function.
+type libraryCallSpec struct {
+	kind  string
+	pkg   string
+	recvs []string
+	names []string
+	arg   int
+}
+
+func newLibraryCalls(specs []libraryCallSpec) map[libraryFunc]libraryCall {
+	var calls = map[libraryFunc]libraryCall{}
+
+	for _, spec := range specs {
+		for _, recv := range spec.recvs {
+			for _, name := range spec.names {
+				calls[libraryFunc{spec.pkg, recv, name}] = libraryCall{spec.kind, spec.arg}
+			}
+		}
+	}
+
+	return calls
+}
+
+// handleEgressCall adds the "serialization" or "egress" annotation to the call
+// c of fn, if it serializes a value or sends data out of the process.
+func (this *GoLanguageFrontend) handleEgressCall(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func) {
+	lf := libraryFuncOf(fn)
+
+	if call, ok := serializationCalls[lf]; ok {
+		(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{this.newLibraryCallAnnotation(fset, "serialization", "format", call, callExpr)})
+	}
+
+	if call, ok := egressCalls[lf]; ok {
+		(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{this.newLibraryCallAnnotation(fset, "egress", "kind", call, callExpr)})
+	}
+}
+
+// newLibraryCallAnnotation creates the annotation with the given name, whose
+// members contain the kind of the call, by the name kindMember, and the type
+// of its argument of interest.
+func (this *GoLanguageFrontend) newLibraryCallAnnotation(fset *token.FileSet, name string, kindMember string, call libraryCall, callExpr *ast.CallExpr) *cpg.Annotation {
+	values := [][2]string{{kindMember, call.kind}}
+
+	if call.arg >= 0 && call.arg < len(callExpr.Args) && this.Package != nil && this.Package.TypesInfo != nil {
+		if t := this.Package.TypesInfo.TypeOf(callExpr.Args[call.arg]); t != nil {
+			values = append(values, [2]string{"type", types.TypeString(t, nil)})
+		}
+	}
+
+	return this.newStringAnnotation(fset, name, values)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+)
+
+// A package-level variable of type string, []byte or embed.FS can be
+// initialized with the content of files of the package directory, which are
+// selected by the patterns of the //go:embed directives before its declaration.
+// Since the content is not part of the source code, the variable is marked by
+// an "embed" annotation, whose member "patterns" contains the patterns, and
+// initialized by an implicit literal without a value, which is located at the
+// directives. This way, the data flows from embedded files end there.
+
+// embedDirectives returns the //go:embed directives of the variable declared by
+// valueSpec, as well as their patterns. The directives are part of the doc
+// comment of the spec, or of its declaration, if it is not grouped.
+func (this *GoLanguageFrontend) embedDirectives(valueSpec *ast.ValueSpec) (directives []*ast.Comment, patterns []string) {
+	if this.File == nil {
+		return
+	}
+
+	doc := valueSpec.Doc
+	if genDecl := this.enclosingGenDecl(valueSpec); doc == nil && genDecl != nil && !genDecl.Lparen.IsValid() {
+		doc = genDecl.Doc
+	}
+
+	if doc == nil {
+		return
+	}
+
+	for _, c := range doc.List {
+		if name, args, ok := parseDirective(c.Text); ok && name == "go:embed" {
+			directives = append(directives, c)
+			patterns = append(patterns, args...)
+		}
+	}
+
+	return
+}
+
+// handleEmbed annotates the variable d, declared by valueSpec with the type t,
+// if it is initialized with embedded files, and sets its implicit initializer.
+func (this *GoLanguageFrontend) handleEmbed(fset *token.FileSet, valueSpec *ast.ValueSpec, d *cpg.VariableDeclaration, t *cpg.Type) {
+	// only a single variable without any value can be embedded
+	if len(valueSpec.Names) != 1 || len(valueSpec.Values) != 0 || t == nil {
+		return
+	}
+
+	directives, patterns := this.embedDirectives(valueSpec)
+	if len(directives) == 0 {
+		return
+	}
+
+	list := this.NewInitializerListExpression(fset, directives[0])
+	for _, pattern := range patterns {
+		lit := this.NewLiteral(fset, directives[0], cpg.NewString(pattern), this.parseType("string"))
+
+		list.AddInitializer((*cpg.Expression)(lit))
+	}
+
+	a := this.NewAnnotation(fset, directives[0], "embed")
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, directives[0], "patterns", (*cpg.Expression)(list)),
+	})
+
+	(*cpg.Node)(d).AddAnnotations([]*cpg.Annotation{a})
+
+	init := this.NewLiteral(fset, directives[0], nil, t)
+	(*cpg.Node)(init).SetImplicit(true)
+
+	err := d.SetInitializer((*cpg.Expression)(init))
+	if err != nil {
+		panic(err)
+	}
+}
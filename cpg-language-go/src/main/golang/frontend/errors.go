@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Errors are ordinary values in Go, which are returned as (usually the last)
+// result of a function and checked by its caller, typically with
+//
+//	if err != nil {
+//		return nil, err
+//	}
+//
+// To tell the error paths from the happy path, they are marked as follows:
+//   - a function with results of type error has an "errorResults" annotation,
+//     whose "index" members are the positions of these results,
+//   - an if statement, which compares an error with nil, has an "errorCheck"
+//     annotation, whose members contain the checked "error" and the "branch"
+//     ("then" or "else"), which is taken if there is an error,
+//   - a return statement within this branch, which returns the checked error
+//     or wraps it, e.g., with fmt.Errorf("...: %w", err), has an
+//     "errorPropagation" annotation, whose members contain the "index" of the
+//     result and whether the error is "wrapped".
+//
+// Together with the data flow from the returned values to the destructured
+// results of a call, this allows following the propagation of an error.
+
+// errorType is the predeclared error interface.
+var errorType = types.Universe.Lookup("error").Type()
+
+// handleErrorResults adds the "errorResults" annotation to the function f with
+// the type typ, if any of its results is of type error.
+func (this *GoLanguageFrontend) handleErrorResults(fset *token.FileSet, f *cpg.FunctionDeclaration, typ types.Type) {
+	sig, ok := typ.(*types.Signature)
+	if !ok {
+		return
+	}
+
+	var members []*cpg.AnnotationMember
+	for i := 0; i < sig.Results().Len(); i++ {
+		if !types.Identical(sig.Results().At(i).Type(), errorType) {
+			continue
+		}
+
+		lit := this.NewLiteral(fset, nil, cpg.NewInteger(i), this.parseType("int"))
+		members = append(members, this.NewAnnotationMember(fset, nil, "index", (*cpg.Expression)(lit)))
+	}
+
+	if len(members) == 0 {
+		return
+	}
+
+	a := this.NewAnnotation(fset, nil, "errorResults")
+	a.SetMembers(members)
+
+	(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{a})
+}
+
+// errorCheck returns the error, which is compared with nil by cond, e.g., in
+// err != nil, and the branch of the if statement, which is taken if there is an
+// error.
+func (this *GoLanguageFrontend) errorCheck(cond ast.Expr) (obj types.Object, branch string, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil, "", false
+	}
+
+	binExpr, isBinary := unparen(cond).(*ast.BinaryExpr)
+	if !isBinary {
+		return nil, "", false
+	}
+
+	switch binExpr.Op {
+	case token.NEQ:
+		branch = "then"
+	case token.EQL:
+		branch = "else"
+	default:
+		return nil, "", false
+	}
+
+	x, y := unparen(binExpr.X), unparen(binExpr.Y)
+	if this.Package.TypesInfo.Types[x].IsNil() {
+		x, y = y, x
+	}
+
+	ident, isIdent := x.(*ast.Ident)
+	if !isIdent || !this.Package.TypesInfo.Types[y].IsNil() {
+		return nil, "", false
+	}
+
+	obj = this.Package.TypesInfo.ObjectOf(ident)
+	if obj == nil || !types.Identical(obj.Type(), errorType) {
+		return nil, "", false
+	}
+
+	return obj, branch, true
+}
+
+// newErrorCheckAnnotation creates the "errorCheck" annotation of an if
+// statement, which checks the error obj.
+func (this *GoLanguageFrontend) newErrorCheckAnnotation(fset *token.FileSet, obj types.Object, branch string) *cpg.Annotation {
+	return this.newStringAnnotation(fset, "errorCheck", [][2]string{
+		{"error", obj.Name()},
+		{"branch", branch},
+	})
+}
+
+// handleErrorPropagation adds an "errorPropagation" annotation to the return
+// statement r for each of its results, which returns an error checked by an
+// enclosing if statement.
+func (this *GoLanguageFrontend) handleErrorPropagation(fset *token.FileSet, r *cpg.ReturnStatement, returnStmt *ast.ReturnStmt) {
+	if len(this.errorChecks) == 0 {
+		return
+	}
+
+	for i, res := range returnStmt.Results {
+		wrapped, ok := this.propagatesError(res)
+		if !ok {
+			continue
+		}
+
+		index := this.NewLiteral(fset, nil, cpg.NewInteger(i), this.parseType("int"))
+		isWrapped := this.NewLiteral(fset, nil, cpg.NewBoolean(wrapped), this.parseType("bool"))
+
+		a := this.NewAnnotation(fset, res, "errorPropagation")
+		a.SetMembers([]*cpg.AnnotationMember{
+			this.NewAnnotationMember(fset, nil, "index", (*cpg.Expression)(index)),
+			this.NewAnnotationMember(fset, nil, "wrapped", (*cpg.Expression)(isWrapped)),
+		})
+
+		(*cpg.Node)(r).AddAnnotations([]*cpg.Annotation{a})
+	}
+}
+
+// propagatesError returns, whether the returned value res is one of the
+// checked errors, or an error, which wraps one of them, e.g., created by
+// fmt.Errorf("...: %w", err).
+func (this *GoLanguageFrontend) propagatesError(res ast.Expr) (wrapped bool, ok bool) {
+	switch v := unparen(res).(type) {
+	case *ast.Ident:
+		return false, this.isCheckedError(v)
+	case *ast.CallExpr:
+		t := this.Package.TypesInfo.TypeOf(v)
+		if t == nil || !types.Implements(t, errorType.Underlying().(*types.Interface)) {
+			return false, false
+		}
+
+		for _, arg := range v.Args {
+			if ident, isIdent := unparen(arg).(*ast.Ident); isIdent && this.isCheckedError(ident) {
+				return true, true
+			}
+		}
+	}
+
+	return false, false
+}
+
+// isCheckedError returns, whether ident refers to an error checked by an
+// enclosing if statement.
+func (this *GoLanguageFrontend) isCheckedError(ident *ast.Ident) bool {
+	obj := this.Package.TypesInfo.ObjectOf(ident)
+
+	for _, checked := range this.errorChecks {
+		if obj == checked {
+			return true
+		}
+	}
+
+	return false
+}
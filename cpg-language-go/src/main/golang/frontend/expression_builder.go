@@ -39,7 +39,7 @@ func (frontend *GoLanguageFrontend) NewTupleExpression(fset *token.FileSet, astN
 }
 
 func (frontend *GoLanguageFrontend) NewProblemExpression(fset *token.FileSet, astNode ast.Node, problem string) *cpg.Expression {
-	return (*cpg.Expression)(frontend.NewExpression("ProblemExpression", fset, astNode, cpg.NewString(problem)))
+	return (*cpg.Expression)(frontend.NewExpression("ProblemExpression", fset, astNode, problem))
 }
 
 func (frontend *GoLanguageFrontend) NewDestructureTupleExpression(fset *token.FileSet, astNode ast.Node) *cpg.DestructureTupleExpression {
@@ -55,13 +55,13 @@ func (frontend *GoLanguageFrontend) NewCastExpression(fset *token.FileSet, astNo
 }
 
 func (frontend *GoLanguageFrontend) NewMemberExpression(fset *token.FileSet, astNode ast.Node, name string, base cpg.Castable) *cpg.MemberExpression {
-	return (*cpg.MemberExpression)(frontend.NewExpression("MemberExpression", fset, astNode, cpg.NewString(name), base.Cast(cpg.ExpressionClass)))
+	return (*cpg.MemberExpression)(frontend.NewExpression("MemberExpression", fset, astNode, name, base.Cast(cpg.ExpressionClass)))
 }
 
 func (frontend *GoLanguageFrontend) NewMemberCallExpression(fset *token.FileSet, astNode ast.Node, name string, fqn string, base *cpg.Expression, member *cpg.Node) *cpg.MemberCallExpression {
 	return (*cpg.MemberCallExpression)(frontend.NewExpression("MemberCallExpression", fset, astNode,
-		cpg.NewString(name),
-		cpg.NewString(fqn),
+		name,
+		fqn,
 		base.Cast(cpg.ExpressionClass),
 		member.Cast(cpg.NodeClass),
 	))
@@ -79,6 +79,15 @@ func (frontend *GoLanguageFrontend) NewArraySubscriptionExpression(fset *token.F
 	return (*cpg.ArraySubscriptionExpression)(frontend.NewExpression("ArraySubscriptionExpression", fset, astNode))
 }
 
+// NewArrayRangeExpression creates a range with the given bounds, either of
+// which may be nil.
+func (frontend *GoLanguageFrontend) NewArrayRangeExpression(fset *token.FileSet, astNode ast.Node, floor *cpg.Expression, ceiling *cpg.Expression) *cpg.ArrayRangeExpression {
+	return (*cpg.ArrayRangeExpression)(frontend.NewExpression("ArrayRangeExpression", fset, astNode,
+		expressionOrNull(floor),
+		expressionOrNull(ceiling),
+	))
+}
+
 func (frontend *GoLanguageFrontend) NewConstructExpression(fset *token.FileSet, astNode ast.Node) *cpg.ConstructExpression {
 	return (*cpg.ConstructExpression)(frontend.NewExpression("ConstructExpression", fset, astNode))
 }
@@ -93,52 +102,57 @@ func (frontend *GoLanguageFrontend) NewLambdaExpression(fset *token.FileSet, ast
 
 func (frontend *GoLanguageFrontend) NewBinaryOperator(fset *token.FileSet, astNode ast.Node, opCode string) *cpg.BinaryOperator {
 	return (*cpg.BinaryOperator)(frontend.NewExpression("BinaryOperator", fset, astNode,
-		cpg.NewString(opCode),
+		opCode,
 	))
 }
 
 func (frontend *GoLanguageFrontend) NewUnaryOperator(fset *token.FileSet, astNode ast.Node, opCode string, postfix bool, prefix bool) *cpg.UnaryOperator {
 	return (*cpg.UnaryOperator)(frontend.NewExpression("UnaryOperator", fset, astNode,
-		cpg.NewString(opCode),
+		opCode,
 		postfix, prefix,
 	))
 }
 
+// NewLiteral creates a literal with the given value, which is usually created
+// just for this purpose. Therefore, its local reference is released afterwards.
 func (frontend *GoLanguageFrontend) NewLiteral(fset *token.FileSet, astNode ast.Node, value cpg.Castable, typ *cpg.Type) *cpg.Literal {
 	if value == nil {
 		value = jnigi.NewObjectRef("java/lang/Object")
 	} else {
 		value = value.Cast("java/lang/Object")
+		defer env.DeleteLocalRef(value.(*jnigi.ObjectRef))
 	}
 
 	return (*cpg.Literal)(frontend.NewExpression("Literal", fset, astNode, value, typ.Cast(cpg.TypeClass)))
 }
 
 func (frontend *GoLanguageFrontend) NewDeclaredReferenceExpression(fset *token.FileSet, astNode ast.Node, name string) *cpg.DeclaredReferenceExpression {
-	return (*cpg.DeclaredReferenceExpression)(frontend.NewExpression("DeclaredReferenceExpression", fset, astNode, cpg.NewString(name)))
+	return (*cpg.DeclaredReferenceExpression)(frontend.NewExpression("DeclaredReferenceExpression", fset, astNode, name))
 }
 
 func (frontend *GoLanguageFrontend) NewKeyValueExpression(fset *token.FileSet, astNode ast.Node) *cpg.KeyValueExpression {
 	return (*cpg.KeyValueExpression)(frontend.NewExpression("KeyValueExpression", fset, astNode))
 }
 
-func (frontend *GoLanguageFrontend) NewExpression(typ string, fset *token.FileSet, astNode ast.Node, args ...any) *jnigi.ObjectRef {
-	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.ExpressionsPackage, typ))
-
-	// Prepend the frontend as the receiver
-	args = append([]any{frontend.Cast(cpg.GraphPackage + "/MetadataProvider")}, args...)
+func (frontend *GoLanguageFrontend) NewTypeExpression(fset *token.FileSet, astNode ast.Node, name string, typ *cpg.Type) *cpg.TypeExpression {
+	return (*cpg.TypeExpression)(frontend.NewExpression("TypeExpression", fset, astNode, name, typ.Cast(cpg.TypeClass)))
+}
 
-	err := env.CallStaticMethod(
-		cpg.GraphPackage+"/ExpressionBuilderKt",
-		fmt.Sprintf("new%s", typ), node,
-		args...,
-	)
-	if err != nil {
-		panic(err)
+// expressionOrNull returns e as an argument of a builder, which expects an
+// expression that is null if e is nil.
+func expressionOrNull(e *cpg.Expression) *jnigi.ObjectRef {
+	if e == nil {
+		return jnigi.NewObjectRef(cpg.ExpressionClass)
 	}
 
-	updateCode(fset, (*cpg.Node)(node), astNode)
-	updateLocation(fset, (*cpg.Node)(node), astNode)
+	return e.Cast(cpg.ExpressionClass)
+}
+
+func (frontend *GoLanguageFrontend) NewExpression(typ string, fset *token.FileSet, astNode ast.Node, args ...any) *jnigi.ObjectRef {
+	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.ExpressionsPackage, typ))
+
+	frontend.setMetadata(fset, astNode)
+	frontend.callBuilder("ExpressionBuilderKt", typ, node, args...)
 
 	return node
 }
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/types"
+)
+
+// qualifier returns the qualifier of the fully qualified name of a
+// package-level object, which is the path of its package. Methods are
+// qualified by their receiver type as well, using the notation of the Go
+// runtime, e.g. github.com/x/y.(*Server) for the method Handle with a pointer
+// receiver. An empty string is returned for all other objects.
+func qualifier(obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+
+	path := obj.Pkg().Path()
+
+	if fn, ok := obj.(*types.Func); ok {
+		recv := fn.Type().(*types.Signature).Recv()
+		if recv == nil {
+			return path
+		}
+
+		named, pointer := receiverType(recv.Type())
+		if named == nil {
+			return ""
+		}
+
+		if pointer {
+			return path + ".(*" + named.Obj().Name() + ")"
+		}
+
+		return path + "." + named.Obj().Name()
+	}
+
+	// local variables, parameters and fields are not qualified
+	if obj.Parent() != obj.Pkg().Scope() {
+		return ""
+	}
+
+	return path
+}
+
+// receiverType returns the named type of a method receiver of type t and
+// whether the receiver is a pointer to it.
+func receiverType(t types.Type) (named *types.Named, pointer bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+		pointer = true
+	}
+
+	named, _ = t.(*types.Named)
+
+	return
+}
+
+// qualifyName qualifies the full name of node, which declares or refers to
+// obj, with the qualifier of obj. Thereby, declarations and call sites share
+// the same fully qualified name, e.g. github.com/x/y.(*Server).Handle, while
+// their local name, by which they are resolved, stays the same.
+func (this *GoLanguageFrontend) qualifyName(node *cpg.Node, obj types.Object) {
+	if q := qualifier(obj); q != "" {
+		node.SetNameParent(q)
+	}
+}
+
+// methodFqn returns the FQN of the method fn, as it is used to resolve calls of
+// it, i.e., the name of the record of its receiver type, followed by the name
+// of the method. This is the name of the record scope, in which the method is
+// declared. An empty string is returned if fn is not a method of a named type.
+func methodFqn(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || fn.Pkg() == nil {
+		return ""
+	}
+
+	named, _ := receiverType(recv.Type())
+	if named == nil || named.Obj().Pkg() == nil {
+		return ""
+	}
+
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+}
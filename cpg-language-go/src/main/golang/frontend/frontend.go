@@ -32,9 +32,9 @@ import (
 	"go/ast"
 	"go/printer"
 	"go/token"
-	"log"
+	"go/types"
+	"os"
 
-	"golang.org/x/mod/modfile"
 	"golang.org/x/tools/go/packages"
 	"tekao.net/jnigi"
 )
@@ -45,18 +45,187 @@ type GoLanguageFrontend struct {
 	*jnigi.ObjectRef
 	File             *ast.File
 	RelativeFilePath string
-	Module           *modfile.File
+	Module           *Module
 	CommentMap       ast.CommentMap
 	Package          *packages.Package
 
 	CurrentTU *cpg.TranslationUnitDeclaration
+
+	Config *Config
+
+	// Dependency specifies, whether the current file belongs to an external
+	// package, of which only the exported declarations are translated
+	Dependency bool
+
+	// the buffer, in which the code and location of a node are encoded before
+	// they are transferred to the JVM
+	metadata nodeMetadata
+
+	// DirectBuffer returns the memory of a direct java.nio.ByteBuffer, which
+	// requires cgo and is therefore provided by the library
+	DirectBuffer func(buffer *jnigi.ObjectRef) []byte
+
+	// the raw sources of the files, from which the code of the nodes is taken,
+	// keyed by their path
+	sources map[string][]byte
+
+	// Symbols holds the package-level symbols of all files, which are declared
+	// before any function body is handled
+	Symbols *SymbolTable
+
+	// Types holds the types of the project, which were already created by the
+	// TypeParser
+	Types *TypeCache
+
+	// Strings holds the strings of the JVM, which were already passed to the
+	// builders of the nodes
+	Strings *StringCache
+
+	// RPCServices holds the gRPC services declared in the packages of the
+	// project, see rpcServices
+	RPCServices *RPCServiceCache
+
+	// Metrics holds the statistics of the translation of the project
+	Metrics *Metrics
+
+	// the language and scope manager of the frontend, which only need to be
+	// retrieved once
+	language     *cpg.Language
+	scopeManager *cpg.ScopeManager
+
+	// labels and gotos that still wait for their label, keyed by the label
+	// object of the type checker
+	labels       map[types.Object]*cpg.LabelStatement
+	pendingGotos map[types.Object][]*cpg.GotoStatement
+
+	// the namespace of the package of the current file, see enterPackageNamespace
+	namespace *cpg.NamespaceDeclaration
+
+	// the directives of the current file, see fileDirectives
+	directives *fileDirectives
+
+	// anonymous struct types, for which we already declared an implicit record
+	anonymousStructs map[*ast.StructType]bool
+
+	// records of (non-interface) named types, which could implement interfaces
+	// and to which the types referring to them are linked
+	records map[*types.TypeName]*cpg.RecordDeclaration
+
+	// declarations of the objects of the type checker, used to resolve references
+	declarations map[types.Object]*cpg.Declaration
+
+	// whether we are currently declaring the package-level symbols
+	declaringSymbols bool
+
+	// errors, which are known to be non-nil in the currently handled branches
+	// of the enclosing if statements
+	errorChecks []types.Object
+
+	// methods of the routes registered by calls, which are restricted by a
+	// chained call, see collectRouteMethods
+	routeMethods map[*ast.CallExpr][]string
 }
 
 func InitEnv(e *jnigi.Env) {
 	env = e
 }
 
+// localFrameCapacity is the number of local references, for which a new local
+// frame reserves space. The JVM grows it on demand.
+const localFrameCapacity = 256
+
+// HandleInLocalFrame calls handle within a new frame of local references, which
+// are all released afterwards, so that they do not pile up, while the files of
+// a whole project are handled within a single JNI call. The declarations, which
+// the frontend remembers, are only valid within the frame, so they are
+// forgotten afterwards. Only the records are kept as global references, see
+// Release.
+func (this *GoLanguageFrontend) HandleInLocalFrame(handle func() error) error {
+	// the language and scope manager are cached, so they need to outlive the frame
+	if _, err := this.GetLanguage(); err != nil {
+		return err
+	}
+
+	this.GetScopeManager()
+
+	if err := env.PushLocalFrame(localFrameCapacity); err != nil {
+		return err
+	}
+
+	defer func() {
+		env.PopLocalFrame(jnigi.NewObjectRef("java/lang/Object"))
+		this.declarations = nil
+	}()
+
+	return handle()
+}
+
+// Release deletes the global references, which the frontend holds, once the
+// JNI call is finished.
+func (this *GoLanguageFrontend) Release() {
+	for _, r := range this.records {
+		env.DeleteGlobalRef((*jnigi.ObjectRef)(r))
+	}
+
+	this.records = nil
+}
+
+// recoverError recovers from a panic, e.g. caused by an error in a JNI call
+// deep within a handler, and stores it in err. It needs to be deferred by the
+// exported functions, so that errors are returned to the caller instead of
+// terminating the whole process.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		if e, ok := r.(error); ok {
+			*err = e
+		} else {
+			*err = fmt.Errorf("%v", r)
+		}
+	}
+}
+
+// AddSource registers the source of the file with the given path, e.g., if it
+// was passed to the frontend and differs from the file on disk. Otherwise, the
+// source is read from disk on demand.
+func (g *GoLanguageFrontend) AddSource(path string, src []byte) {
+	if g.sources == nil {
+		g.sources = make(map[string][]byte)
+	}
+
+	g.sources[path] = src
+}
+
+// source returns the raw source of file, or nil if it is not available.
+func (g *GoLanguageFrontend) source(file *token.File) []byte {
+	src, ok := g.sources[file.Name()]
+	if !ok {
+		src, _ = os.ReadFile(file.Name())
+		g.AddSource(file.Name(), src)
+	}
+
+	// the file might have changed in the meantime
+	if len(src) != file.Size() {
+		return nil
+	}
+
+	return src
+}
+
+// GetCodeFromRawNode returns the code of astNode as it is written in the
+// source. If the source is not available, e.g., for nodes we created
+// ourselves, the node is printed instead.
 func (g *GoLanguageFrontend) GetCodeFromRawNode(fset *token.FileSet, astNode ast.Node) string {
+	if astNode != nil && astNode.Pos().IsValid() && astNode.End().IsValid() {
+		if file := fset.File(astNode.Pos()); file != nil {
+			start := file.Offset(astNode.Pos())
+			end := file.Offset(astNode.End())
+
+			if src := g.source(file); src != nil && start <= end {
+				return string(src[start:end])
+			}
+		}
+	}
+
 	var codeBuf bytes.Buffer
 	_ = printer.Fprint(&codeBuf, fset, astNode)
 
@@ -64,13 +233,21 @@ func (g *GoLanguageFrontend) GetCodeFromRawNode(fset *token.FileSet, astNode ast
 }
 
 func (g *GoLanguageFrontend) GetScopeManager() *cpg.ScopeManager {
+	// the scope manager of the frontend does not change, so we only ask once
+	// instead of creating a new local reference for each of the many calls
+	if g.scopeManager != nil {
+		return g.scopeManager
+	}
+
 	var scope = jnigi.NewObjectRef(cpg.ScopeManagerClass)
 	err := g.GetField(env, "scopeManager", scope)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
-	return (*cpg.ScopeManager)(scope)
+	g.scopeManager = (*cpg.ScopeManager)(scope)
+
+	return g.scopeManager
 }
 
 func (g *GoLanguageFrontend) getLog() (logger *jnigi.ObjectRef, err error) {
@@ -80,96 +257,81 @@ func (g *GoLanguageFrontend) getLog() (logger *jnigi.ObjectRef, err error) {
 	return
 }
 
-func (g *GoLanguageFrontend) LogInfo(format string, args ...interface{}) (err error) {
-	var logger *jnigi.ObjectRef
-
-	if logger, err = g.getLog(); err != nil {
+// log logs the message with the given level, e.g., "info". Both the logger and
+// the message are released afterwards, since logging happens all the time.
+func (g *GoLanguageFrontend) log(level string, format string, args ...interface{}) (err error) {
+	if !g.Config.IsLogged(level) {
 		return
 	}
 
-	err = logger.CallMethod(env, "info", nil, cpg.NewString(fmt.Sprintf(format, args...)))
-
-	return
-}
-
-func (g *GoLanguageFrontend) LogDebug(format string, args ...interface{}) (err error) {
 	var logger *jnigi.ObjectRef
 
 	if logger, err = g.getLog(); err != nil {
 		return
 	}
+	defer env.DeleteLocalRef(logger)
 
-	err = logger.CallMethod(env, "debug", nil, cpg.NewString(fmt.Sprintf(format, args...)))
+	msg := cpg.NewString(fmt.Sprintf(format, args...))
+	defer env.DeleteLocalRef(msg)
+
+	err = logger.CallMethod(env, level, nil, msg)
 
 	return
 }
 
-func (g *GoLanguageFrontend) LogWarn(format string, args ...interface{}) (err error) {
-	var logger *jnigi.ObjectRef
-
-	if logger, err = g.getLog(); err != nil {
-		return
-	}
+func (g *GoLanguageFrontend) LogInfo(format string, args ...interface{}) (err error) {
+	return g.log("info", format, args...)
+}
 
-	err = logger.CallMethod(env, "warn", nil, cpg.NewString(fmt.Sprintf(format, args...)))
+func (g *GoLanguageFrontend) LogDebug(format string, args ...interface{}) (err error) {
+	return g.log("debug", format, args...)
+}
 
-	return
+func (g *GoLanguageFrontend) LogWarn(format string, args ...interface{}) (err error) {
+	return g.log("warn", format, args...)
 }
 
 func (g *GoLanguageFrontend) LogError(format string, args ...interface{}) (err error) {
-	var logger *jnigi.ObjectRef
-
-	if logger, err = g.getLog(); err != nil {
-		return
-	}
-
-	err = logger.CallMethod(env, "error", nil, cpg.NewString(fmt.Sprintf(format, args...)))
-
-	return
+	return g.log("error", format, args...)
 }
 
-func (g *GoLanguageFrontend) GetLanguage() (l *cpg.Language, err error) {
-	l = new(cpg.Language)
-	err = g.ObjectRef.CallMethod(env, "getLanguage", l)
+// The phases of a translation, whose progress is reported to the JVM
+const (
+	// PhaseDiscovered counts the files, which were discovered in the project
+	PhaseDiscovered = "DISCOVERED"
 
-	return
-}
+	// PhaseLoaded counts the files, whose package was loaded and whose records
+	// were declared
+	PhaseLoaded = "LOADED"
 
-func updateCode(fset *token.FileSet, node *cpg.Node, astNode ast.Node) {
-	var codeBuf bytes.Buffer
-	_ = printer.Fprint(&codeBuf, fset, astNode)
+	// PhaseTranslated counts the files, which were translated completely
+	PhaseTranslated = "TRANSLATED"
+)
 
-	node.SetCode(codeBuf.String())
-}
+// ReportProgress reports to the JVM that done out of total files were handled in
+// the given phase. A total of zero means that it is not known yet.
+func (g *GoLanguageFrontend) ReportProgress(phase string, done int, total int) {
+	str := cpg.NewString(phase)
+	defer env.DeleteLocalRef(str)
 
-func updateLocation(fset *token.FileSet, node *cpg.Node, astNode ast.Node) {
-	if astNode == nil {
-		return
+	err := g.ObjectRef.CallMethod(env, "reportProgress", nil, str, done, total)
+	if err != nil {
+		panic(err)
 	}
+}
 
-	file := fset.File(astNode.Pos())
-	if file == nil {
-		return
+func (g *GoLanguageFrontend) GetLanguage() (l *cpg.Language, err error) {
+	if g.language != nil {
+		return g.language, nil
 	}
 
-	uri, err := env.NewObject("java/net/URI", cpg.NewString(file.Name()))
-	if err != nil {
-		log.Fatal(err)
+	l = new(cpg.Language)
+	err = g.ObjectRef.CallMethod(env, "getLanguage", l)
+	if err == nil {
+		g.language = l
 	}
 
-	region := cpg.NewRegion(fset, astNode,
-		fset.Position(astNode.Pos()).Line,
-		fset.Position(astNode.Pos()).Column,
-		fset.Position(astNode.End()).Line,
-		fset.Position(astNode.End()).Column,
-	)
-
-	location := cpg.NewPhysicalLocation(fset, astNode, uri, region)
-
-	err = node.SetLocation(location)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return
 }
 
 func updateLanguage(node *cpg.Node, frontend *GoLanguageFrontend) {
@@ -180,11 +342,11 @@ func updateLanguage(node *cpg.Node, frontend *GoLanguageFrontend) {
 
 	l, err = frontend.GetLanguage()
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	err = node.SetLanguge(l)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 }
@@ -29,68 +29,138 @@ import (
 	"cpg"
 	"fmt"
 	"go/ast"
+	"go/build/constraint"
+	"go/constant"
 	"go/token"
 	"go/types"
-	"io/ioutil"
-	"log"
-	"os"
-	"path"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
-	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
 	"tekao.net/jnigi"
 )
 
 const MetadataProviderClass = cpg.GraphPackage + "/MetadataProvider"
 
+// getImportName returns the name, under which the imported package is known in
+// the file. Blank and dot imports do not introduce a name, so they are named
+// after the package itself. The name is taken from the explicit alias, the
+// loaded package or, if the package could not be loaded, from its import path.
 func (frontend *GoLanguageFrontend) getImportName(spec *ast.ImportSpec) string {
-	if spec.Name != nil {
+	if spec.Name != nil && !isBlankImport(spec) && !isDotImport(spec) {
 		return spec.Name.Name
 	}
 
-	var path = spec.Path.Value[1 : len(spec.Path.Value)-1]
-	var paths = strings.Split(path, "/")
-
-	if frontend.Package != nil {
-		im := frontend.Package.Imports[path]
+	var path = importPath(spec)
 
-		if im.Name != "" {
-			return im.Name
-		}
+	if name, ok := frontend.importedPackageName(path); ok {
+		return name
 	}
 
-	return paths[len(paths)-1]
+	return assumedPackageName(path)
 }
 
-func (frontend *GoLanguageFrontend) ParseModule(topLevel string) (exists bool, err error) {
-	frontend.LogDebug("Looking for a go.mod file in %s", topLevel)
+// importPath returns the (unquoted) import path of spec.
+func importPath(spec *ast.ImportSpec) string {
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		// strip the quotes, at least
+		return strings.Trim(spec.Path.Value, "\"`")
+	}
+
+	return path
+}
 
-	mod := path.Join(topLevel, "go.mod")
+// importedPackageName returns the name of the package with the given import
+// path, if it is imported by the current package and could be resolved. Unless
+// the dependencies are loaded, it is only known to the type checker, which
+// creates incomplete fake packages for imports it cannot resolve.
+func (frontend *GoLanguageFrontend) importedPackageName(path string) (name string, ok bool) {
+	if frontend.Package == nil {
+		return "", false
+	}
 
-	if _, err := os.Stat(mod); err != nil {
-		if os.IsNotExist(err) {
-			frontend.LogInfo("%s does not exist", mod)
+	if im := frontend.Package.Imports[path]; im != nil && im.Name != "" {
+		return im.Name, true
+	}
 
-			return false, nil
+	if frontend.Package.Types != nil {
+		for _, im := range frontend.Package.Types.Imports() {
+			if im.Path() == path && im.Complete() {
+				return im.Name(), true
+			}
 		}
 	}
 
-	b, err := ioutil.ReadFile(mod)
-	if err != nil {
-		return true, fmt.Errorf("could not read go.mod: %w", err)
+	return "", false
+}
+
+// assumedPackageName guesses the name of a package from its import path. By
+// convention, it is the last element of the path, without a major version
+// suffix, e.g. "yaml" for "gopkg.in/yaml.v3" or "chi" for
+// "github.com/go-chi/chi/v5".
+func assumedPackageName(path string) string {
+	var paths = strings.Split(path, "/")
+
+	name := paths[len(paths)-1]
+	if len(paths) > 1 && isMajorVersion(name) {
+		name = paths[len(paths)-2]
 	}
 
-	module, err := modfile.Parse(mod, b, nil)
-	if err != nil {
-		return true, fmt.Errorf("could not parse mod file: %w", err)
+	if i := strings.LastIndex(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
 	}
 
-	frontend.Module = module
+	return name
+}
+
+// isMajorVersion checks, whether s is a major version suffix, such as v2.
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+
+	_, err := strconv.Atoi(s[1:])
+
+	return err == nil
+}
+
+// isBlankImport returns, whether the package is only imported for its side
+// effects, i.e., its initialization.
+func isBlankImport(spec *ast.ImportSpec) bool {
+	return spec.Name != nil && spec.Name.Name == "_"
+}
+
+// isDotImport returns, whether the exported symbols of the package are imported
+// into the file block, so that they are used without a qualifier.
+func isDotImport(spec *ast.ImportSpec) bool {
+	return spec.Name != nil && spec.Name.Name == "."
+}
+
+// importByName returns the import of the current file, which introduces the
+// given package name, if any.
+func (frontend *GoLanguageFrontend) importByName(name string) *ast.ImportSpec {
+	if frontend.File == nil {
+		return nil
+	}
+
+	for _, imp := range frontend.File.Imports {
+		if isBlankImport(imp) || isDotImport(imp) {
+			continue
+		}
 
-	frontend.LogInfo("Go application has module support with path %s", module.Module.Mod.Path)
+		if frontend.getImportName(imp) == name {
+			return imp
+		}
+	}
 
-	return true, nil
+	return nil
 }
 
 func (this *GoLanguageFrontend) HandleFileContent(
@@ -98,15 +168,15 @@ func (this *GoLanguageFrontend) HandleFileContent(
 	file *ast.File,
 	tu *cpg.TranslationUnitDeclaration,
 ) (err error) {
+	defer recoverError(&err)
+
 	scope := this.GetScopeManager()
 
 	// reset scope
 	scope.ResetToGlobal((*cpg.Node)(tu))
 	this.CurrentTU = tu
 
-	ns := this.NewNamespaceDeclaration(fset, nil, this.modulePath())
-
-	scope.EnterScope((*cpg.Node)(ns))
+	namespaces := this.enterPackageNamespace(fset)
 	for _, decl := range file.Decls {
 		if v, ok := decl.(*ast.GenDecl); ok && v.Tok == token.TYPE {
 			continue
@@ -118,13 +188,12 @@ func (this *GoLanguageFrontend) HandleFileContent(
 			for _, decl := range d {
 				err = scope.AddDeclaration((*cpg.Declaration)(decl))
 				if err != nil {
-					log.Fatal(err)
+					return fmt.Errorf("could not add declaration: %w", err)
 				}
 			}
 		}
 	}
-	scope.LeaveScope((*cpg.Node)(ns))
-	scope.AddDeclaration((*cpg.Declaration)(ns))
+	this.leavePackageNamespace(namespaces)
 
 	return
 }
@@ -134,8 +203,14 @@ func (this *GoLanguageFrontend) HandleFileRecordDeclarations(
 	file *ast.File,
 	path string,
 ) (tu *cpg.TranslationUnitDeclaration, err error) {
+	defer recoverError(&err)
+
 	tu = this.NewTranslationUnitDeclaration(fset, file, path)
 
+	if comment := fileComment(file); comment != "" {
+		(*cpg.Node)(tu).SetComment(comment)
+	}
+
 	scope := this.GetScopeManager()
 
 	// reset scope
@@ -148,15 +223,36 @@ func (this *GoLanguageFrontend) HandleFileRecordDeclarations(
 
 		err = scope.AddDeclaration((*cpg.Declaration)(i))
 		if err != nil {
-			log.Fatal(err)
+			return nil, fmt.Errorf("could not add declaration: %w", err)
 		}
 	}
 
-	// create a new namespace declaration, representing the package
-	namespace := this.NewNamespaceDeclaration(fset, nil, this.modulePath())
+	// record the build constraints, under which the file was selected
+	if this.Config != nil {
+		(*cpg.Node)(tu).AddAnnotations([]*cpg.Annotation{this.handleBuildConstraints(fset, file)})
+	}
 
-	// enter scope
-	scope.EnterScope((*cpg.Node)(namespace))
+	if IsGenerated(file) {
+		tu.SetGenerated(true)
+	}
+
+	if strings.HasSuffix(path, "_test.go") {
+		(*cpg.Node)(tu).AddAnnotations([]*cpg.Annotation{this.handleTestFile(fset, file)})
+	}
+
+	if this.Dependency {
+		(*cpg.Node)(tu).AddAnnotations([]*cpg.Annotation{this.handleDependencyFile(fset)})
+	}
+
+	if this.Module != nil && !this.Dependency {
+		(*cpg.Node)(tu).AddAnnotations(this.handleModule(fset))
+	}
+
+	(*cpg.Node)(tu).AddAnnotations(this.handleDirectives(fset, nil))
+
+	// create a new namespace declaration, representing the package, and enter
+	// its scope
+	namespaces := this.enterPackageNamespace(fset)
 
 	for _, decl := range file.Decls {
 		if v, ok := decl.(*ast.GenDecl); !ok || v.Tok != token.TYPE {
@@ -169,23 +265,355 @@ func (this *GoLanguageFrontend) HandleFileRecordDeclarations(
 			for _, di := range d {
 				err = scope.AddDeclaration((*cpg.Declaration)(di))
 				if err != nil {
-					log.Fatal(err)
+					return nil, fmt.Errorf("could not add declaration: %w", err)
 				}
 			}
 		}
 	}
 
-	// leave scope
-	scope.LeaveScope((*cpg.Node)(namespace))
+	// leave scope and add it
+	this.leavePackageNamespace(namespaces)
+
+	return
+}
+
+// handleBuildConstraints creates an annotation, which describes the build
+// constraints that were active when the file was selected, i.e., the target
+// platform and the build tags, as well as the constraint of the file itself.
+func (this *GoLanguageFrontend) handleBuildConstraints(fset *token.FileSet, file *ast.File) *cpg.Annotation {
+	goos, goarch := this.Config.Platform()
+
+	var values = [][2]string{
+		{"goos", goos},
+		{"goarch", goarch},
+		{"tags", strings.Join(this.Config.BuildTags, ",")},
+	}
+
+	// the constraint of the file needs to be placed before the package clause
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+
+		for _, c := range group.List {
+			if expr, err := constraint.Parse(c.Text); err == nil && constraint.IsGoBuild(c.Text) {
+				values = append(values, [2]string{"constraint", expr.String()})
+			}
+		}
+	}
+
+	return this.newStringAnnotation(fset, "build", values)
+}
+
+// generatedComment matches the comment, which marks a generated file, see
+// https://go.dev/s/generatedcode
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// IsGenerated checks, whether the file was generated by a tool, e.g., protoc or
+// stringer. By convention, such files contain a special comment before the
+// package clause.
+func IsGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+
+		for _, c := range group.List {
+			if generatedComment.MatchString(c.Text) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// handleTestFile creates an annotation, which marks a test file. Its package
+// member distinguishes tests within the package from external tests, i.e.,
+// the ones in a separate package with the suffix _test.
+func (this *GoLanguageFrontend) handleTestFile(fset *token.FileSet, file *ast.File) *cpg.Annotation {
+	lit := this.NewLiteral(fset, nil, cpg.NewString(file.Name.Name), this.parseType("string"))
+
+	a := this.NewAnnotation(fset, nil, "test")
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, nil, "package", (*cpg.Expression)(lit)),
+	})
+
+	return a
+}
+
+// handleDependencyFile creates an annotation, which marks the translation unit
+// as a stub of an external package. Its members contain the module and version,
+// from which the package was loaded, if known.
+func (this *GoLanguageFrontend) handleDependencyFile(fset *token.FileSet) *cpg.Annotation {
+	var values = [][2]string{
+		{"package", this.Package.PkgPath},
+	}
+
+	if m := this.Package.Module; m != nil {
+		values = append(values, [2]string{"module", m.Path}, [2]string{"version", m.Version})
+	}
+
+	return this.newStringAnnotation(fset, "dependency", values)
+}
+
+// newStringAnnotation creates an annotation with the given name, whose members
+// are string literals with the given names and values, in this order.
+func (this *GoLanguageFrontend) newStringAnnotation(fset *token.FileSet, name string, values [][2]string) *cpg.Annotation {
+	a := this.NewAnnotation(fset, nil, name)
+	a.SetMembers(this.newStringMembers(fset, values))
+
+	return a
+}
+
+// newStringMembers creates annotation members, which are string literals with
+// the given names and values, in this order.
+func (this *GoLanguageFrontend) newStringMembers(fset *token.FileSet, values [][2]string) (members []*cpg.AnnotationMember) {
+	for _, kv := range values {
+		lit := this.NewLiteral(fset, nil, cpg.NewString(kv[1]), this.parseType("string"))
+
+		members = append(members, this.NewAnnotationMember(fset, nil, kv[0], (*cpg.Expression)(lit)))
+	}
+
+	return
+}
+
+// HandleFileSymbols declares the package-level functions, methods and variables
+// of a file, without handling any function bodies or initializers. This way, all
+// symbols of a package are known before any file content is handled and can be
+// referenced across files. It needs to be called after the record declarations
+// of all packages are handled.
+func (this *GoLanguageFrontend) HandleFileSymbols(
+	fset *token.FileSet,
+	file *ast.File,
+	tu *cpg.TranslationUnitDeclaration,
+) (err error) {
+	defer recoverError(&err)
+
+	scope := this.GetScopeManager()
+
+	// reset scope
+	scope.ResetToGlobal((*cpg.Node)(tu))
+	this.CurrentTU = tu
+
+	namespaces := this.enterPackageNamespace(fset)
+
+	this.declaringSymbols = true
+	defer func() {
+		this.declaringSymbols = false
+	}()
+
+	for _, decl := range file.Decls {
+		// the remaining symbols are declared when handling the file content
+		if this.Config.ReachesSymbolLimit(this.Symbols.len()) {
+			this.LogInfo("Reached the limit of %d package-level symbols, the remaining symbols of %s are declared with its content",
+				this.Config.SymbolLimit, fset.Position(file.Package).Filename)
+			break
+		}
+
+		switch v := decl.(type) {
+		case *ast.FuncDecl:
+			// only the exported API of dependencies is of interest
+			if this.Dependency && !v.Name.IsExported() {
+				continue
+			}
+
+			f, record := this.declareFuncDecl(fset, v)
+
+			if record != nil && !record.IsNil() {
+				scope.EnterScope((*cpg.Node)(record))
+				err = scope.AddDeclaration((*cpg.Declaration)(f))
+				scope.LeaveScope((*cpg.Node)(record))
+			} else {
+				err = scope.AddDeclaration((*cpg.Declaration)(f))
+			}
+
+			if err != nil {
+				return fmt.Errorf("could not add declaration: %w", err)
+			}
+
+			this.Symbols.addFunction(v, f, record)
+
+			if this.Package == nil {
+				continue
+			}
+
+			// methods are the candidates for calls through interfaces, and
+			// functions are looked up, e.g., if they are passed as handlers
+			if fn, ok := this.Package.TypesInfo.Defs[v.Name].(*types.Func); ok && v.Recv != nil {
+				this.Symbols.addMethod(fn, f)
+			} else if ok {
+				this.Symbols.addPackageFunction(fn, f)
+			}
+		case *ast.GenDecl:
+			if v.Tok != token.VAR && v.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range v.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for i, ident := range valueSpec.Names {
+					if (this.Dependency && !ident.IsExported()) || isBlank(ident) {
+						continue
+					}
+
+					d := this.declareValueSpecName(fset, valueSpec, i, v.Tok)
+
+					err = scope.AddDeclaration((*cpg.Declaration)(d))
+					if err != nil {
+						return fmt.Errorf("could not add declaration: %w", err)
+					}
 
-	// add it
-	scope.AddDeclaration((*cpg.Declaration)(namespace))
+					this.Symbols.addVariable(ident, d)
+				}
+			}
+		}
+	}
+
+	this.leavePackageNamespace(namespaces)
 
 	return
 }
 
+// HandleInterfaceImplementations adds the interfaces of all packages, which are
+// implemented by a record, as super classes of the record. This enables the
+// resolution of (dynamic) calls to interface methods. It needs to be called
+// after the record declarations of all packages are handled.
+func (this *GoLanguageFrontend) HandleInterfaceImplementations(pkgs []*packages.Package) {
+	var interfaces []*types.TypeName
+
+	for _, p := range pkgs {
+		if p.Types == nil {
+			continue
+		}
+
+		scope := p.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !isImplementable(obj.Type()) {
+				continue
+			}
+
+			iface, ok := obj.Type().Underlying().(*types.Interface)
+
+			// every type implements the empty interface and constraint
+			// interfaces cannot be implemented at all
+			if !ok || iface.Empty() || !iface.IsMethodSet() {
+				continue
+			}
+
+			interfaces = append(interfaces, obj)
+		}
+	}
+
+	// the super classes are added in a fixed order, regardless of the order of
+	// the map
+	var objs []*types.TypeName
+	for obj := range this.records {
+		objs = append(objs, obj)
+	}
+
+	sort.Slice(objs, func(i, j int) bool {
+		return objectLess(objs[i], objs[j])
+	})
+
+	for _, obj := range objs {
+		r := this.records[obj]
+		t := obj.Type()
+		if !isImplementable(t) {
+			continue
+		}
+
+		for _, i := range interfaces {
+			iface := i.Type().Underlying().(*types.Interface)
+
+			// methods with a pointer receiver are only in the method set of the pointer type
+			if types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface) {
+				this.LogDebug("%s implements %s", obj.Name(), i.Name())
+
+				r.AddSuperClass(this.handleTypingType(i.Type()))
+			}
+		}
+	}
+}
+
+// objectLess orders objects by their package, name and position, so that maps
+// keyed by objects can be iterated in a deterministic order.
+func objectLess(a types.Object, b types.Object) bool {
+	var aPkg, bPkg string
+
+	if a.Pkg() != nil {
+		aPkg = a.Pkg().Path()
+	}
+
+	if b.Pkg() != nil {
+		bPkg = b.Pkg().Path()
+	}
+
+	if aPkg != bPkg {
+		return aPkg < bPkg
+	}
+
+	if a.Name() != b.Name() {
+		return a.Name() < b.Name()
+	}
+
+	return a.Pos() < b.Pos()
+}
+
+// isImplementable checks, whether t can be used with types.Implements, which is
+// not the case for generic types that are not instantiated.
+func isImplementable(t types.Type) bool {
+	named, ok := t.(*types.Named)
+
+	return !ok || named.TypeParams().Len() == 0
+}
+
+// registerDeclaration remembers d as the declaration of the object, which is
+// defined by ident according to the type checker.
+func (this *GoLanguageFrontend) registerDeclaration(ident *ast.Ident, d *cpg.Declaration) {
+	if this.Package == nil {
+		return
+	}
+
+	this.registerObject(this.Package.TypesInfo.Defs[ident], d)
+}
+
+// registerObject remembers d as the declaration of the type checker object obj,
+// so that references to obj can directly be resolved to d.
+func (this *GoLanguageFrontend) registerObject(obj types.Object, d *cpg.Declaration) {
+	if obj == nil {
+		return
+	}
+
+	// package-level variables and constants are qualified by their package
+	switch obj.(type) {
+	case *types.Var, *types.Const:
+		this.qualifyName((*cpg.Node)(d), obj)
+	}
+
+	// declarations of package-level symbols need to outlive the current call
+	if this.declaringSymbols {
+		this.Symbols.addObject(obj, d)
+		return
+	}
+
+	if this.declarations == nil {
+		this.declarations = map[types.Object]*cpg.Declaration{}
+	}
+
+	this.declarations[obj] = d
+}
+
 // handleComments maps comments from ast.Node to a cpg.Node by using ast.CommentMap.
-func (this *GoLanguageFrontend) handleComments(node *cpg.Node, astNode ast.Node) {
+// Additionally, the doc and line comments of the node can be passed as groups,
+// since the CommentMap associates them with a parent node in some cases, e.g.,
+// the declaration of an ungrouped import or the field list of a struct.
+func (this *GoLanguageFrontend) handleComments(node *cpg.Node, astNode ast.Node, groups ...*ast.CommentGroup) {
 	this.LogDebug("Handling comments for %+v", astNode)
 
 	var comment = ""
@@ -193,13 +621,29 @@ func (this *GoLanguageFrontend) handleComments(node *cpg.Node, astNode ast.Node)
 	// Lookup ast node in comment map. One cannot use Filter() because this would actually filter all the comments
 	// that are "below" this AST node as well, e.g. in its children. We only want the comments on the node itself.
 	// Therefore we must convert the CommentMap back into an actual map to access the stored entry for the node.
-	comments, ok := (map[ast.Node][]*ast.CommentGroup)(this.CommentMap)[astNode]
-	if !ok {
+	comments := append([]*ast.CommentGroup{}, (map[ast.Node][]*ast.CommentGroup)(this.CommentMap)[astNode]...)
+
+	for _, g := range groups {
+		if g != nil && !containsCommentGroup(comments, g) {
+			comments = append(comments, g)
+		}
+	}
+
+	if len(comments) == 0 {
 		return
 	}
 
+	// keep the comments in the order of the source code
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Pos() < comments[j].Pos()
+	})
+
 	for _, c := range comments {
 		text := strings.TrimRight(c.Text(), "\n")
+		if comment != "" && text != "" {
+			comment += "\n"
+		}
+
 		comment += text
 	}
 
@@ -210,6 +654,69 @@ func (this *GoLanguageFrontend) handleComments(node *cpg.Node, astNode ast.Node)
 	}
 }
 
+func containsCommentGroup(groups []*ast.CommentGroup, group *ast.CommentGroup) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deprecatedPrefix starts the paragraph of a doc comment, which marks the
+// documented declaration as deprecated, see https://go.dev/wiki/Deprecated
+const deprecatedPrefix = "Deprecated:"
+
+// handleDeprecation creates a "Deprecated" annotation for node, if one of the
+// doc comments contains a paragraph starting with "Deprecated:". The rest of the
+// paragraph is stored in the "message" member of the annotation, e.g., to
+// suggest a replacement.
+func (this *GoLanguageFrontend) handleDeprecation(fset *token.FileSet, node *cpg.Node, docs ...*ast.CommentGroup) {
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+			if !strings.HasPrefix(paragraph, deprecatedPrefix) {
+				continue
+			}
+
+			message := strings.Join(strings.Fields(strings.TrimPrefix(paragraph, deprecatedPrefix)), " ")
+
+			node.AddAnnotations([]*cpg.Annotation{
+				this.newStringAnnotation(fset, "Deprecated", [][2]string{{"message", message}}),
+			})
+
+			return
+		}
+	}
+}
+
+// enclosingGenDecl returns the package-level declaration of the current file,
+// which contains spec, if any.
+func (this *GoLanguageFrontend) enclosingGenDecl(spec ast.Spec) *ast.GenDecl {
+	if this.File == nil {
+		return nil
+	}
+
+	for _, decl := range this.File.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || spec.Pos() < genDecl.Pos() || spec.End() > genDecl.End() {
+			continue
+		}
+
+		for _, s := range genDecl.Specs {
+			if s == spec {
+				return genDecl
+			}
+		}
+	}
+
+	return nil
+}
+
 func (this *GoLanguageFrontend) handleDecl(fset *token.FileSet, decl ast.Decl) (d []*cpg.Declaration, addToScope bool) {
 	this.LogDebug("Handling declaration (%T): %+v", decl, decl)
 	addToScope = true
@@ -221,32 +728,57 @@ func (this *GoLanguageFrontend) handleDecl(fset *token.FileSet, decl ast.Decl) (
 		addToScope = funcAddToScope
 	case *ast.GenDecl:
 		d = this.handleGenDecl(fset, v)
+	case *ast.BadDecl:
+		// the parser could not make sense of this region of the file
+		d = []*cpg.Declaration{this.NewProblemDeclaration(fset, v, "could not parse declaration")}
 	default:
-		this.LogError("Not parsing declaration of type %T yet: %+v", v, v)
+		this.LogWarn("Not parsing declaration of type %T yet: %+v", v, v)
+		this.Metrics.unhandled(fset, decl)
 		// no match
 		d = nil
 	}
 
+	if d != nil {
+		this.Metrics.handled(decl)
+	}
+
 	// if len(d) == 1 {
 	// 	this.handleComments((*cpg.Node)(d[0]), decl)
 	// }
 
+	if directives := this.handleDirectives(fset, decl); len(directives) > 0 {
+		for _, di := range d {
+			(*cpg.Node)(di).AddAnnotations(directives)
+		}
+	}
+
 	return
 }
 
 func (this *GoLanguageFrontend) addFuncTypeData(f *cpg.FunctionDeclaration, fset *token.FileSet, funcDecl *ast.FuncDecl) {
-	var t *cpg.Type = this.handleType(funcDecl.Type)
+	var t *cpg.Type = this.handleFuncType(fset, funcDecl.Recv, funcDecl.Type)
 	var returnTypes []*cpg.Type = []*cpg.Type{}
 
 	if funcDecl.Type.Results != nil {
 		for _, returnVariable := range funcDecl.Type.Results.List {
-			t := this.handleType(returnVariable.Type)
+			t := this.handleType(fset, returnVariable.Type)
+
+			// if the function has named return variables, be sure to declare
+			// them as well. A group of them, e.g. (n, m int), shares its type
+			// but still contributes one return value per name.
+			if returnVariable.Names == nil {
+				returnTypes = append(returnTypes, t)
+			}
+
+			for _, ident := range returnVariable.Names {
+				returnTypes = append(returnTypes, t)
 
-			returnTypes = append(returnTypes, t)
+				if isBlank(ident) {
+					continue
+				}
 
-			// if the function has named return variables, be sure to declare them as well
-			if returnVariable.Names != nil {
-				p := this.NewVariableDeclaration(fset, returnVariable, returnVariable.Names[0].Name)
+				p := this.NewVariableDeclaration(fset, returnVariable, ident.Name)
+				this.registerDeclaration(ident, (*cpg.Declaration)(p))
 
 				p.SetType(t)
 
@@ -264,40 +796,54 @@ func (this *GoLanguageFrontend) addFuncTypeData(f *cpg.FunctionDeclaration, fset
 	for _, param := range funcDecl.Type.Params.List {
 		this.LogDebug("Parsing param: %+v", param)
 
-		var name string
+		var paramType *cpg.Type
+		var variadic bool
+
+		if ellipsis, ok := param.Type.(*ast.Ellipsis); ok {
+			paramType = this.handleType(fset, ellipsis.Elt)
+			variadic = true
+		} else {
+			paramType = this.handleType(fset, param.Type)
+		}
+
 		// Somehow parameters end up having no name sometimes, have not fully understood why.
-		if len(param.Names) > 0 {
-			// TODO: more than one name?
-			name = param.Names[0].Name
+		names := param.Names
+		if len(names) == 0 {
+			this.LogDebug("Some param has no name, which is a bit weird: %+v", param)
+
+			names = []*ast.Ident{nil}
+		}
+
+		// a group of parameters, e.g. a, b int, shares its type and comments,
+		// but each of them is a parameter of its own
+		for _, ident := range names {
+			var name string
 
 			// If the name is an underscore, it means that the parameter is
 			// unnamed. In order to avoid confusing and some compatibility with
 			// other languages, we are just setting the name to an empty string
 			// in this case.
-			if name == "_" {
-				name = ""
+			if ident != nil && ident.Name != "_" {
+				name = ident.Name
 			}
-		} else {
-			this.LogError("Some param has no name, which is a bit weird: %+v", param)
-		}
 
-		p := this.NewParamVariableDeclaration(fset, param, name)
+			p := this.NewParamVariableDeclaration(fset, param, name)
 
-		var paramType *cpg.Type
+			if ident != nil {
+				this.registerDeclaration(ident, (*cpg.Declaration)(p))
+			}
 
-		if ellipsis, ok := param.Type.(*ast.Ellipsis); ok {
-			paramType = this.handleType(ellipsis.Elt)
-			p.SetVariadic(true)
-		} else {
-			paramType = this.handleType(param.Type)
-		}
+			if variadic {
+				p.SetVariadic(true)
+			}
 
-		p.SetType(paramType)
+			p.SetType(paramType)
 
-		// add parameter to scope
-		this.GetScopeManager().AddDeclaration((*cpg.Declaration)(p))
+			// add parameter to scope
+			this.GetScopeManager().AddDeclaration((*cpg.Declaration)(p))
 
-		this.handleComments((*cpg.Node)(p), param)
+			this.handleComments((*cpg.Node)(p), param)
+		}
 	}
 }
 
@@ -311,20 +857,30 @@ func (this *GoLanguageFrontend) handleFuncLit(fset *token.FileSet, funcLit *ast.
 		Type: funcLit.Type,
 	})
 
+	if this.Package != nil && this.Package.TypesInfo != nil {
+		this.handleErrorResults(fset, f, this.Package.TypesInfo.TypeOf(funcLit))
+	}
+
 	if funcLit.Body != nil {
+		// the errors checked around the literal cannot be returned by it
+		checks := this.errorChecks
+		this.errorChecks = nil
+
 		// parse body
-		s := this.handleBlockStmt(fset, funcLit.Body)
+		s := this.handleFuncBody(fset, funcLit.Body)
+
+		this.errorChecks = checks
 
 		err := f.SetBody((*cpg.Statement)(s))
 		if err != nil {
-			log.Fatal(err)
+			panic(err)
 		}
 	}
 
 	// leave scope
 	err := scope.LeaveScope((*cpg.Node)(f))
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	scope.AddDeclaration((*cpg.Declaration)(f))
@@ -332,17 +888,109 @@ func (this *GoLanguageFrontend) handleFuncLit(fset *token.FileSet, funcLit *ast.
 	r := this.NewLambdaExpression(fset, funcLit)
 	r.SetFunction(f)
 
+	// closures in Go share the variables of the enclosing function
+	r.SetCapturesByReference(true)
+	for _, d := range this.capturedVariables(funcLit) {
+		r.AddCapturedVariable(d)
+	}
+
 	return (*jnigi.ObjectRef)(r)
 }
 
+// capturedVariables returns the declarations of the variables, which are used
+// within funcLit, but declared outside of it, i.e., its free variables.
+// Package-level variables are not captured, since they are shared anyway.
+func (this *GoLanguageFrontend) capturedVariables(funcLit *ast.FuncLit) (captured []*cpg.Declaration) {
+	if this.Package == nil || funcLit.Body == nil {
+		return
+	}
+
+	seen := map[*types.Var]bool{}
+
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		v, ok := this.Package.TypesInfo.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || seen[v] || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+
+		// declared within the literal, e.g. as parameter
+		if v.Pos() >= funcLit.Pos() && v.Pos() < funcLit.End() {
+			return true
+		}
+
+		seen[v] = true
+
+		if d, ok := this.declarations[v]; ok {
+			captured = append(captured, d)
+		}
+
+		return true
+	})
+
+	return
+}
+
 func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *ast.FuncDecl) (*jnigi.ObjectRef, bool) {
 	this.LogDebug("Handling func Decl: %+v", *funcDecl)
 
 	var scope = this.GetScopeManager()
-	var receiver *cpg.VariableDeclaration
 
-	var f *cpg.FunctionDeclaration
-	var record *cpg.RecordDeclaration
+	// The function might already have been declared by HandleFileSymbols, in
+	// which case it is already part of the scope and we only need to handle
+	// the body.
+	f, record, predeclared := this.Symbols.function(funcDecl)
+	if !predeclared {
+		f, record = this.declareFuncDecl(fset, funcDecl)
+	}
+
+	if record != nil && !record.IsNil() {
+		scope.EnterScope((*cpg.Node)(record))
+	}
+	// enter scope for function
+	scope.EnterScope((*cpg.Node)(f))
+
+	this.LogDebug("Parsing function body of %s", (*cpg.Node)(f).GetName())
+
+	if funcDecl.Body != nil {
+		// parse body
+		s := this.handleFuncBody(fset, funcDecl.Body)
+
+		err := f.SetBody((*cpg.Statement)(s))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// leave scope
+	err := scope.LeaveScope((*cpg.Node)(f))
+	if err != nil {
+		panic(err)
+	}
+
+	if record != nil && !record.IsNil() {
+		if !predeclared {
+			scope.AddDeclaration((*cpg.Declaration)(f))
+		}
+		scope.LeaveScope((*cpg.Node)(record))
+
+		return (*jnigi.ObjectRef)(f), false
+	}
+
+	return (*jnigi.ObjectRef)(f), !predeclared
+}
+
+// declareFuncDecl creates the function (or method) declaration of funcDecl,
+// including its receiver, parameters and return types, but without its body.
+// If funcDecl is a method, the record it belongs to is returned as well. The
+// function itself is not added to any scope.
+func (this *GoLanguageFrontend) declareFuncDecl(fset *token.FileSet, funcDecl *ast.FuncDecl) (f *cpg.FunctionDeclaration, record *cpg.RecordDeclaration) {
+	var scope = this.GetScopeManager()
+	var receiver *cpg.VariableDeclaration
 
 	if funcDecl.Recv != nil {
 		m := this.NewMethodDeclaration(fset, funcDecl, funcDecl.Name.Name)
@@ -351,25 +999,32 @@ func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *as
 		recv := funcDecl.Recv.List[0]
 		recvType := recv.Type
 
+		// the receiver type is only needed to look up the record, but we need
+		// to remember whether the method has a pointer receiver
 		if star, ok := recv.Type.(*ast.StarExpr); ok {
 			recvType = star.X
+			m.SetHasPointerReceiver(true)
 		}
 
-		var recordType = this.handleType(recvType)
+		// a generic receiver, e.g. List[T], names the type parameters of its type
+		recvType = withoutTypeArguments(recvType)
+
+		var recordType = this.handleType(fset, recvType)
 
 		// The name of the Go receiver is optional. In fact, if the name is not
 		// specified we probably do not need any receiver variable at all,
 		// because the syntax is only there to ensure that this method is part
 		// of the struct, but it is not modifying the receiver.
-		if len(recv.Names) > 0 {
+		if len(recv.Names) > 0 && !isBlank(recv.Names[0]) {
 			receiver = this.NewVariableDeclaration(fset, nil, recv.Names[0].Name)
+			this.registerDeclaration(recv.Names[0], (*cpg.Declaration)(receiver))
 
 			// TODO: should we use the FQN here? FQNs are a mess in the CPG...
 			receiver.SetType(recordType)
 
 			err := m.SetReceiver(receiver)
 			if err != nil {
-				log.Fatal(err)
+				panic(err)
 			}
 		}
 
@@ -377,17 +1032,22 @@ func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *as
 			var recordName = recordType.GetName()
 			var err error
 
-			this.LogInfo("Getting record: %s", recordName)
+			this.LogDebug("Getting record: %s", recordName)
 
-			// TODO: this will only find methods within the current translation unit
-			// this is a limitation that we have for C++ as well
-			record, err = this.GetScopeManager().GetRecordForName(
-				this.GetScopeManager().GetCurrentScope(),
-				recordName)
+			// the type can be declared in any file of the package, so we
+			// first look at the records of all loaded packages. Only the
+			// records known to the scope manager are available otherwise.
+			record = this.declaredRecord(recvType)
 
-			if err != nil {
-				log.Fatal(err)
+			if record == nil {
+				record, err = this.GetScopeManager().GetRecordForName(
+					this.GetScopeManager().GetCurrentScope(),
+					recordName)
+
+				if err != nil {
+					panic(err)
 
+				}
 			}
 
 			if record != nil && !record.IsNil() {
@@ -399,7 +1059,7 @@ func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *as
 
 				err = record.AddMethod(m)
 				if err != nil {
-					log.Fatal(err)
+					panic(err)
 
 				}
 			} else {
@@ -410,6 +1070,41 @@ func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *as
 		f = (*cpg.FunctionDeclaration)(m)
 	} else {
 		f = this.NewFunctionDeclaration(fset, funcDecl, funcDecl.Name.Name)
+
+		// mark test functions, so that they can be excluded or targeted
+		if kind := testFunctionKind(fset.Position(funcDecl.Pos()).Filename, funcDecl); kind != "" {
+			(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{this.NewAnnotation(fset, nil, kind)})
+		}
+
+		// init functions are implicitly called during the initialization of the package
+		if order, ok := this.initFunctionOrder(funcDecl); ok {
+			(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{this.newInitializationAnnotation(fset, order)})
+		}
+	}
+
+	// functions without a body are implemented elsewhere, e.g., in assembly or
+	// by another function via go:linkname, so they are only declarations
+	f.SetIsDefinition(funcDecl.Body != nil)
+
+	this.handleDeprecation(fset, (*cpg.Node)(f), funcDecl.Doc)
+
+	if funcDecl.Recv == nil {
+		if a := this.handleLinkname(fset, funcDecl.Name.Name); a != nil {
+			(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{a})
+		}
+	}
+
+	// e.g. github.com/x/y.(*Server).Handle for a method
+	if this.Package != nil {
+		this.qualifyName((*cpg.Node)(f), this.Package.TypesInfo.Defs[funcDecl.Name])
+	}
+
+	if this.Package != nil && this.Package.TypesInfo != nil {
+		this.handleErrorResults(fset, f, this.Package.TypesInfo.TypeOf(funcDecl.Name))
+
+		if fn, ok := this.Package.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok && funcDecl.Recv != nil {
+			this.handleRPCMethod(fset, f, fn)
+		}
 	}
 
 	if record != nil && !record.IsNil() {
@@ -427,897 +1122,2395 @@ func (this *GoLanguageFrontend) handleFuncDecl(fset *token.FileSet, funcDecl *as
 
 	this.addFuncTypeData(f, fset, funcDecl)
 
-	this.LogDebug("Parsing function body of %s", (*cpg.Node)(f).GetName())
-
-	if funcDecl.Body != nil {
-		// parse body
-		s := this.handleBlockStmt(fset, funcDecl.Body)
-
-		err := f.SetBody((*cpg.Statement)(s))
-		if err != nil {
-			log.Fatal(err)
-		}
-	}
-
 	// leave scope
 	err := scope.LeaveScope((*cpg.Node)(f))
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	if record != nil && !record.IsNil() {
-		scope.AddDeclaration((*cpg.Declaration)(f))
 		scope.LeaveScope((*cpg.Node)(record))
+	}
 
-		return (*jnigi.ObjectRef)(f), false
+	return
+}
+
+// declaredRecord returns the record of the named type referred to by the
+// receiver type recvType, if it was declared by HandleFileRecordDeclarations.
+func (this *GoLanguageFrontend) declaredRecord(recvType ast.Expr) *cpg.RecordDeclaration {
+	if this.Package == nil || this.records == nil {
+		return nil
+	}
+
+	ident, ok := recvType.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	obj, ok := this.Package.TypesInfo.Uses[ident].(*types.TypeName)
+	if !ok {
+		return nil
 	}
 
-	return (*jnigi.ObjectRef)(f), true
+	return this.records[obj]
 }
 
+// handleGenDecl handles all specifications of a (possibly grouped) generic
+// declaration, e.g. var ( a int; b string ), and returns one declaration for
+// each of them.
 func (this *GoLanguageFrontend) handleGenDecl(fset *token.FileSet, genDecl *ast.GenDecl) []*cpg.Declaration {
-	// TODO: Handle multiple declarations
 	res := []*cpg.Declaration{}
 
 	for _, spec := range genDecl.Specs {
 		switch v := spec.(type) {
 		case *ast.ValueSpec:
-			r := this.handleValueSpec(fset, v)
-			if v == nil {
-				continue
-			}
-
-			res = append(res, (*cpg.Declaration)(r))
+			res = append(res, this.handleValueSpec(fset, v, genDecl.Tok)...)
 		case *ast.TypeSpec:
 			r := this.handleTypeSpec(fset, v)
 			if r == nil {
 				continue
 			}
 
+			// the documentation of a group applies to all of its types
+			this.handleDeprecation(fset, (*cpg.Node)(r), v.Doc, genDecl.Doc)
+
 			res = append(res, (*cpg.Declaration)(r))
 		case *ast.ImportSpec:
 			// somehow these end up duplicate in the AST, so do not handle them here
 			continue
 			/*return (*jnigi.ObjectRef)(this.handleImportSpec(fset, v))*/
 		default:
-			this.LogError("Not parsing specication of type %T yet: %+v", v, v)
+			this.LogWarn("Not parsing specication of type %T yet: %+v", v, v)
+			this.Metrics.unhandled(fset, spec)
 		}
 	}
 
 	return res
 }
 
-func (this *GoLanguageFrontend) handleValueSpec(fset *token.FileSet, valueDecl *ast.ValueSpec) *cpg.Declaration {
-	// TODO: more names
-	var ident = valueDecl.Names[0]
+// testFunctionKind returns the kind of test function, i.e., Test, Benchmark or
+// Fuzz, if funcDecl is run by "go test". It follows the rules of the testing
+// package: the function needs to be declared in a test file, its name needs to
+// start with the kind, which is not followed by a lower-case letter, and it
+// needs a single parameter of the respective type, e.g. *testing.T.
+func testFunctionKind(path string, funcDecl *ast.FuncDecl) string {
+	if !strings.HasSuffix(path, "_test.go") || funcDecl.Recv != nil {
+		return ""
+	}
+
+	var params = funcDecl.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 || funcDecl.Type.Results != nil {
+		return ""
+	}
+
+	for _, kind := range []string{"Test", "Benchmark", "Fuzz"} {
+		if !strings.HasPrefix(funcDecl.Name.Name, kind) {
+			continue
+		}
 
-	d := (this.NewVariableDeclaration(fset, valueDecl, ident.Name))
+		suffix := strings.TrimPrefix(funcDecl.Name.Name, kind)
 
-	if valueDecl.Type != nil {
-		t := this.handleType(valueDecl.Type)
+		if r, _ := utf8.DecodeRuneInString(suffix); suffix != "" && unicode.IsLower(r) {
+			return ""
+		}
 
-		d.SetType(t)
+		star, ok := params[0].Type.(*ast.StarExpr)
+		if !ok {
+			return ""
+		}
+
+		// the type is named after the first letter of the kind, e.g. testing.T
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != kind[:1] {
+			return ""
+		}
+
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "testing" {
+			return ""
+		}
+
+		return kind
 	}
 
-	// add an initializer
-	if len(valueDecl.Values) > 0 {
-		// TODO: How to deal with multiple values
-		var expr = this.handleExpr(fset, valueDecl.Values[0])
+	return ""
+}
 
-		if expr != nil {
-			err := d.SetInitializer(expr)
+// handleValueSpec creates one variable declaration for each name in the value
+// specification and pairs it with its corresponding initializer. If a single
+// (multi-value) expression initializes several names, e.g. var a, b = f(), each
+// declaration is initialized with a DestructureTupleExpression of this value.
+//
+// Constants (tok is token.CONST) are marked with a const type. Since a constant
+// spec without values implicitly repeats the previous expression list with
+// the next value of iota, its initializer is the value evaluated by the type
+// checker.
+func (this *GoLanguageFrontend) handleValueSpec(fset *token.FileSet, valueDecl *ast.ValueSpec, tok token.Token) []*cpg.Declaration {
+	var res = []*cpg.Declaration{}
+
+	// a single value for multiple names needs to be destructured
+	var tuple *cpg.Expression
+	if len(valueDecl.Names) > 1 && len(valueDecl.Values) == 1 {
+		tuple = this.handleExpr(fset, valueDecl.Values[0])
+
+		if tuple == nil {
+			tuple = (*cpg.Expression)(this.NewProblemExpression(fset, valueDecl.Values[0], "Could not convert."))
+		}
+	}
+
+	var discarded = true
+
+	for i, ident := range valueDecl.Names {
+		// the blank identifier does not declare anything, but the value of a
+		// variable is still evaluated
+		if isBlank(ident) {
+			if tok == token.VAR && tuple == nil && i < len(valueDecl.Values) {
+				this.handleBlankValue(fset, valueDecl, valueDecl.Values[i])
+			}
+
+			continue
+		}
+
+		discarded = false
+
+		// package-level variables might already have been declared by
+		// HandleFileSymbols, in which case they are already part of the scope
+		d := this.Symbols.variable(ident)
+		if d == nil {
+			d = this.declareValueSpecName(fset, valueDecl, i, tok)
+
+			res = append(res, (*cpg.Declaration)(d))
+		}
+
+		// add an initializer
+		if tuple != nil {
+			tupdest := this.destructure(fset, valueDecl, tuple, valueDecl.Values, i)
+
+			err := d.SetInitializer((*cpg.Expression)(tupdest))
+			if err != nil {
+				panic(err)
+			}
+		} else if i < len(valueDecl.Values) {
+			var expr = this.handleExpr(fset, valueDecl.Values[i])
+
+			if expr != nil {
+				err := d.SetInitializer(expr)
+				if err != nil {
+					panic(err)
+				}
+			}
+		} else if c := this.constantOf(ident); tok == token.CONST && c != nil {
+			err := d.SetInitializer((*cpg.Expression)(this.handleConstantValue(fset, ident, c.Val(), c.Type())))
 			if err != nil {
-				log.Fatal(err)
+				panic(err)
 			}
 		}
 	}
 
-	return (*cpg.Declaration)(d)
+	// none of the names needs the destructured value, e.g., in var _, _ = f()
+	if tuple != nil && discarded && tok == token.VAR {
+		this.discard(tuple)
+	}
+
+	return res
 }
 
-func (this *GoLanguageFrontend) handleTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec) *cpg.Declaration {
-	err := this.LogDebug("Type specifier with name %s and type (%T, %+v)", typeDecl.Name.Name, typeDecl.Type, typeDecl.Type)
-	if err != nil {
-		log.Fatal(err)
+// destructure creates a DestructureTupleExpression for the i-th value of tuple,
+// which is the (multi-value) expression of values, or the TupleExpression of
+// all of them. The type of the destructured value is taken from the type
+// checker, e.g., the i-th result of a called function, since the type of the
+// tuple itself is not known yet.
+func (this *GoLanguageFrontend) destructure(fset *token.FileSet, astNode ast.Node, tuple *cpg.Expression, values []ast.Expr, i int) *cpg.DestructureTupleExpression {
+	tupdest := this.NewDestructureTupleExpression(fset, astNode)
+
+	tupdest.SetTupleIndex(i)
+	if tuple != nil {
+		tupdest.SetRefersTo(tuple)
 	}
 
-	switch v := typeDecl.Type.(type) {
-	case *ast.StructType:
-		return (*cpg.Declaration)(this.handleStructTypeSpec(fset, typeDecl, v))
-	case *ast.InterfaceType:
-		return (*cpg.Declaration)(this.handleInterfaceTypeSpec(fset, typeDecl, v))
-	case *ast.Ident:
-		return (*cpg.Declaration)(this.handleTypeAlias(fset, typeDecl, v))
+	if t := this.destructuredType(values, i); t != nil {
+		(*cpg.Expression)(tupdest).SetType(t)
 	}
 
-	return nil
+	return tupdest
 }
 
-func (this *GoLanguageFrontend) handleImportSpec(fset *token.FileSet, importSpec *ast.ImportSpec) *cpg.Declaration {
-	this.LogDebug("Import specifier with: %+v %s)", *importSpec, importSpec.Path)
-
-	i := this.NewIncludeDeclaration(fset, importSpec, this.getImportName(importSpec))
-
-	var scope = this.GetScopeManager()
+// destructuredType returns the type of the i-th value of values, which is
+// either a single (multi-value) expression or a list of single values, if it
+// was recorded by the type checker.
+func (this *GoLanguageFrontend) destructuredType(values []ast.Expr, i int) *cpg.Type {
+	if this.Package == nil || this.Package.TypesInfo == nil || len(values) == 0 {
+		return nil
+	}
 
-	i.SetFilename(importSpec.Path.Value[1 : len(importSpec.Path.Value)-1])
+	var t types.Type
+	if len(values) > 1 && i < len(values) {
+		t = this.Package.TypesInfo.TypeOf(values[i])
+	} else if tuple, ok := this.Package.TypesInfo.TypeOf(values[0]).(*types.Tuple); ok && i < tuple.Len() {
+		t = tuple.At(i).Type()
+	} else if len(values) == 1 && i == 0 && !ok {
+		// a single value, e.g., the received one in case v := <-ch
+		t = this.Package.TypesInfo.TypeOf(values[0])
+	}
 
-	err := scope.AddDeclaration((*cpg.Declaration)(i))
-	if err != nil {
-		log.Fatal(err)
+	if t == nil {
+		return nil
 	}
 
-	return (*cpg.Declaration)(i)
+	return this.handleTypingType(t)
 }
 
-func (this *GoLanguageFrontend) modulePath() string {
-	if this.Module == nil {
-		return this.File.Name.Name
+// handleBlankValue handles the value of a variable, which is named by the blank
+// identifier. A typed one, such as in var _ io.Writer = (*File)(nil), is a
+// compile-time assertion that a type implements an interface.
+func (this *GoLanguageFrontend) handleBlankValue(fset *token.FileSet, valueDecl *ast.ValueSpec, value ast.Expr) {
+	if expr := this.handleExpr(fset, value); expr != nil {
+		this.discard(expr)
 	}
 
-	packPath := this.Module.Module.Mod.Path
-	if this.RelativeFilePath != "" {
-		packPath += "/" + this.RelativeFilePath
+	if valueDecl.Type != nil {
+		this.handleImplementsAssertion(valueDecl.Type, value)
 	}
-
-	// if this.File.Name.Name == "main" {
-	// 	packPath += "/main"
-	// }
-
-	return packPath
 }
 
-func (this *GoLanguageFrontend) handleIdentAsName(ident *ast.Ident) string {
-	if this.isBuiltinType(ident.Name) {
-		return ident.Name
-	} else {
-		return fmt.Sprintf("%s.%s", this.modulePath(), ident.Name)
+// discard keeps expr, whose value is discarded, as a statement of the current
+// block or, on package level, of the translation unit, so that it is still
+// evaluated.
+func (this *GoLanguageFrontend) discard(expr *cpg.Expression) {
+	if block := this.GetScopeManager().GetCurrentBlock(); block != nil && !block.IsNil() {
+		block.AddStatement((*cpg.Statement)(expr))
+	} else if this.CurrentTU != nil {
+		this.CurrentTU.AddStatement((*cpg.Statement)(expr))
 	}
 }
 
-func (this *GoLanguageFrontend) handleStructTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec, structType *ast.StructType) *cpg.RecordDeclaration {
-	r := this.NewRecordDeclaration(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), "struct")
+// handleImplementsAssertion handles the assertion that the type of value
+// implements the interface typ. The interface is added as a super class of the
+// record of the type, like HandleInterfaceImplementations does, which however
+// only knows the interfaces of the analyzed packages.
+func (this *GoLanguageFrontend) handleImplementsAssertion(typ ast.Expr, value ast.Expr) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return
+	}
 
-	var scope = this.GetScopeManager()
+	iface := this.Package.TypesInfo.TypeOf(typ)
+	if iface == nil || !types.IsInterface(iface) {
+		return
+	}
 
-	scope.EnterScope((*cpg.Node)(r))
+	named, _ := receiverType(this.Package.TypesInfo.TypeOf(value))
+	if named == nil {
+		return
+	}
 
-	this.LogDebug("Handle struct: %s", this.handleIdentAsName(typeDecl.Name))
+	r, ok := this.records[named.Origin().Obj()]
+	if !ok || r == nil {
+		return
+	}
 
-	if !structType.Incomplete {
-		for _, field := range structType.Fields.List {
+	if t := this.handleTypingType(iface); !r.HasSuperClass(t) {
+		r.AddSuperClass(t)
+	}
+}
 
-			// a field can also have no name, which means that it is embedded, not quite
-			// sure yet how to handle this, but since the embedded field can be accessed
-			// by its type, it could make sense to name the field according to the type
+// declareValueSpecName creates the variable declaration for the i-th name of
+// valueDecl, without its initializer.
+func (this *GoLanguageFrontend) declareValueSpecName(fset *token.FileSet, valueDecl *ast.ValueSpec, i int, tok token.Token) *cpg.VariableDeclaration {
+	var ident = valueDecl.Names[i]
 
-			var name string
-			embedded := false
-			t := this.handleType(field.Type)
+	var astNode ast.Node = valueDecl
+	if len(valueDecl.Names) > 1 {
+		astNode = ident
+	}
 
-			if field.Names == nil {
-				// retrieve the root type name
-				var typeName = t.GetRoot().GetName()
+	d := this.NewVariableDeclaration(fset, astNode, ident.Name)
+	this.registerDeclaration(ident, (*cpg.Declaration)(d))
 
-				this.LogDebug("Handling embedded field of type %s", typeName)
+	if order, ok := this.variableInitializerOrder(ident); ok {
+		(*cpg.Node)(d).AddAnnotations([]*cpg.Annotation{this.newInitializationAnnotation(fset, order)})
+	}
 
-				s := strings.Split(typeName, ".")
-				name = s[len(s)-1]
-				embedded = true
-			} else {
-				this.LogDebug("Handling field %s", field.Names[0].Name)
+	var t *cpg.Type
+	if valueDecl.Type != nil {
+		t = this.handleType(fset, valueDecl.Type)
+	} else if this.Package != nil && (this.declaringSymbols || (len(valueDecl.Names) > 1 && len(valueDecl.Values) == 1)) {
+		// Neither a destructured value nor a variable that is declared before
+		// its initializer is handled carries a type on its own, so we take
+		// the type of the variable from the type checker
+		if obj := this.Package.TypesInfo.Defs[ident]; obj != nil && tok != token.CONST {
+			t = this.handleTypingType(obj.Type())
+		}
+	}
 
-				// TODO: Multiple names?
-				name = field.Names[0].Name
-			}
+	if t != nil {
+		d.SetType(t)
+	}
 
-			f := this.NewFieldDeclaration(fset, field, name)
+	if tok == token.CONST {
+		var ct = t
+		if c := this.constantOf(ident); ct == nil && c != nil {
+			ct = this.handleTypingType(types.Default(c.Type()))
+		}
 
-			f.SetType(t)
-			f.SetIsEmbeddedField(embedded)
+		if ct != nil {
+			// the type could be shared with other nodes, so we need our own copy
+			ct = ct.Duplicate()
+			ct.SetConst(true)
 
-			scope.AddDeclaration((*cpg.Declaration)(f))
+			d.SetType(ct)
 		}
 	}
 
-	scope.LeaveScope((*cpg.Node)(r))
+	if tok == token.VAR {
+		this.handleEmbed(fset, valueDecl, d, t)
+	}
 
-	return r
+	// the documentation of a group applies to all of its variables or constants
+	if genDecl := this.enclosingGenDecl(valueDecl); genDecl != nil {
+		this.handleDeprecation(fset, (*cpg.Node)(d), valueDecl.Doc, genDecl.Doc)
+	}
+
+	return d
 }
 
-func (this *GoLanguageFrontend) handleTypeAlias(fset *token.FileSet, typeDecl *ast.TypeSpec, aliasName *ast.Ident) *cpg.RecordDeclaration {
-	r := this.NewRecordDeclaration(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), "type")
+// constantOf returns the constant defined by ident, if type information is available.
+func (this *GoLanguageFrontend) constantOf(ident *ast.Ident) *types.Const {
+	if this.Package == nil {
+		return nil
+	}
 
-	var scope = this.GetScopeManager()
+	c, _ := this.Package.TypesInfo.Defs[ident].(*types.Const)
 
-	scope.EnterScope((*cpg.Node)(r))
-	scope.LeaveScope((*cpg.Node)(r))
+	return c
+}
 
-	decl, _ := this.handleFuncDecl(fset, &ast.FuncDecl{
-		Name: ast.NewIdent(typeDecl.Name.Name),
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{ast.NewIdent("_")},
-						// TODO: Handle tree of aliased types.
-						Type: &ast.BadExpr{},
-					},
-				},
-			},
-			Results: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: typeDecl.Name,
-					},
-				},
-			},
-		},
-	})
+// handleConstantValue creates a literal out of a constant value that was
+// evaluated by the type checker, e.g. for iota.
+func (this *GoLanguageFrontend) handleConstantValue(fset *token.FileSet, astNode ast.Node, val constant.Value, typ types.Type) *cpg.Literal {
+	var value cpg.Castable
 
-	if decl != nil {
-		scope.AddDeclaration((*cpg.Declaration)(decl))
+	switch val.Kind() {
+	case constant.Bool:
+		value = cpg.NewBoolean(constant.BoolVal(val))
+	case constant.String:
+		value = cpg.NewString(constant.StringVal(val))
+	case constant.Int:
+		value, _ = integerValue(val)
+	case constant.Float:
+		f, _ := constant.Float64Val(val)
+		value = cpg.NewDouble(f)
+	case constant.Complex:
+		value = complexValue(val)
 	}
 
-	return r
+	return this.NewLiteral(fset, astNode, value, this.handleTypingType(types.Default(typ)))
 }
 
-func (this *GoLanguageFrontend) handleInterfaceTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec, interfaceType *ast.InterfaceType) *cpg.RecordDeclaration {
-	r := this.NewRecordDeclaration(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), "interface")
+// integerValue converts an integer constant into the smallest fitting Java number, similar to
+// what the C++ frontend does. It also returns the name of the type the value fits into, which is
+// uint64 for values that overflow int64.
+func integerValue(val constant.Value) (value cpg.Castable, typ string) {
+	if i, exact := constant.Int64Val(val); exact {
+		if i >= math.MinInt32 && i <= math.MaxInt32 {
+			return cpg.NewInteger(int(i)), "int"
+		}
 
-	var scope = this.GetScopeManager()
+		return cpg.NewLong(i), "int"
+	}
 
-	scope.EnterScope((*cpg.Node)(r))
+	if val.Kind() != constant.Int {
+		// the literal was malformed
+		return nil, "int"
+	}
 
-	if !interfaceType.Incomplete {
-		for _, method := range interfaceType.Methods.List {
-			t := this.handleType(method.Type)
+	// untyped constants can be arbitrarily large, but everything beyond int64 needs to be a
+	// BigInteger in Java
+	if _, exact := constant.Uint64Val(val); exact {
+		typ = "uint64"
+	} else {
+		typ = "int"
+	}
 
-			// Even though this list is called "Methods", it contains all kinds
-			// of things, so we need to proceed with caution. Only if the
-			// "method" actually has a name, we declare a new method
-			// declaration.
-			if len(method.Names) > 0 {
-				m := this.NewMethodDeclaration(fset, method, method.Names[0].Name)
-				m.SetType(t)
-				scope.AddDeclaration((*cpg.Declaration)(m))
-				scope.EnterScope((*cpg.Node)(m))
+	return cpg.NewBigInteger(val.ExactString()), typ
+}
 
-				this.addFuncTypeData((*cpg.FunctionDeclaration)(m), fset, &ast.FuncDecl{
-					Doc:  method.Doc,
-					Name: method.Names[0],
-					Type: method.Type.(*ast.FuncType),
-				})
+// complexValue converts a complex (or imaginary) constant into its Java representation.
+func complexValue(val constant.Value) cpg.Castable {
+	if val.Kind() == constant.Unknown {
+		return nil
+	}
 
-				r.AddMethod(m)
+	re, _ := constant.Float64Val(constant.Real(val))
+	im, _ := constant.Float64Val(constant.Imag(val))
 
-				// leave scope
-				err := scope.LeaveScope((*cpg.Node)(m))
-				if err != nil {
-					log.Fatal(err)
-				}
-			} else {
-				this.LogDebug("Adding %s as super class of interface %s", t.GetName(), (*cpg.Node)(r).GetName())
-				// Otherwise, it contains either types or interfaces. For now we
-				// hope that it only has interfaces. We consider embedded
-				// interfaces as sort of super types for this interface.
-				r.AddSuperClass(t)
-			}
+	return cpg.NewComplex(re, im)
+}
+
+func (this *GoLanguageFrontend) handleTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec) *cpg.Declaration {
+	err := this.LogDebug("Type specifier with name %s and type (%T, %+v)", typeDecl.Name.Name, typeDecl.Type, typeDecl.Type)
+	if err != nil {
+		panic(err)
+	}
+
+	// an alias, e.g. type A = B, is just another name for the very same type
+	if typeDecl.Assign.IsValid() {
+		return (*cpg.Declaration)(this.handleTypeAliasSpec(fset, typeDecl))
+	}
+
+	var r *cpg.RecordDeclaration
+
+	switch v := typeDecl.Type.(type) {
+	case *ast.StructType:
+		r = this.handleStructTypeSpec(fset, typeDecl, v)
+	case *ast.InterfaceType:
+		return (*cpg.Declaration)(this.handleInterfaceTypeSpec(fset, typeDecl, v))
+	default:
+		r = this.handleDefinedTypeSpec(fset, typeDecl)
+	}
+
+	// remember the record, so that we can later check which interfaces it
+	// implements and link the types referring to it
+	if obj, ok := this.typeNameOf(typeDecl); ok {
+		if this.records == nil {
+			this.records = map[*types.TypeName]*cpg.RecordDeclaration{}
 		}
+
+		// the records of all files are needed afterwards, i.e., outside of
+		// the local frame of the current file, see HandleInLocalFrame
+		this.records[obj] = (*cpg.RecordDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(r)))
 	}
 
-	scope.LeaveScope((*cpg.Node)(r))
+	return (*cpg.Declaration)(r)
+}
 
-	return r
+// typeNameOf returns the type name declared by typeDecl according to the type
+// checker, if it is available.
+func (this *GoLanguageFrontend) typeNameOf(typeDecl *ast.TypeSpec) (obj *types.TypeName, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil, false
+	}
+
+	obj, ok = this.Package.TypesInfo.Defs[typeDecl.Name].(*types.TypeName)
+
+	return
 }
 
-func (this *GoLanguageFrontend) handleBlockStmt(fset *token.FileSet, blockStmt *ast.BlockStmt) *cpg.CompoundStatement {
-	this.LogDebug("Handling block statement: %+v", *blockStmt)
+func (this *GoLanguageFrontend) handleImportSpec(fset *token.FileSet, importSpec *ast.ImportSpec) *cpg.Declaration {
+	this.LogDebug("Import specifier with: %+v %s)", *importSpec, importSpec.Path.Value)
 
-	c := this.NewCompoundStatement(fset, blockStmt)
+	i := this.NewIncludeDeclaration(fset, importSpec, this.getImportName(importSpec))
 
-	// enter scope
-	this.GetScopeManager().EnterScope((*cpg.Node)(c))
+	// the documentation of an ungrouped import belongs to its declaration
+	doc := importSpec.Doc
+	if genDecl := this.enclosingGenDecl(importSpec); doc == nil && genDecl != nil && !genDecl.Lparen.IsValid() {
+		doc = genDecl.Doc
+	}
 
-	for _, stmt := range blockStmt.List {
-		var s *cpg.Statement
+	this.handleComments((*cpg.Node)(i), importSpec, doc, importSpec.Comment)
 
-		s = this.handleStmt(fset, stmt)
+	var scope = this.GetScopeManager()
 
-		if s != nil {
-			// add statement
-			c.AddStatement(s)
+	path := importPath(importSpec)
+	i.SetFilename(path)
+
+	// the package could not be loaded, e.g. because its module is missing, so
+	// its symbols will remain unresolved. This is only known, if the package was
+	// type checked. cgo's pseudo-package C is never loaded.
+	if _, ok := this.importedPackageName(path); !ok && this.Package != nil && this.Package.Types != nil && path != "C" {
+		this.LogWarn("Could not resolve import %s", path)
+
+		problem := this.NewProblemDeclaration(fset, importSpec, fmt.Sprintf("could not resolve import %s", path))
+
+		err := scope.AddDeclaration(problem)
+		if err != nil {
+			panic(err)
 		}
 	}
 
-	// leave scope
-	this.GetScopeManager().LeaveScope((*cpg.Node)(c))
+	// blank and dot imports cannot be referenced by their name, so we mark them
+	if isBlankImport(importSpec) || isDotImport(importSpec) {
+		(*cpg.Node)(i).AddAnnotations([]*cpg.Annotation{this.handleImportKind(fset, importSpec)})
+	}
 
-	return c
+	err := scope.AddDeclaration((*cpg.Declaration)(i))
+	if err != nil {
+		panic(err)
+	}
+
+	return (*cpg.Declaration)(i)
 }
 
-func (this *GoLanguageFrontend) handleForStmt(fset *token.FileSet, forStmt *ast.ForStmt) *cpg.ForStatement {
-	this.LogDebug("Handling for statement: %+v", *forStmt)
+// handleImportKind creates an annotation, which describes a blank import, i.e.,
+// one that only imports the package for its side effects, or a dot import.
+func (this *GoLanguageFrontend) handleImportKind(fset *token.FileSet, importSpec *ast.ImportSpec) *cpg.Annotation {
+	var kind = "dot"
+	if isBlankImport(importSpec) {
+		kind = "blank"
+	}
 
-	f := this.NewForStatement(fset, forStmt)
+	lit := this.NewLiteral(fset, nil, cpg.NewString(kind), this.parseType("string"))
 
-	var scope = this.GetScopeManager()
+	a := this.NewAnnotation(fset, nil, "import")
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, nil, "kind", (*cpg.Expression)(lit)),
+	})
 
-	scope.EnterScope((*cpg.Node)(f))
+	return a
+}
 
-	if initStatement := this.handleStmt(fset, forStmt.Init); initStatement != nil {
-		f.SetInitializerStatement(initStatement)
-	}
+// enterPackageNamespace creates the namespace declaration, which represents the
+// package, and enters its scope. If nested namespaces are configured, it is
+// nested in one namespace per segment of the package path, e.g., the package
+// github.com/org/repo/pkg in github.com/org/repo, github.com/org and
+// github.com. Like the package itself, each namespace is named after its full
+// path, which keeps the names of its declarations unique. The namespaces are
+// returned from the outermost to the innermost one.
+func (this *GoLanguageFrontend) enterPackageNamespace(fset *token.FileSet) (namespaces []*cpg.NamespaceDeclaration) {
+	var (
+		scope = this.GetScopeManager()
+		path  = this.modulePath()
+		names = []string{path}
+	)
 
-	if condition := this.handleExpr(fset, forStmt.Cond); condition != nil {
-		f.SetCondition(condition)
-	}
+	if this.Config != nil && this.Config.NestedNamespaces {
+		names = nil
 
-	if iter := this.handleStmt(fset, forStmt.Post); iter != nil {
-		f.SetIterationStatement(iter)
+		for i, r := range path {
+			if r == '/' {
+				names = append(names, path[:i])
+			}
+		}
+
+		names = append(names, path)
 	}
 
-	if body := this.handleStmt(fset, forStmt.Body); body != nil {
-		f.SetStatement(body)
+	for _, name := range names {
+		namespace := this.NewNamespaceDeclaration(fset, nil, name)
+		scope.EnterScope((*cpg.Node)(namespace))
+
+		namespaces = append(namespaces, namespace)
 	}
 
-	scope.LeaveScope((*cpg.Node)(f))
+	this.namespace = namespaces[len(namespaces)-1]
 
-	return f
+	// only the namespace of the package itself is documented
+	if doc := this.packageDoc(fset); doc != "" {
+		(*cpg.Node)(this.namespace).SetComment(doc)
+	}
+
+	return
 }
 
-func (this *GoLanguageFrontend) handleReturnStmt(fset *token.FileSet, returnStmt *ast.ReturnStmt) *cpg.ReturnStatement {
-	this.LogDebug("Handling return statement: %+v", *returnStmt)
+// packageDoc returns the documentation of the current package, i.e., the doc
+// comment of its package clause. By convention, it is placed in a file named
+// doc.go, if it is longer. Otherwise, it is taken from the first file of the
+// package that has one.
+func (this *GoLanguageFrontend) packageDoc(fset *token.FileSet) string {
+	files := []*ast.File{this.File}
+	if this.Package != nil && len(this.Package.Syntax) > 0 {
+		files = this.Package.Syntax
+	}
 
-	r := this.NewReturnStatement(fset, returnStmt)
+	var doc *ast.CommentGroup
+	for _, file := range files {
+		if file == nil || file.Doc == nil {
+			continue
+		}
 
-	if returnStmt.Results != nil && len(returnStmt.Results) > 0 {
-		var e *cpg.Expression
+		if filepath.Base(fset.Position(file.Package).Filename) == "doc.go" {
+			doc = file.Doc
+			break
+		}
 
-		if len(returnStmt.Results) > 1 {
-			tup := this.NewTupleExpression(fset, returnStmt)
+		if doc == nil {
+			doc = file.Doc
+		}
+	}
 
-			for _, res := range returnStmt.Results {
-				subE := this.handleExpr(fset, res)
+	if doc == nil {
+		return ""
+	}
 
-				if subE != nil {
-					tup.AddMember(subE)
-				} else {
-					tup.AddMember(
-						this.NewProblemExpression(fset, res, "Could not parse return value"),
-					)
-				}
-			}
+	return strings.TrimRight(doc.Text(), "\n")
+}
 
-			e = (*cpg.Expression)(tup)
-		} else {
-			e = this.handleExpr(fset, returnStmt.Results[0])
+// fileComment returns the comments of the file itself, i.e., the ones before
+// its package clause, such as a license header or the package documentation.
+// Directives, such as build constraints, are omitted.
+func fileComment(file *ast.File) string {
+	var texts []string
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
 		}
 
-		if e != nil {
-			r.SetReturnValue(e)
+		if text := strings.TrimRight(group.Text(), "\n"); text != "" {
+			texts = append(texts, text)
 		}
-	} else {
-		// TODO: connect result statement to result variables
 	}
 
-	return r
+	return strings.Join(texts, "\n\n")
 }
 
-func (this *GoLanguageFrontend) handleIncDecStmt(fset *token.FileSet, incDecStmt *ast.IncDecStmt) *cpg.UnaryOperator {
-	this.LogDebug("Handling decimal increment statement: %+v", *incDecStmt)
+// leavePackageNamespace leaves the scopes of the namespaces created by
+// enterPackageNamespace and adds each of them to its enclosing scope.
+func (this *GoLanguageFrontend) leavePackageNamespace(namespaces []*cpg.NamespaceDeclaration) {
+	scope := this.GetScopeManager()
 
-	var opCode string
-	if incDecStmt.Tok == token.INC {
-		opCode = "++"
+	this.namespace = nil
+
+	for i := len(namespaces) - 1; i >= 0; i-- {
+		scope.LeaveScope((*cpg.Node)(namespaces[i]))
+		scope.AddDeclaration((*cpg.Declaration)(namespaces[i]))
 	}
+}
 
-	if incDecStmt.Tok == token.DEC {
-		opCode = "--"
+func (this *GoLanguageFrontend) modulePath() string {
+	// external packages are not part of our module, so we use their import path
+	if this.Dependency && this.Package != nil {
+		return this.Package.PkgPath
 	}
 
-	u := this.NewUnaryOperator(fset, incDecStmt, opCode, true, false)
+	if this.Module == nil {
+		return this.File.Name.Name
+	}
 
-	if input := this.handleExpr(fset, incDecStmt.X); input != nil {
-		u.SetInput(input)
+	packPath := this.Module.Path
+	if this.RelativeFilePath != "" {
+		packPath += "/" + this.RelativeFilePath
 	}
 
-	return u
+	// if this.File.Name.Name == "main" {
+	// 	packPath += "/main"
+	// }
+
+	return packPath
 }
 
-func (this *GoLanguageFrontend) handleStmt(fset *token.FileSet, stmt ast.Stmt) (s *cpg.Statement) {
-	this.LogDebug("Handling statement (%T): %+v", stmt, stmt)
+func (this *GoLanguageFrontend) handleIdentAsName(ident *ast.Ident) string {
+	if this.isBuiltinType(ident.Name) {
+		return ident.Name
+	} else {
+		return fmt.Sprintf("%s.%s", this.modulePath(), ident.Name)
+	}
+}
 
-	switch v := stmt.(type) {
-	case *ast.ExprStmt:
-		// in our cpg, each expression is also a statement,
-		// so we do not need an expression statement wrapper
-		s = (*cpg.Statement)(this.handleExpr(fset, v.X))
-	case *ast.AssignStmt:
-		s = (*cpg.Statement)(this.handleAssignStmt(fset, v))
-	case *ast.DeclStmt:
-		s = (*cpg.Statement)(this.handleDeclStmt(fset, v))
-	case *ast.IfStmt:
-		s = (*cpg.Statement)(this.handleIfStmt(fset, v))
-	case *ast.SwitchStmt:
-		s = (*cpg.Statement)(this.handleSwitchStmt(fset, v))
-	case *ast.CaseClause:
-		s = (*cpg.Statement)(this.handleCaseClause(fset, v))
-	case *ast.BlockStmt:
-		s = (*cpg.Statement)(this.handleBlockStmt(fset, v))
-	case *ast.ForStmt:
-		s = (*cpg.Statement)(this.handleForStmt(fset, v))
-	case *ast.ReturnStmt:
-		s = (*cpg.Statement)(this.handleReturnStmt(fset, v))
-	case *ast.IncDecStmt:
-		s = (*cpg.Statement)(this.handleIncDecStmt(fset, v))
-	case *ast.RangeStmt:
-		s = (*cpg.Statement)(this.handleRangeStmnt(fset, v))
-	case *ast.GoStmt:
-		s = (*cpg.Statement)(this.handleExpr(fset, v.Call))
-	case *ast.DeferStmt:
-		s = (*cpg.Statement)(this.handleExpr(fset, v.Call))
-	case *ast.BranchStmt:
-		s = nil
-	case nil:
-		s = nil
-	default:
-		this.LogError("Not parsing statement of type %T yet: %+v", v, v)
-		s = nil
+func (this *GoLanguageFrontend) handleStructTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec, structType *ast.StructType) *cpg.RecordDeclaration {
+	return this.handleStructType(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), structType)
+}
+
+// handleAnonymousStructType declares an implicit record for a struct type
+// literal, such as in var x struct{ A int }, and returns its type. Since the
+// struct has no name, a synthetic one is derived from its position within the
+// package. The record belongs to the package, even if the struct type is used
+// within a function or the field of another record.
+func (this *GoLanguageFrontend) handleAnonymousStructType(fset *token.FileSet, structType *ast.StructType) *cpg.Type {
+	pos := fset.Position(structType.Pos())
+	file := strings.TrimSuffix(filepath.Base(pos.Filename), ".go")
+
+	pkg := this.modulePath()
+	if this.Package != nil {
+		pkg = this.Package.PkgPath
 	}
 
-	if s != nil {
-		this.handleComments((*cpg.Node)(s), stmt)
+	name := fmt.Sprintf("%s.struct_%s_%d_%d", pkg, sanitizeName(file), pos.Line, pos.Column)
+
+	// the same type expression could be handled more than once, but we only
+	// want to declare the record once
+	if !this.anonymousStructs[structType] {
+		if this.anonymousStructs == nil {
+			this.anonymousStructs = map[*ast.StructType]bool{}
+		}
+
+		this.anonymousStructs[structType] = true
+
+		r := this.handleStructType(fset, structType, name, structType)
+		(*cpg.Node)(r).SetImplicit(true)
+
+		if err := this.declarePackageRecord(r); err != nil {
+			this.LogError("Could not declare record %s: %v", name, err)
+		}
+	}
+
+	return this.parseType(name)
+}
+
+// declarePackageRecord adds r to the scope of the namespace of the current
+// package, instead of the current scope.
+func (this *GoLanguageFrontend) declarePackageRecord(r *cpg.RecordDeclaration) error {
+	scope := this.GetScopeManager()
+
+	if this.namespace != nil {
+		if s := scope.LookupScopeOf((*cpg.Node)(this.namespace)); !s.IsNil() {
+			return s.AddDeclaration((*cpg.Declaration)(r))
+		}
+	}
+
+	return scope.AddDeclaration((*cpg.Declaration)(r))
+}
+
+// sanitizeName replaces all characters of s that are not allowed in an identifier.
+func sanitizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return '_'
+	}, s)
+}
+
+func (this *GoLanguageFrontend) handleStructType(fset *token.FileSet, astNode ast.Node, name string, structType *ast.StructType) *cpg.RecordDeclaration {
+	r := this.NewRecordDeclaration(fset, astNode, name, "struct")
+
+	var scope = this.GetScopeManager()
+
+	scope.EnterScope((*cpg.Node)(r))
+
+	this.LogDebug("Handle struct: %s", name)
+
+	if !structType.Incomplete {
+		for _, field := range structType.Fields.List {
+
+			// a field can also have no name, which means that it is embedded, not quite
+			// sure yet how to handle this, but since the embedded field can be accessed
+			// by its type, it could make sense to name the field according to the type
+
+			t := this.handleType(fset, field.Type)
+
+			if field.Names == nil {
+				// retrieve the root type name
+				var typeName = t.GetRoot().GetName()
+
+				this.LogDebug("Handling embedded field of type %s", typeName)
+
+				s := strings.Split(typeName, ".")
+				this.declareField(fset, field, s[len(s)-1], t, true)
+
+				continue
+			}
+
+			// a group of fields, e.g. X, Y float64, shares its type and tag,
+			// but each of them is a field of its own
+			for _, ident := range field.Names {
+				this.LogDebug("Handling field %s", ident.Name)
+
+				this.declareField(fset, field, ident.Name, t, false)
+			}
+		}
+	}
+
+	scope.LeaveScope((*cpg.Node)(r))
+
+	// e.g. the struct of a message in a .pb.go file
+	if !structType.Incomplete && this.isProtocGenerated() && isProtoMessage(structType) {
+		(*cpg.Node)(r).AddAnnotations([]*cpg.Annotation{this.NewAnnotation(fset, nil, "protoMessage")})
+	}
+
+	return r
+}
+
+// declareField declares a field with the given name, which is (one of the
+// names) declared by field, in the current record.
+func (this *GoLanguageFrontend) declareField(fset *token.FileSet, field *ast.Field, name string, t *cpg.Type, embedded bool) {
+	f := this.NewFieldDeclaration(fset, field, name)
+
+	f.SetType(t)
+	f.SetIsEmbeddedField(embedded)
+
+	if field.Tag != nil {
+		(*cpg.Node)(f).AddAnnotations(this.handleStructTag(fset, field.Tag))
+
+		if this.isProtocGenerated() {
+			if a := this.handleProtoField(fset, field.Tag); a != nil {
+				(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{a})
+			}
+		}
+	}
+
+	this.handleDeprecation(fset, (*cpg.Node)(f), field.Doc)
+
+	this.handleComments((*cpg.Node)(f), field, field.Doc, field.Comment)
+
+	this.GetScopeManager().AddDeclaration((*cpg.Declaration)(f))
+}
+
+// handleStructTag creates one annotation for each key of a struct field tag,
+// e.g. `json:"email,omitempty"`. The (unparsed) value of the key is stored in
+// the "value" member of the annotation.
+func (this *GoLanguageFrontend) handleStructTag(fset *token.FileSet, tag *ast.BasicLit) (annotations []*cpg.Annotation) {
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		this.LogWarn("Could not unquote struct tag %s: %v", tag.Value, err)
+		return
+	}
+
+	for _, kv := range parseStructTag(value) {
+		a := this.NewAnnotation(fset, tag, kv[0])
+
+		lit := this.NewLiteral(fset, tag, cpg.NewString(kv[1]), this.parseType("string"))
+		a.SetMembers([]*cpg.AnnotationMember{
+			this.NewAnnotationMember(fset, tag, "value", (*cpg.Expression)(lit)),
+		})
+
+		annotations = append(annotations, a)
+	}
+
+	return
+}
+
+// parseStructTag splits a struct tag into its key/value pairs. It follows the
+// conventional format that is also used by reflect.StructTag, which
+// unfortunately has no way to list all keys of a tag.
+func parseStructTag(tag string) (pairs [][2]string) {
+	for tag != "" {
+		// skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// scan to colon. A space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := tag[:i]
+		tag = tag[i+1:]
+
+		// scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		qvalue := tag[:i+1]
+		tag = tag[i+1:]
+
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			break
+		}
+
+		pairs = append(pairs, [2]string{name, value})
+	}
+
+	return
+}
+
+// handleTypeAliasSpec handles an alias, e.g. type A = B, as a typedef, since
+// both names denote the same type.
+func (this *GoLanguageFrontend) handleTypeAliasSpec(fset *token.FileSet, typeDecl *ast.TypeSpec) *cpg.TypedefDeclaration {
+	typedef := this.NewTypedefDeclaration(fset, typeDecl,
+		this.handleType(fset, typeDecl.Type),
+		this.handleType(fset, typeDecl.Name),
+	)
+
+	this.GetScopeManager().AddTypedef(typedef)
+
+	return typedef
+}
+
+// handleDefinedTypeSpec handles a defined type, which is neither a struct nor
+// an interface, e.g. type Names []string. It is a distinct type with its own
+// methods, so it becomes a record, which is based on its underlying type.
+func (this *GoLanguageFrontend) handleDefinedTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec) *cpg.RecordDeclaration {
+	r := this.NewRecordDeclaration(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), "type")
+
+	var scope = this.GetScopeManager()
+
+	scope.EnterScope((*cpg.Node)(r))
+	scope.LeaveScope((*cpg.Node)(r))
+
+	// like the type checker, we follow a chain of defined types, e.g. the
+	// underlying type of type A B is the one of B, so that conversions between
+	// them can be checked
+	if obj, ok := this.typeNameOf(typeDecl); ok {
+		r.SetUnderlyingType(this.handleTypingType(obj.Type().Underlying()))
+	} else {
+		r.SetUnderlyingType(this.handleType(fset, typeDecl.Type))
+	}
+
+	return r
+}
+
+func (this *GoLanguageFrontend) handleInterfaceTypeSpec(fset *token.FileSet, typeDecl *ast.TypeSpec, interfaceType *ast.InterfaceType) *cpg.RecordDeclaration {
+	r := this.NewRecordDeclaration(fset, typeDecl, this.handleIdentAsName(typeDecl.Name), "interface")
+
+	var scope = this.GetScopeManager()
+
+	scope.EnterScope((*cpg.Node)(r))
+
+	if !interfaceType.Incomplete {
+		for _, method := range interfaceType.Methods.List {
+			t := this.handleType(fset, method.Type)
+
+			// Even though this list is called "Methods", it contains all kinds
+			// of things, so we need to proceed with caution. Only if the
+			// "method" actually has a name, we declare a new method
+			// declaration.
+			if len(method.Names) > 0 {
+				m := this.NewMethodDeclaration(fset, method, method.Names[0].Name)
+				m.SetType(t)
+				this.handleDeprecation(fset, (*cpg.Node)(m), method.Doc)
+				scope.AddDeclaration((*cpg.Declaration)(m))
+				scope.EnterScope((*cpg.Node)(m))
+
+				this.addFuncTypeData((*cpg.FunctionDeclaration)(m), fset, &ast.FuncDecl{
+					Doc:  method.Doc,
+					Name: method.Names[0],
+					Type: method.Type.(*ast.FuncType),
+				})
+
+				r.AddMethod(m)
+
+				// leave scope
+				err := scope.LeaveScope((*cpg.Node)(m))
+				if err != nil {
+					panic(err)
+				}
+			} else {
+				this.LogDebug("Adding %s as super class of interface %s", t.GetName(), (*cpg.Node)(r).GetName())
+				// Otherwise, it contains either types or interfaces. For now we
+				// hope that it only has interfaces. We consider embedded
+				// interfaces as sort of super types for this interface.
+				r.AddSuperClass(t)
+			}
+		}
+	}
+
+	scope.LeaveScope((*cpg.Node)(r))
+
+	return r
+}
+
+func (this *GoLanguageFrontend) handleBlockStmt(fset *token.FileSet, blockStmt *ast.BlockStmt) *cpg.CompoundStatement {
+	this.LogDebug("Handling block statement: %+v", *blockStmt)
+
+	c := this.NewCompoundStatement(fset, blockStmt)
+
+	// enter scope
+	this.GetScopeManager().EnterScope((*cpg.Node)(c))
+
+	for _, stmt := range blockStmt.List {
+		var s *cpg.Statement
+
+		s = this.handleStmt(fset, stmt)
+
+		if s != nil {
+			// add statement
+			c.AddStatement(s)
+		}
+	}
+
+	// leave scope
+	this.GetScopeManager().LeaveScope((*cpg.Node)(c))
+
+	return c
+}
+
+// handleFuncBody handles the body of a function. Other than an ordinary block,
+// it takes care of deferred calls that recover from a panic (see
+// handleFuncBodyStmts).
+func (this *GoLanguageFrontend) handleFuncBody(fset *token.FileSet, body *ast.BlockStmt) *cpg.CompoundStatement {
+	c := this.NewCompoundStatement(fset, body)
+
+	this.GetScopeManager().EnterScope((*cpg.Node)(c))
+	this.handleFuncBodyStmts(fset, c, body.List)
+	this.GetScopeManager().LeaveScope((*cpg.Node)(c))
+
+	return c
+}
+
+// handleFuncBodyStmts adds the statements of list to c. Once a deferred call
+// recovers from a panic, the remaining statements are wrapped in a try
+// statement, which executes the deferred call in its finally block. A panic
+// that occurs before the defer statement is not recovered, so the preceding
+// statements stay outside of it.
+func (this *GoLanguageFrontend) handleFuncBodyStmts(fset *token.FileSet, c *cpg.CompoundStatement, list []ast.Stmt) {
+	for i, stmt := range list {
+		if deferStmt, ok := stmt.(*ast.DeferStmt); ok && this.recovers(deferStmt) {
+			c.AddStatement((*cpg.Statement)(this.handleRecoveringDefer(fset, deferStmt, list[i+1:])))
+			return
+		}
+
+		if s := this.handleStmt(fset, stmt); s != nil {
+			c.AddStatement(s)
+		}
+	}
+}
+
+// handleRecoveringDefer models a deferred call, which recovers from a panic,
+// like a try/finally statement. The statements following the defer statement
+// make up the try block and the defer statement itself the finally block.
+func (this *GoLanguageFrontend) handleRecoveringDefer(fset *token.FileSet, deferStmt *ast.DeferStmt, rest []ast.Stmt) *cpg.TryStatement {
+	this.LogDebug("Handling recovering defer statement: %+v", *deferStmt)
+
+	var scope = this.GetScopeManager()
+
+	t := this.NewTryStatement(fset, deferStmt)
+	scope.EnterScope((*cpg.Node)(t))
+
+	finally := this.NewCompoundStatement(fset, nil)
+	scope.EnterScope((*cpg.Node)(finally))
+	finally.AddStatement((*cpg.Statement)(this.handleDeferStmt(fset, deferStmt)))
+	scope.LeaveScope((*cpg.Node)(finally))
+
+	block := this.NewCompoundStatement(fset, nil)
+	scope.EnterScope((*cpg.Node)(block))
+	this.handleFuncBodyStmts(fset, block, rest)
+	scope.LeaveScope((*cpg.Node)(block))
+
+	t.SetTryBlock(block)
+	t.SetFinallyBlock(finally)
+
+	scope.LeaveScope((*cpg.Node)(t))
+
+	return t
+}
+
+// recovers returns true, if deferStmt defers a function literal, which calls
+// recover. Calls of recover in nested function literals do not count, since
+// they do not stop a panic.
+func (this *GoLanguageFrontend) recovers(deferStmt *ast.DeferStmt) (found bool) {
+	funcLit, ok := deferStmt.Call.Fun.(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if this.builtinName(v) == "recover" {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return
+}
+
+func (this *GoLanguageFrontend) handleForStmt(fset *token.FileSet, forStmt *ast.ForStmt) *cpg.ForStatement {
+	this.LogDebug("Handling for statement: %+v", *forStmt)
+
+	f := this.NewForStatement(fset, forStmt)
+
+	var scope = this.GetScopeManager()
+
+	scope.EnterScope((*cpg.Node)(f))
+
+	if initStatement := this.handleStmt(fset, forStmt.Init); initStatement != nil {
+		f.SetInitializerStatement(initStatement)
+	}
+
+	if condition := this.handleExpr(fset, forStmt.Cond); condition != nil {
+		f.SetCondition(condition)
+	}
+
+	if iter := this.handleStmt(fset, forStmt.Post); iter != nil {
+		f.SetIterationStatement(iter)
+	}
+
+	if body := this.handleStmt(fset, forStmt.Body); body != nil {
+		f.SetStatement(body)
+	}
+
+	scope.LeaveScope((*cpg.Node)(f))
+
+	return f
+}
+
+func (this *GoLanguageFrontend) handleReturnStmt(fset *token.FileSet, returnStmt *ast.ReturnStmt) *cpg.ReturnStatement {
+	this.LogDebug("Handling return statement: %+v", *returnStmt)
+
+	r := this.NewReturnStatement(fset, returnStmt)
+
+	if returnStmt.Results != nil && len(returnStmt.Results) > 0 {
+		var e *cpg.Expression
+
+		if len(returnStmt.Results) > 1 {
+			tup := this.NewTupleExpression(fset, returnStmt)
+
+			for _, res := range returnStmt.Results {
+				subE := this.handleExpr(fset, res)
+
+				if subE != nil {
+					tup.AddMember(subE)
+				} else {
+					tup.AddMember(
+						this.NewProblemExpression(fset, res, "Could not parse return value"),
+					)
+				}
+			}
+
+			e = (*cpg.Expression)(tup)
+		} else {
+			e = this.handleExpr(fset, returnStmt.Results[0])
+		}
+
+		if e != nil {
+			r.SetReturnValue(e)
+		}
+
+		this.handleErrorPropagation(fset, r, returnStmt)
+	} else {
+		// TODO: connect result statement to result variables
+	}
+
+	return r
+}
+
+func (this *GoLanguageFrontend) handleIncDecStmt(fset *token.FileSet, incDecStmt *ast.IncDecStmt) *cpg.UnaryOperator {
+	this.LogDebug("Handling decimal increment statement: %+v", *incDecStmt)
+
+	var opCode string
+	if incDecStmt.Tok == token.INC {
+		opCode = "++"
+	}
+
+	if incDecStmt.Tok == token.DEC {
+		opCode = "--"
+	}
+
+	u := this.NewUnaryOperator(fset, incDecStmt, opCode, true, false)
+
+	if input := this.handleExpr(fset, incDecStmt.X); input != nil {
+		u.SetInput(input)
+	}
+
+	return u
+}
+
+// handleGoStmt models a go statement as a unary operator with the operator
+// code "go", which wraps the call that is spawned as a new goroutine. This
+// keeps the asynchronous invocation apart from an ordinary call.
+func (this *GoLanguageFrontend) handleGoStmt(fset *token.FileSet, goStmt *ast.GoStmt) *cpg.UnaryOperator {
+	this.LogDebug("Handling go statement: %+v", *goStmt)
+
+	u := this.NewUnaryOperator(fset, goStmt, "go", false, true)
+
+	if input := this.handleExpr(fset, goStmt.Call); input != nil {
+		u.SetInput(input)
+	}
+
+	return u
+}
+
+// handleDeferStmt models a defer statement as a unary operator with the
+// operator code "defer", which wraps the deferred call as its input.
+func (this *GoLanguageFrontend) handleDeferStmt(fset *token.FileSet, deferStmt *ast.DeferStmt) *cpg.UnaryOperator {
+	this.LogDebug("Handling defer statement: %+v", *deferStmt)
+
+	u := this.NewUnaryOperator(fset, deferStmt, "defer", false, true)
+
+	if input := this.handleExpr(fset, deferStmt.Call); input != nil {
+		u.SetInput(input)
+	}
+
+	return u
+}
+
+func (this *GoLanguageFrontend) handleLabeledStmt(fset *token.FileSet, labeledStmt *ast.LabeledStmt) *cpg.LabelStatement {
+	this.LogDebug("Handling labeled statement: %+v", *labeledStmt)
+
+	l := this.NewLabelStatement(fset, labeledStmt)
+	l.SetLabel(labeledStmt.Label.Name)
+
+	// register the label first, so that gotos within the sub statement can
+	// already be resolved
+	this.registerLabel(labeledStmt.Label, l)
+
+	sub := this.handleStmt(fset, labeledStmt.Stmt)
+	if sub == nil {
+		// the core expects a sub statement, e.g. for a label at the end of a block
+		sub = (*cpg.Statement)(this.NewEmptyStatement(fset, labeledStmt.Stmt))
+	}
+
+	l.SetSubStatement(sub)
+
+	return l
+}
+
+func (this *GoLanguageFrontend) handleBranchStmt(fset *token.FileSet, branchStmt *ast.BranchStmt) *cpg.Statement {
+	this.LogDebug("Handling branch statement: %+v", *branchStmt)
+
+	switch branchStmt.Tok {
+	case token.BREAK:
+		b := this.NewBreakStatement(fset, branchStmt)
+		if branchStmt.Label != nil {
+			b.SetLabel(branchStmt.Label.Name)
+		}
+
+		return (*cpg.Statement)(b)
+	case token.CONTINUE:
+		c := this.NewContinueStatement(fset, branchStmt)
+		if branchStmt.Label != nil {
+			c.SetLabel(branchStmt.Label.Name)
+		}
+
+		return (*cpg.Statement)(c)
+	case token.GOTO:
+		g := this.NewGotoStatement(fset, branchStmt)
+		g.SetLabelName(branchStmt.Label.Name)
+
+		this.resolveGoto(branchStmt.Label, g)
+
+		return (*cpg.Statement)(g)
+	default:
+		this.LogDebug("Not parsing branch statement %s yet", branchStmt.Tok)
+
+		return nil
+	}
+}
+
+// registerLabel remembers the label statement for the given label identifier
+// and sets it as target of all gotos that were waiting for it.
+func (this *GoLanguageFrontend) registerLabel(ident *ast.Ident, l *cpg.LabelStatement) {
+	obj := this.labelObject(ident)
+	if obj == nil {
+		return
+	}
+
+	if this.labels == nil {
+		this.labels = map[types.Object]*cpg.LabelStatement{}
+	}
+
+	this.labels[obj] = l
+
+	for _, g := range this.pendingGotos[obj] {
+		g.SetTargetLabel(l)
+	}
+
+	delete(this.pendingGotos, obj)
+}
+
+// resolveGoto sets the target label of the goto statement, if the label was
+// already handled. Otherwise, the goto waits until the label is registered.
+func (this *GoLanguageFrontend) resolveGoto(ident *ast.Ident, g *cpg.GotoStatement) {
+	obj := this.labelObject(ident)
+	if obj == nil {
+		return
+	}
+
+	if l, ok := this.labels[obj]; ok {
+		g.SetTargetLabel(l)
+		return
+	}
+
+	if this.pendingGotos == nil {
+		this.pendingGotos = map[types.Object][]*cpg.GotoStatement{}
+	}
+
+	this.pendingGotos[obj] = append(this.pendingGotos[obj], g)
+}
+
+// labelObject returns the label object of the type checker, which identifies a
+// label independently of its (function-scoped) name.
+func (this *GoLanguageFrontend) labelObject(ident *ast.Ident) types.Object {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil
+	}
+
+	return this.Package.TypesInfo.ObjectOf(ident)
+}
+
+func (this *GoLanguageFrontend) handleStmt(fset *token.FileSet, stmt ast.Stmt) (s *cpg.Statement) {
+	this.LogDebug("Handling statement (%T): %+v", stmt, stmt)
+
+	switch v := stmt.(type) {
+	case *ast.ExprStmt:
+		// in our cpg, each expression is also a statement,
+		// so we do not need an expression statement wrapper
+		s = (*cpg.Statement)(this.handleExpr(fset, v.X))
+	case *ast.AssignStmt:
+		s = (*cpg.Statement)(this.handleAssignStmt(fset, v))
+	case *ast.DeclStmt:
+		s = (*cpg.Statement)(this.handleDeclStmt(fset, v))
+	case *ast.IfStmt:
+		s = (*cpg.Statement)(this.handleIfStmt(fset, v))
+	case *ast.SwitchStmt:
+		s = (*cpg.Statement)(this.handleSwitchStmt(fset, v))
+	case *ast.CaseClause:
+		s = (*cpg.Statement)(this.handleCaseClause(fset, v))
+	case *ast.TypeSwitchStmt:
+		s = (*cpg.Statement)(this.handleTypeSwitchStmt(fset, v))
+	case *ast.SelectStmt:
+		s = (*cpg.Statement)(this.handleSelectStmt(fset, v))
+	case *ast.SendStmt:
+		s = (*cpg.Statement)(this.handleSendStmt(fset, v))
+	case *ast.BlockStmt:
+		s = (*cpg.Statement)(this.handleBlockStmt(fset, v))
+	case *ast.ForStmt:
+		s = (*cpg.Statement)(this.handleForStmt(fset, v))
+	case *ast.ReturnStmt:
+		s = (*cpg.Statement)(this.handleReturnStmt(fset, v))
+	case *ast.IncDecStmt:
+		s = (*cpg.Statement)(this.handleIncDecStmt(fset, v))
+	case *ast.RangeStmt:
+		s = (*cpg.Statement)(this.handleRangeStmnt(fset, v))
+	case *ast.GoStmt:
+		s = (*cpg.Statement)(this.handleGoStmt(fset, v))
+	case *ast.DeferStmt:
+		s = (*cpg.Statement)(this.handleDeferStmt(fset, v))
+	case *ast.LabeledStmt:
+		s = (*cpg.Statement)(this.handleLabeledStmt(fset, v))
+	case *ast.BranchStmt:
+		s = this.handleBranchStmt(fset, v)
+	case *ast.BadStmt:
+		s = (*cpg.Statement)(this.NewProblemExpression(fset, v, "could not parse statement"))
+	case nil:
+		s = nil
+	default:
+		this.LogWarn("Not parsing statement of type %T yet: %+v", v, v)
+		this.Metrics.unhandled(fset, stmt)
+		s = nil
+	}
+
+	if s != nil {
+		this.handleComments((*cpg.Node)(s), stmt)
+		this.Metrics.handled(stmt)
+	}
+
+	return
+}
+
+func (this *GoLanguageFrontend) handleRangeStmnt(fset *token.FileSet, expr *ast.RangeStmt) *cpg.ForEachStatement {
+	this.LogDebug("Handling range statement: %+v", *expr)
+
+	scope := this.GetScopeManager()
+	r := this.NewForEachStatement(fset, expr)
+	it := this.handleExpr(fset, expr.X)
+
+	scope.EnterScope((*cpg.Node)(r))
+
+	switch expr.Tok {
+	case token.DEFINE:
+		s := this.NewDeclarationStatement(fset, expr)
+		kt, vt := this.rangeTypes(expr)
+
+		if ident, ok := expr.Key.(*ast.Ident); ok && !isBlank(ident) {
+			s.AddDeclaration((*cpg.Declaration)(this.declareRangeVariable(fset, ident, kt)))
+		}
+
+		if ident, ok := expr.Value.(*ast.Ident); ok && !isBlank(ident) {
+			s.AddDeclaration((*cpg.Declaration)(this.declareRangeVariable(fset, ident, vt)))
+		}
+
+		r.SetVariable((*cpg.Statement)(s))
+	default:
+		// the iteration values are assigned to arbitrary expressions, unless they are discarded
+		// or there are none at all, e.g., in for range ch
+		var assigned bool
+
+		for _, x := range []ast.Expr{expr.Key, expr.Value} {
+			if x == nil || isBlank(x) {
+				continue
+			}
+
+			if e := this.handleExpr(fset, x); e != nil {
+				r.AddVariable((*cpg.Statement)(e))
+				assigned = true
+			}
+		}
+
+		if !assigned {
+			// Set a blank declaration statement to the variable
+			// to make the core lib happy.
+			s := this.NewDeclarationStatement(fset, expr)
+			r.SetVariable((*cpg.Statement)(s))
+		}
+	}
+
+	r.SetIterable((*cpg.Statement)(it))
+
+	then := this.handleBlockStmt(fset, expr.Body)
+	r.SetStatement((*cpg.Statement)(then))
+
+	scope.LeaveScope((*cpg.Node)(r))
+
+	return r
+}
+
+// declareRangeVariable declares the iteration variable ident of a range statement with the type t.
+func (this *GoLanguageFrontend) declareRangeVariable(fset *token.FileSet, ident *ast.Ident, t types.Type) *cpg.VariableDeclaration {
+	d := this.NewVariableDeclaration(fset, ident, ident.Name)
+	this.registerDeclaration(ident, (*cpg.Declaration)(d))
+
+	if t != nil {
+		d.SetType(this.handleTypingType(t))
+	}
+
+	this.GetScopeManager().AddDeclaration((*cpg.Declaration)(d))
+
+	return d
+}
+
+// rangeTypes returns the types of the key and value iteration variables of the range statement
+// stmt. We prefer the types recorded by the type checker, but it might not support newer range
+// forms, such as ranging over integers or iterator functions, so we fall back to deriving them
+// from the range expression ourselves.
+func (this *GoLanguageFrontend) rangeTypes(stmt *ast.RangeStmt) (key types.Type, value types.Type) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil, nil
+	}
+
+	if x := this.Package.TypesInfo.TypeOf(stmt.X); x != nil {
+		key, value = iterationTypes(x)
+	}
+
+	if t := validTypeOf(this.Package.TypesInfo, stmt.Key); t != nil {
+		key = t
+	}
+
+	if t := validTypeOf(this.Package.TypesInfo, stmt.Value); t != nil {
+		value = t
+	}
+
+	return
+}
+
+// iterationTypes returns the types of the values produced by ranging over a value of type x.
+func iterationTypes(x types.Type) (key types.Type, value types.Type) {
+	switch v := x.Underlying().(type) {
+	case *types.Basic:
+		if v.Info()&types.IsString != 0 {
+			return types.Typ[types.Int], types.Typ[types.Rune]
+		} else if v.Info()&types.IsInteger != 0 {
+			// ranging over an integer n yields 0 to n-1, which are of the type of n
+			return types.Default(x), nil
+		}
+	case *types.Array:
+		return types.Typ[types.Int], v.Elem()
+	case *types.Slice:
+		return types.Typ[types.Int], v.Elem()
+	case *types.Pointer:
+		if a, ok := v.Elem().Underlying().(*types.Array); ok {
+			return types.Typ[types.Int], a.Elem()
+		}
+	case *types.Map:
+		return v.Key(), v.Elem()
+	case *types.Chan:
+		return v.Elem(), nil
+	case *types.Signature:
+		// iterator functions have the form func(yield func(K, V) bool), where the parameters of
+		// yield are the values produced by the iteration
+		if v.Params().Len() != 1 {
+			break
+		}
+
+		if yield, ok := v.Params().At(0).Type().Underlying().(*types.Signature); ok {
+			if yield.Params().Len() > 0 {
+				key = yield.Params().At(0).Type()
+			}
+
+			if yield.Params().Len() > 1 {
+				value = yield.Params().At(1).Type()
+			}
+		}
+	}
+
+	return
+}
+
+// validTypeOf returns the type of expr recorded in info, unless it is missing or invalid.
+func validTypeOf(info *types.Info, expr ast.Expr) types.Type {
+	if expr == nil {
+		return nil
+	}
+
+	t := info.TypeOf(expr)
+	if t == nil || t == types.Typ[types.Invalid] {
+		return nil
+	}
+
+	return t
+}
+
+func (this *GoLanguageFrontend) handleExpr(fset *token.FileSet, expr ast.Expr) (e *cpg.Expression) {
+	this.LogDebug("Handling expression (%T): %+v", expr, expr)
+
+	switch v := expr.(type) {
+	case *ast.CallExpr:
+		e = (*cpg.Expression)(this.handleCallExpr(fset, v))
+	case *ast.IndexExpr:
+		if this.isInstantiation(v.X) {
+			e = this.handleInstantiationExpr(fset, v, v.X)
+		} else {
+			e = (*cpg.Expression)(this.handleIndexExpr(fset, v))
+		}
+	case *ast.IndexListExpr:
+		e = this.handleInstantiationExpr(fset, v, v.X)
+	case *ast.BinaryExpr:
+		e = (*cpg.Expression)(this.handleBinaryExpr(fset, v))
+	case *ast.UnaryExpr:
+		e = (*cpg.Expression)(this.handleUnaryExpr(fset, v))
+	case *ast.StarExpr:
+		e = (*cpg.Expression)(this.handleStarExpr(fset, v))
+	case *ast.SelectorExpr:
+		e = (*cpg.Expression)(this.handleSelectorExpr(fset, v))
+	case *ast.KeyValueExpr:
+		e = (*cpg.Expression)(this.handleKeyValueExpr(fset, v, false))
+	case *ast.BasicLit:
+		e = (*cpg.Expression)(this.handleBasicLit(fset, v))
+	case *ast.CompositeLit:
+		e = (*cpg.Expression)(this.handleCompositeLit(fset, v))
+	case *ast.Ident:
+		e = (*cpg.Expression)(this.handleIdent(fset, v))
+	case *ast.TypeAssertExpr:
+		e = (*cpg.Expression)(this.handleTypeAssertExpr(fset, v))
+	case *ast.ParenExpr:
+		e = this.handleExpr(fset, v.X)
+	case *ast.SliceExpr:
+		e = (*cpg.Expression)(this.handleSliceExpr(fset, v))
+	case *ast.FuncLit:
+		e = (*cpg.Expression)(this.handleFuncLit(fset, v))
+	case *ast.BadExpr:
+		e = this.NewProblemExpression(fset, v, "could not parse expression")
+	default:
+		this.LogWarn("Could not parse expression of type %T: %+v", v, v)
+		this.Metrics.unhandled(fset, expr)
+		// TODO: return an error instead?
+		e = nil
+	}
+
+	if e != nil {
+		this.handleComments((*cpg.Node)(e), expr)
+		this.setTypeOf(e, expr)
+		this.Metrics.handled(expr)
+	}
+
+	return
+}
+
+// setTypeOf sets the type of the expression e to the type of expr, as it was
+// recorded by the type checker. Untyped constants get their default type,
+// whereas untyped nil and calls without results have no type at all.
+func (this *GoLanguageFrontend) setTypeOf(e *cpg.Expression, expr ast.Expr) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return
+	}
+
+	t := validTypeOf(this.Package.TypesInfo, expr)
+	if t == nil {
+		return
+	}
+
+	if b, ok := t.(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+		if b.Kind() == types.UntypedNil {
+			return
+		}
+
+		t = types.Default(t)
+	}
+
+	if tuple, ok := t.(*types.Tuple); ok && tuple.Len() == 0 {
+		return
+	}
+
+	e.SetType(this.handleTypingType(t))
+}
+
+func (this *GoLanguageFrontend) addPossibleExternalSubtypes(destObj types.Type, assignType types.Type) {
+	if destObj == nil || assignType == nil || !types.IsInterface(destObj) {
+		return
+	}
+
+	cpgType := this.handleTypingType(destObj)
+
+	lastSep := strings.LastIndex(destObj.String(), ".")
+	if lastSep == -1 {
+		return
+	}
+
+	var recordName = cpgType.GetName()
+	scope := this.GetScopeManager().LookupScope(
+		destObj.String()[:lastSep],
+	)
+
+	if scope != nil && !(*jnigi.ObjectRef)(scope).IsNil() {
+		record, err := this.GetScopeManager().GetRecordForName(
+			scope,
+			recordName)
+
+		if err != nil {
+			panic(err)
+		}
+
+		assignCPGType := this.handleTypingType(assignType)
+		if record != nil && !record.IsNil() && assignCPGType != nil && !(*jnigi.ObjectRef)(assignCPGType).IsNil() {
+			if err := record.AddExternalSubType(assignCPGType); err != nil {
+				this.LogError("Error adding subtype: %v %v r: %+v", err, record, *assignCPGType)
+			}
+		} else {
+			this.LogError("Record is nil: %s %s", destObj.String()[:lastSep], recordName)
+		}
+	}
+}
+
+func (this *GoLanguageFrontend) handleAssignStmt(fset *token.FileSet, assignStmt *ast.AssignStmt) (expr *cpg.Statement) {
+	this.LogDebug("Handling assignment statement: %+v", assignStmt)
+
+	var rhs *cpg.Expression
+
+	if len(assignStmt.Rhs) > 1 {
+		tup := this.NewTupleExpression(fset, assignStmt)
+
+		for _, stmnt := range assignStmt.Rhs {
+			subE := this.handleExpr(fset, stmnt)
+			if subE != nil {
+				tup.AddMember(subE)
+			} else {
+				pe := this.NewProblemExpression(fset, stmnt, "Could not convert.")
+				tup.AddMember(pe)
+			}
+		}
+
+		rhs = (*cpg.Expression)(tup)
+	} else {
+		rhs = this.handleExpr(fset, assignStmt.Rhs[0])
+
+		if rhs == nil {
+			rhs = (*cpg.Expression)(this.NewProblemExpression(fset, assignStmt, "Could not convert."))
+		}
+	}
+
+	// the types of both values of a comma-ok expression, e.g. v, ok := m[k]
+	commaOk := this.commaOkTypes(fset, assignStmt)
+	if commaOk != nil {
+		rhs.SetType(commaOk[0])
+	}
+
+	if len(assignStmt.Lhs) > 1 {
+		c := this.NewCompoundStatement(fset, assignStmt)
+
+		if rhs != nil {
+			c.AddStatement((*cpg.Statement)(rhs))
+		}
+
+		for i, ls := range assignStmt.Lhs {
+			// the blank identifier discards its value
+			if isBlank(ls) {
+				continue
+			}
+
+			tupdest := this.destructure(fset, assignStmt, rhs, assignStmt.Rhs, i)
+
+			if commaOk != nil {
+				(*cpg.Expression)(tupdest).SetType(commaOk[i])
+			}
+
+			if s := this.assign(fset, assignStmt, ls, ls, (*cpg.Expression)(tupdest)); s != nil {
+				c.AddStatement(s)
+			}
+		}
+
+		expr = (*cpg.Statement)(c)
+	} else if isBlank(assignStmt.Lhs[0]) {
+		// only the right-hand side is evaluated
+		expr = (*cpg.Statement)(rhs)
+	} else if assignStmt.Tok == token.DEFINE {
+		expr = this.assign(fset, assignStmt, assignStmt.Lhs[0], assignStmt, rhs)
+	} else {
+		lhs := this.handleExpr(fset, assignStmt.Lhs[0])
+		// compound assignments, e.g. +=, keep their operator
+		b := this.NewBinaryOperator(fset, assignStmt, assignStmt.Tok.String())
+
+		if lhs != nil {
+			b.SetLHS(lhs)
+		}
+
+		if rhs != nil {
+			b.SetRHS(rhs)
+		}
+
+		expr = (*cpg.Statement)(b)
+	}
+
+	// declared variables have the type of their value anyway
+	if this.Package != nil && assignStmt.Tok != token.DEFINE {
+		lhsTypes := make([]types.Type, len(assignStmt.Lhs))
+
+		for i, stmnt := range assignStmt.Lhs {
+			if isBlank(stmnt) {
+				continue
+			}
+
+			lhsTypes[i] = this.Package.TypesInfo.TypeOf(stmnt)
+		}
+
+		for i, stmnt := range assignStmt.Rhs {
+			t := this.Package.TypesInfo.TypeOf(stmnt)
+
+			if len(lhsTypes) > i && lhsTypes[i] != nil {
+				this.addPossibleExternalSubtypes(lhsTypes[i], t)
+			}
+		}
+	}
+
+	return
+}
+
+// assign assigns value to the target ls of the assignment statement assignStmt. In a short variable
+// declaration, an identifier declares a new variable located at node, unless it is already declared
+// in the same scope. All other targets, such as selectors or index expressions, are simply
+// assigned to.
+func (this *GoLanguageFrontend) assign(fset *token.FileSet, assignStmt *ast.AssignStmt, ls ast.Expr, node ast.Node, value *cpg.Expression) *cpg.Statement {
+	if ident, ok := ls.(*ast.Ident); ok && assignStmt.Tok == token.DEFINE && !this.isRedeclaration(ident) {
+		stmt := this.NewDeclarationStatement(fset, assignStmt)
+
+		d := this.NewVariableDeclaration(fset, node, ident.Name)
+		this.registerDeclaration(ident, (*cpg.Declaration)(d))
+
+		if value != nil {
+			d.SetInitializer(value)
+		}
+
+		this.GetScopeManager().AddDeclaration((*cpg.Declaration)(d))
+		stmt.SetSingleDeclaration((*cpg.Declaration)(d))
+
+		return (*cpg.Statement)(stmt)
+	}
+
+	lhs := this.handleExpr(fset, ls)
+	if lhs == nil {
+		return nil
+	}
+
+	b := this.NewBinaryOperator(fset, assignStmt, "=")
+	b.SetLHS(lhs)
+
+	if value != nil {
+		b.SetRHS(value)
+	}
+
+	return (*cpg.Statement)(b)
+}
+
+// isRedeclaration checks, whether ident in a short variable declaration refers to a variable,
+// which is already declared in the same scope, e.g., err in a, err := f(). In this case, the
+// type checker records a use instead of a definition.
+func (this *GoLanguageFrontend) isRedeclaration(ident *ast.Ident) bool {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return false
+	}
+
+	_, ok := this.Package.TypesInfo.Uses[ident]
+
+	return ok
+}
+
+// isBlank checks, whether expr is the blank identifier, which discards the value assigned to it.
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// unparen returns expr without any enclosing parentheses.
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+
+		expr = paren.X
+	}
+}
+
+// commaOkTypes returns the types of both values of a comma-ok expression, i.e.,
+// a map index, a type assertion or a channel receive, which is assigned to two
+// values. The first one is the type of the element, the asserted or received
+// value, the second one is always bool. Otherwise, it returns nil.
+func (this *GoLanguageFrontend) commaOkTypes(fset *token.FileSet, assignStmt *ast.AssignStmt) []*cpg.Type {
+	if len(assignStmt.Lhs) != 2 || len(assignStmt.Rhs) != 1 {
+		return nil
+	}
+
+	lang, err := this.GetLanguage()
+	if err != nil {
+		panic(err)
+	}
+
+	expr := assignStmt.Rhs[0]
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+
+		expr = paren.X
+	}
+
+	var valueType *cpg.Type
+
+	switch v := expr.(type) {
+	case *ast.IndexExpr:
+		// only map index expressions can have two values
+	case *ast.TypeAssertExpr:
+		// type switches are handled elsewhere, so there is always a type
+		valueType = this.handleType(fset, v.Type)
+	case *ast.UnaryExpr:
+		if v.Op != token.ARROW {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	// the type checker records the types of both values as tuple
+	if this.Package != nil {
+		if tuple, ok := this.Package.TypesInfo.TypeOf(assignStmt.Rhs[0]).(*types.Tuple); ok && tuple.Len() == 2 {
+			valueType = this.handleTypingType(tuple.At(0).Type())
+		}
+	}
+
+	if valueType == nil {
+		valueType = (*cpg.Type)(cpg.UnknownType_getUnknown(lang))
+	}
+
+	return []*cpg.Type{valueType, this.parseType("bool")}
+}
+
+func (this *GoLanguageFrontend) handleDeclStmt(fset *token.FileSet, declStmt *ast.DeclStmt) (expr *cpg.Expression) {
+	this.LogDebug("Handling declaration statement: %+v", *declStmt)
+
+	d, _ := this.handleDecl(fset, declStmt.Decl)
+
+	// e.g., var _ = f() does not declare anything
+	if len(d) == 0 {
+		return nil
+	}
+
+	// lets create a variable declaration (wrapped with a declaration stmt) with this,
+	// because we define the variable here
+	stmt := this.NewDeclarationStatement(fset, declStmt)
+
+	for _, decl := range d {
+		stmt.AddDeclaration((*cpg.Declaration)(decl))
+		this.GetScopeManager().AddDeclaration(decl)
 	}
 
-	return
+	return (*cpg.Expression)(stmt)
 }
 
-func (this *GoLanguageFrontend) handleRangeStmnt(fset *token.FileSet, expr *ast.RangeStmt) *cpg.ForEachStatement {
-	this.LogDebug("Handling range statement: %+v", *expr)
+func (this *GoLanguageFrontend) handleIfStmt(fset *token.FileSet, ifStmt *ast.IfStmt) (expr *cpg.Expression) {
+	this.LogDebug("Handling if statement: %+v", *ifStmt)
 
-	scope := this.GetScopeManager()
-	r := this.NewForEachStatement(fset, expr)
-	it := this.handleExpr(fset, expr.X)
+	stmt := this.NewIfStatement(fset, ifStmt)
 
-	scope.EnterScope((*cpg.Node)(r))
+	var scope = this.GetScopeManager()
 
-	switch expr.Tok {
-	case token.ILLEGAL:
-		// Set a blank declaration statement to the variable
-		// to make the core lib happy.
-		s := this.NewDeclarationStatement(fset, expr)
-		r.SetVariable((*cpg.Statement)(s))
-	case token.ASSIGN:
-		if expr.Key != nil && expr.Value == nil {
-			expr := this.handleExpr(fset, expr.Key)
-			r.SetVariable((*cpg.Statement)(expr))
-		} else if expr.Key != nil && expr.Value != nil {
-			kexpr := this.handleExpr(fset, expr.Key)
-			vexpr := this.handleExpr(fset, expr.Value)
-			r.AddVariable((*cpg.Statement)(kexpr))
-			r.AddVariable((*cpg.Statement)(vexpr))
-		}
-	case token.DEFINE:
-		s := this.NewDeclarationStatement(fset, expr)
+	scope.EnterScope((*cpg.Node)(stmt))
 
-		if expr.Key != nil && expr.Value == nil {
-			d := this.NewVariableDeclaration(fset, expr.Key, expr.Key.(*ast.Ident).Name)
-			if this.Package != nil {
-				t := this.Package.TypesInfo.TypeOf(expr.Key)
-				if t != nil {
-					d.SetType(this.handleTypingType(t))
-				}
-			}
+	init := this.handleStmt(fset, ifStmt.Init)
+	if init != nil {
+		stmt.SetInitializerStatement(init)
+	}
 
-			s.SetSingleDeclaration((*cpg.Declaration)(d))
-			scope.AddDeclaration((*cpg.Declaration)(d))
-		} else if expr.Key != nil && expr.Value != nil {
-			k := this.NewVariableDeclaration(fset, expr.Key, expr.Key.(*ast.Ident).Name)
-			if this.Package != nil {
-				kt := this.Package.TypesInfo.TypeOf(expr.Key)
-				if kt != nil {
-					k.SetType(this.handleTypingType(kt))
-				}
-			}
+	cond := this.handleExpr(fset, ifStmt.Cond)
+	if cond != nil {
+		stmt.SetCondition(cond)
+	} else {
+		this.LogWarn("If statement should really have a condition. It is either missing or could not be parsed.")
+	}
 
-			v := this.NewVariableDeclaration(fset, expr.Value, expr.Value.(*ast.Ident).Name)
-			if this.Package != nil {
-				vt := this.Package.TypesInfo.TypeOf(expr.Value)
+	// the error is known to be non-nil within one of the branches
+	checked, branch, isErrorCheck := this.errorCheck(ifStmt.Cond)
+	if isErrorCheck {
+		(*cpg.Node)(stmt).AddAnnotations([]*cpg.Annotation{this.newErrorCheckAnnotation(fset, checked, branch)})
+	}
 
-				if vt != nil {
-					v.SetType(this.handleTypingType(vt))
-				}
-			}
+	if isErrorCheck && branch == "then" {
+		this.errorChecks = append(this.errorChecks, checked)
+	}
 
-			s.AddDeclaration((*cpg.Declaration)(k))
-			s.AddDeclaration((*cpg.Declaration)(v))
+	then := this.handleBlockStmt(fset, ifStmt.Body)
+	stmt.SetThenStatement((*cpg.Statement)(then))
 
-			scope.AddDeclaration((*cpg.Declaration)(k))
-			scope.AddDeclaration((*cpg.Declaration)(v))
+	if isErrorCheck {
+		if branch == "then" {
+			this.errorChecks = this.errorChecks[:len(this.errorChecks)-1]
+		} else {
+			this.errorChecks = append(this.errorChecks, checked)
 		}
-
-		r.SetVariable((*cpg.Statement)(s))
 	}
 
-	r.SetIterable((*cpg.Statement)(it))
+	els := this.handleStmt(fset, ifStmt.Else)
+	if els != nil {
+		stmt.SetElseStatement((*cpg.Statement)(els))
+	}
 
-	then := this.handleBlockStmt(fset, expr.Body)
-	r.SetStatement((*cpg.Statement)(then))
+	if isErrorCheck && branch == "else" {
+		this.errorChecks = this.errorChecks[:len(this.errorChecks)-1]
+	}
 
-	scope.LeaveScope((*cpg.Node)(r))
+	scope.LeaveScope((*cpg.Node)(stmt))
 
-	return r
+	return (*cpg.Expression)(stmt)
 }
 
-func (this *GoLanguageFrontend) handleExpr(fset *token.FileSet, expr ast.Expr) (e *cpg.Expression) {
-	this.LogDebug("Handling expression (%T): %+v", expr, expr)
+func (this *GoLanguageFrontend) handleSwitchStmt(fset *token.FileSet, switchStmt *ast.SwitchStmt) (expr *cpg.Expression) {
+	this.LogDebug("Handling switch statement: %+v", *switchStmt)
 
-	switch v := expr.(type) {
-	case *ast.CallExpr:
-		e = (*cpg.Expression)(this.handleCallExpr(fset, v))
-	case *ast.IndexExpr:
-		e = (*cpg.Expression)(this.handleIndexExpr(fset, v))
-	case *ast.BinaryExpr:
-		e = (*cpg.Expression)(this.handleBinaryExpr(fset, v))
-	case *ast.UnaryExpr:
-		e = (*cpg.Expression)(this.handleUnaryExpr(fset, v))
-	case *ast.StarExpr:
-		e = (*cpg.Expression)(this.handleStarExpr(fset, v))
-	case *ast.SelectorExpr:
-		e = (*cpg.Expression)(this.handleSelectorExpr(fset, v))
-	case *ast.KeyValueExpr:
-		e = (*cpg.Expression)(this.handleKeyValueExpr(fset, v, false))
-	case *ast.BasicLit:
-		e = (*cpg.Expression)(this.handleBasicLit(fset, v))
-	case *ast.CompositeLit:
-		e = (*cpg.Expression)(this.handleCompositeLit(fset, v))
-	case *ast.Ident:
-		e = (*cpg.Expression)(this.handleIdent(fset, v))
-	case *ast.TypeAssertExpr:
-		e = (*cpg.Expression)(this.handleTypeAssertExpr(fset, v))
-	case *ast.ParenExpr:
-		e = this.handleExpr(fset, v.X)
-	case *ast.SliceExpr:
-		e = this.handleExpr(fset, v.X)
-	case *ast.FuncLit:
-		e = (*cpg.Expression)(this.handleFuncLit(fset, v))
-	default:
-		this.LogWarn("Could not parse expression of type %T: %+v", v, v)
-		// TODO: return an error instead?
-		e = nil
+	s := this.NewSwitchStatement(fset, switchStmt)
+
+	var scope = this.GetScopeManager()
+
+	// the switch needs its own scope, so that (labeled) break statements can find it
+	scope.EnterScope((*cpg.Node)(s))
+
+	if switchStmt.Init != nil {
+		s.SetInitializerStatement(this.handleStmt(fset, switchStmt.Init))
 	}
 
-	if e != nil {
-		this.handleComments((*cpg.Node)(e), expr)
+	if switchStmt.Tag != nil {
+		s.SetCondition(this.handleExpr(fset, switchStmt.Tag))
+	} else {
+		// a switch without a tag switches over true, i.e., the first case whose expression
+		// evaluates to true is taken
+		lit := this.NewLiteral(fset, nil, cpg.NewBoolean(true), this.parseType("bool"))
+		(*cpg.Node)(lit).SetImplicit(true)
+
+		s.SetCondition((*cpg.Expression)(lit))
 	}
 
-	return
+	s.SetStatement((*cpg.Statement)(this.handleBlockStmt(fset, switchStmt.Body))) // should only contain case clauses
+
+	scope.LeaveScope((*cpg.Node)(s))
+
+	return (*cpg.Expression)(s)
 }
 
-func (this *GoLanguageFrontend) addPossibleExternalSubtypes(destObj types.Type, assignType types.Type) {
-	if destObj == nil || assignType == nil || !types.IsInterface(destObj) {
-		return
-	}
+func (this *GoLanguageFrontend) handleCaseClause(fset *token.FileSet, caseClause *ast.CaseClause) (expr *cpg.Expression) {
+	this.LogDebug("Handling case clause: %+v", *caseClause)
 
-	cpgType := this.handleTypingType(destObj)
+	var cases []*cpg.Statement
 
-	lastSep := strings.LastIndex(destObj.String(), ".")
-	if lastSep == -1 {
-		return
-	}
+	if caseClause.List == nil {
+		cases = append(cases, (*cpg.Statement)(this.NewDefaultStatement(fset, nil)))
+	} else {
+		// each expression of a clause, e.g., case 1, 2, 3:, is a case of its own. Like
+		// consecutive case labels in C, they all lead to the body of the clause.
+		for _, e := range caseClause.List {
+			c := this.NewCaseStatement(fset, caseClause)
+			c.SetCaseExpression(this.handleExpr(fset, e))
 
-	var recordName = cpgType.GetName()
-	scope := this.GetScopeManager().LookupScope(
-		destObj.String()[:lastSep],
-	)
+			cases = append(cases, (*cpg.Statement)(c))
+		}
+	}
 
-	if scope != nil && !(*jnigi.ObjectRef)(scope).IsNil() {
-		record, err := this.GetScopeManager().GetRecordForName(
-			scope,
-			recordName)
+	// need to find the current block / scope and add the statements to it
+	block := this.GetScopeManager().GetCurrentBlock()
 
-		if err != nil {
-			log.Fatal(err)
+	// add the case statements
+	for _, s := range cases {
+		if block != nil && !block.IsNil() {
+			block.AddStatement(s)
 		}
+	}
 
-		assignCPGType := this.handleTypingType(assignType)
-		if record != nil && !record.IsNil() && assignCPGType != nil && !(*jnigi.ObjectRef)(assignCPGType).IsNil() {
-			if err := record.AddExternalSubType(assignCPGType); err != nil {
-				this.LogError("Error adding subtype: %v %v r: %+v", err, record, *assignCPGType)
-			}
-		} else {
-			this.LogError("Record is nil: %s %s", destObj.String()[:lastSep], recordName)
+	for _, stmt := range caseClause.Body {
+		s := this.handleStmt(fset, stmt)
+
+		if s != nil && block != nil && !block.IsNil() {
+			// add statement
+			block.AddStatement(s)
 		}
 	}
+
+	// this is a little trick, to not add the case statement in handleStmt because we added it already.
+	// otherwise, the order is screwed up.
+	return nil
 }
 
-func (this *GoLanguageFrontend) handleAssignStmt(fset *token.FileSet, assignStmt *ast.AssignStmt) (expr *cpg.Statement) {
-	this.LogDebug("Handling assignment statement: %+v", assignStmt)
+// handleTypeSwitchStmt models a type switch as a switch statement, which uses
+// the expression whose type is switched on as selector. Since the symbolic
+// variable of a type switch (`switch v := x.(type)`) has a different type in
+// each clause, every clause body is placed into its own block, which declares
+// the variable with the type of that particular clause. The selector is only
+// evaluated once, so the variables refer to it by their data flow.
+func (this *GoLanguageFrontend) handleTypeSwitchStmt(fset *token.FileSet, typeSwitchStmt *ast.TypeSwitchStmt) *cpg.SwitchStatement {
+	this.LogDebug("Handling type switch statement: %+v", *typeSwitchStmt)
 
-	var rhs *cpg.Expression
+	s := this.NewSwitchStatement(fset, typeSwitchStmt)
 
-	if len(assignStmt.Rhs) > 1 {
-		tup := this.NewTupleExpression(fset, assignStmt)
+	var scope = this.GetScopeManager()
 
-		for _, stmnt := range assignStmt.Rhs {
-			subE := this.handleExpr(fset, stmnt)
-			if subE != nil {
-				tup.AddMember(subE)
-			} else {
-				pe := this.NewProblemExpression(fset, stmnt, "Could not convert.")
-				tup.AddMember(pe)
-			}
-		}
+	scope.EnterScope((*cpg.Node)(s))
 
-		rhs = (*cpg.Expression)(tup)
-	} else {
-		rhs = this.handleExpr(fset, assignStmt.Rhs[0])
+	if typeSwitchStmt.Init != nil {
+		s.SetInitializerStatement(this.handleStmt(fset, typeSwitchStmt.Init))
+	}
 
-		if rhs == nil {
-			rhs = (*cpg.Expression)(this.NewProblemExpression(fset, assignStmt, "Could not convert."))
+	var (
+		assert  *ast.TypeAssertExpr
+		binding *ast.Ident
+	)
+
+	switch v := typeSwitchStmt.Assign.(type) {
+	case *ast.AssignStmt:
+		binding, _ = v.Lhs[0].(*ast.Ident)
+		assert, _ = v.Rhs[0].(*ast.TypeAssertExpr)
+	case *ast.ExprStmt:
+		assert, _ = v.X.(*ast.TypeAssertExpr)
+	}
+
+	var selector *cpg.Expression
+
+	if assert != nil {
+		if selector = this.handleExpr(fset, assert.X); selector != nil {
+			s.SetCondition(selector)
 		}
 	}
 
-	if assignStmt.Tok == token.DEFINE {
-		// lets create a variable declaration (wrapped with a declaration stmt) with this, because we define the variable here
+	// a blank symbolic variable does not declare anything
+	if binding != nil && binding.Name == "_" {
+		binding = nil
+	}
 
-		if len(assignStmt.Lhs) > 1 {
-			stmt := this.NewCompoundStatement(fset, assignStmt)
-			if rhs != nil {
-				stmt.AddStatement((*cpg.Statement)(rhs))
-			}
+	body := this.NewCompoundStatement(fset, typeSwitchStmt.Body)
 
-			for i, ls := range assignStmt.Lhs {
-				name := ls.(*ast.Ident).Name
+	scope.EnterScope((*cpg.Node)(body))
 
-				decStmt := this.NewDeclarationStatement(fset, assignStmt)
+	for _, stmt := range typeSwitchStmt.Body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
 
-				d := this.NewVariableDeclaration(fset, ls, name)
-				decStmt.AddDeclaration((*cpg.Declaration)(d))
+		this.handleTypeCaseClause(fset, clause, assert, selector, binding, body)
+	}
 
-				tupdest := this.NewDestructureTupleExpression(fset, assignStmt)
+	scope.LeaveScope((*cpg.Node)(body))
 
-				tupdest.SetTupleIndex(i)
-				if rhs != nil {
-					tupdest.SetRefersTo(rhs)
-				}
+	s.SetStatement((*cpg.Statement)(body))
 
-				d.SetInitializer((*cpg.Expression)(tupdest))
+	scope.LeaveScope((*cpg.Node)(s))
 
-				this.GetScopeManager().AddDeclaration((*cpg.Declaration)(d))
-				stmt.AddStatement((*cpg.Statement)(decStmt))
-			}
+	return s
+}
 
-			expr = (*cpg.Statement)(stmt)
+// handleTypeCaseClause adds a case statement for each type of the clause to
+// the block of the type switch, followed by a block containing the clause body.
+// The symbolic variable binding of the clause is initialized by a cast of the
+// selector of the switch, which was already handled.
+func (this *GoLanguageFrontend) handleTypeCaseClause(
+	fset *token.FileSet,
+	clause *ast.CaseClause,
+	assert *ast.TypeAssertExpr,
+	selector *cpg.Expression,
+	binding *ast.Ident,
+	block *cpg.CompoundStatement,
+) {
+	this.LogDebug("Handling type case clause: %+v", *clause)
 
-			return
-		} else {
-			stmt := this.NewDeclarationStatement(fset, assignStmt)
+	var scope = this.GetScopeManager()
 
-			var name = assignStmt.Lhs[0].(*ast.Ident).Name
-			d := this.NewVariableDeclaration(fset, assignStmt, name)
+	if clause.List == nil {
+		block.AddStatement((*cpg.Statement)(this.NewDefaultStatement(fset, nil)))
+	}
 
-			if rhs != nil {
-				d.SetInitializer(rhs)
-			}
+	for _, typ := range clause.List {
+		c := this.NewCaseStatement(fset, clause)
+		c.SetCaseExpression(this.handleTypeCaseExpr(fset, typ))
 
-			this.GetScopeManager().AddDeclaration((*cpg.Declaration)(d))
-			stmt.SetSingleDeclaration((*cpg.Declaration)(d))
+		block.AddStatement((*cpg.Statement)(c))
+	}
 
-			expr = (*cpg.Statement)(stmt)
+	caseBlock := this.NewCompoundStatement(fset, clause)
 
-			return
-		}
-	} else {
-		if len(assignStmt.Lhs) > 1 {
-			c := this.NewCompoundStatement(fset, assignStmt)
+	scope.EnterScope((*cpg.Node)(caseBlock))
+
+	if binding != nil && assert != nil {
+		var t *cpg.Type
+		var obj types.Object
 
-			if rhs != nil {
-				c.AddStatement((*cpg.Statement)(rhs))
+		// the type checker declares an implicit object for the symbolic
+		// variable in each clause, which has the correct type
+		if this.Package != nil {
+			if obj = this.Package.TypesInfo.Implicits[clause]; obj != nil {
+				t = this.handleTypingType(obj.Type())
 			}
+		}
 
-			for i, ls := range assignStmt.Lhs {
-				lhs := this.handleExpr(fset, ls)
+		if t == nil && len(clause.List) == 1 && !isNilIdent(clause.List[0]) {
+			t = this.handleType(fset, clause.List[0])
+		}
 
-				if lhs == nil {
-					continue
-				}
+		cast := this.NewCastExpression(fset, assert)
 
-				tupdest := this.NewDestructureTupleExpression(fset, assignStmt)
+		// the selector is part of the switch statement, so the cast only
+		// takes its value instead of evaluating it again
+		if selector != nil {
+			(*cpg.Node)(cast).AddPrevDFG((*cpg.Node)(selector))
+		}
 
-				tupdest.SetTupleIndex(i)
-				if rhs != nil {
-					tupdest.SetRefersTo(rhs)
-				}
+		if t != nil {
+			cast.SetCastType(t)
+		}
 
-				b := this.NewBinaryOperator(fset, assignStmt, "=")
-				b.SetLHS(lhs)
-				b.SetRHS((*cpg.Expression)(tupdest))
+		d := this.NewVariableDeclaration(fset, binding, binding.Name)
+		this.registerObject(obj, (*cpg.Declaration)(d))
 
-				c.AddStatement((*cpg.Statement)(b))
-			}
+		if t != nil {
+			d.SetType(t)
+		}
 
-			expr = (*cpg.Statement)(c)
-		} else {
-			lhs := this.handleExpr(fset, assignStmt.Lhs[0])
-			b := this.NewBinaryOperator(fset, assignStmt, "=")
+		d.SetInitializer((*cpg.Expression)(cast))
 
-			if lhs != nil {
-				b.SetLHS(lhs)
-			}
+		stmt := this.NewDeclarationStatement(fset, binding)
+		stmt.SetSingleDeclaration((*cpg.Declaration)(d))
 
-			if rhs != nil {
-				b.SetRHS(rhs)
-			}
+		scope.AddDeclaration((*cpg.Declaration)(d))
+		caseBlock.AddStatement((*cpg.Statement)(stmt))
+	}
 
-			expr = (*cpg.Statement)(b)
+	for _, stmt := range clause.Body {
+		if s := this.handleStmt(fset, stmt); s != nil {
+			caseBlock.AddStatement(s)
 		}
 	}
 
-	if this.Package != nil {
-		lhsTypes := make([]types.Type, len(assignStmt.Lhs))
+	scope.LeaveScope((*cpg.Node)(caseBlock))
 
-		for i, stmnt := range assignStmt.Lhs {
-			sident, ok := stmnt.(*ast.Ident)
-			if !ok {
-				continue
-			}
+	block.AddStatement((*cpg.Statement)(caseBlock))
+}
 
-			t := this.Package.TypesInfo.TypeOf(sident)
-			lhsTypes[i] = t
-		}
+// handleTypeCaseExpr returns the case expression for a type in a type switch
+// clause, which is a type expression, unless the case is nil.
+func (this *GoLanguageFrontend) handleTypeCaseExpr(fset *token.FileSet, typ ast.Expr) *cpg.Expression {
+	if isNilIdent(typ) {
+		return this.handleExpr(fset, typ)
+	}
 
-		for i, stmnt := range assignStmt.Rhs {
-			t := this.Package.TypesInfo.TypeOf(stmnt)
+	t := this.handleType(fset, typ)
 
-			if len(lhsTypes) > i && lhsTypes[i] != nil {
-				this.addPossibleExternalSubtypes(lhsTypes[i], t)
-			}
-		}
-	}
+	return (*cpg.Expression)(this.NewTypeExpression(fset, typ, t.GetName(), t))
+}
 
-	return
+func isNilIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "nil"
 }
 
-func (this *GoLanguageFrontend) handleDeclStmt(fset *token.FileSet, declStmt *ast.DeclStmt) (expr *cpg.Expression) {
-	this.LogDebug("Handling declaration statement: %+v", *declStmt)
+// handleSelectStmt models a select statement as a switch statement without a
+// selector. Each comm clause of the select becomes a case within its body,
+// followed by a block containing the clause body. Like in a type switch, the
+// variables declared by the receive of a clause are only visible in its block.
+func (this *GoLanguageFrontend) handleSelectStmt(fset *token.FileSet, selectStmt *ast.SelectStmt) (expr *cpg.Expression) {
+	this.LogDebug("Handling select statement: %+v", *selectStmt)
 
-	// lets create a variable declaration (wrapped with a declaration stmt) with this,
-	// because we define the variable here
-	stmt := this.NewDeclarationStatement(fset, declStmt)
+	s := this.NewSwitchStatement(fset, selectStmt)
 
-	d, _ := this.handleDecl(fset, declStmt.Decl)
+	var scope = this.GetScopeManager()
 
-	for _, decl := range d {
-		stmt.AddDeclaration((*cpg.Declaration)(decl))
-		this.GetScopeManager().AddDeclaration(decl)
+	scope.EnterScope((*cpg.Node)(s))
+
+	body := this.NewCompoundStatement(fset, selectStmt.Body)
+
+	scope.EnterScope((*cpg.Node)(body))
+
+	for _, stmt := range selectStmt.Body.List {
+		clause, ok := stmt.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+
+		this.handleCommClause(fset, clause, body)
 	}
 
-	return (*cpg.Expression)(stmt)
+	scope.LeaveScope((*cpg.Node)(body))
+
+	s.SetStatement((*cpg.Statement)(body))
+
+	scope.LeaveScope((*cpg.Node)(s))
+
+	return (*cpg.Expression)(s)
 }
 
-func (this *GoLanguageFrontend) handleIfStmt(fset *token.FileSet, ifStmt *ast.IfStmt) (expr *cpg.Expression) {
-	this.LogDebug("Handling if statement: %+v", *ifStmt)
+// handleCommClause adds the case statement of a single clause of a select
+// statement to its block, followed by a block containing the clause body. The
+// send or receive operation of the clause is used as the case expression.
+func (this *GoLanguageFrontend) handleCommClause(fset *token.FileSet, commClause *ast.CommClause, block *cpg.CompoundStatement) {
+	this.LogDebug("Handling comm clause: %+v", *commClause)
 
-	stmt := this.NewIfStatement(fset, ifStmt)
+	var s *cpg.Statement
 
 	var scope = this.GetScopeManager()
 
-	scope.EnterScope((*cpg.Node)(stmt))
+	// the assignment of the result of a receive such as `case v, ok := <-ch`,
+	// which needs to be placed at the beginning of the clause body
+	var assign *ast.AssignStmt
+	var recv *cpg.Expression
 
-	init := this.handleStmt(fset, ifStmt.Init)
-	if init != nil {
-		stmt.SetInitializerStatement(init)
-	}
+	switch v := commClause.Comm.(type) {
+	case nil:
+		s = (*cpg.Statement)(this.NewDefaultStatement(fset, nil))
+	case *ast.SendStmt:
+		c := this.NewCaseStatement(fset, commClause)
+		c.SetCaseExpression((*cpg.Expression)(this.handleSendStmt(fset, v)))
 
-	cond := this.handleExpr(fset, ifStmt.Cond)
-	if cond != nil {
-		stmt.SetCondition(cond)
-	} else {
-		this.LogError("If statement should really have a condition. It is either missing or could not be parsed.")
-	}
+		s = (*cpg.Statement)(c)
+	case *ast.ExprStmt:
+		c := this.NewCaseStatement(fset, commClause)
+		c.SetCaseExpression(this.handleExpr(fset, v.X))
 
-	then := this.handleBlockStmt(fset, ifStmt.Body)
-	stmt.SetThenStatement((*cpg.Statement)(then))
+		s = (*cpg.Statement)(c)
+	case *ast.AssignStmt:
+		c := this.NewCaseStatement(fset, commClause)
 
-	els := this.handleStmt(fset, ifStmt.Else)
-	if els != nil {
-		stmt.SetElseStatement((*cpg.Statement)(els))
-	}
+		if v.Tok != token.DEFINE && len(v.Lhs) == 1 {
+			// a single assignment is an expression in our graph, so we can
+			// use it directly as case expression
+			c.SetCaseExpression((*cpg.Expression)(this.handleAssignStmt(fset, v)))
+		} else {
+			recv = this.handleExpr(fset, v.Rhs[0])
+			c.SetCaseExpression(recv)
 
-	scope.LeaveScope((*cpg.Node)(stmt))
+			assign = v
+		}
 
-	return (*cpg.Expression)(stmt)
-}
+		s = (*cpg.Statement)(c)
+	default:
+		this.LogWarn("Not parsing comm clause of type %T yet: %+v", v, v)
+		this.Metrics.unhandled(fset, commClause.Comm)
+		return
+	}
 
-func (this *GoLanguageFrontend) handleSwitchStmt(fset *token.FileSet, switchStmt *ast.SwitchStmt) (expr *cpg.Expression) {
-	this.LogDebug("Handling switch statement: %+v", *switchStmt)
+	block.AddStatement(s)
 
-	s := this.NewSwitchStatement(fset, switchStmt)
+	caseBlock := this.NewCompoundStatement(fset, commClause)
 
-	if switchStmt.Init != nil {
-		s.SetInitializerStatement(this.handleStmt(fset, switchStmt.Init))
+	scope.EnterScope((*cpg.Node)(caseBlock))
+
+	if assign != nil && recv != nil {
+		for _, a := range this.handleCommAssignments(fset, assign, recv) {
+			caseBlock.AddStatement(a)
+		}
 	}
 
-	if switchStmt.Tag != nil {
-		s.SetCondition(this.handleExpr(fset, switchStmt.Tag))
+	for _, stmt := range commClause.Body {
+		if s := this.handleStmt(fset, stmt); s != nil {
+			caseBlock.AddStatement(s)
+		}
 	}
 
-	s.SetStatement((*cpg.Statement)(this.handleBlockStmt(fset, switchStmt.Body))) // should only contain case clauses
+	scope.LeaveScope((*cpg.Node)(caseBlock))
 
-	return (*cpg.Expression)(s)
+	block.AddStatement((*cpg.Statement)(caseBlock))
 }
 
-func (this *GoLanguageFrontend) handleCaseClause(fset *token.FileSet, caseClause *ast.CaseClause) (expr *cpg.Expression) {
-	this.LogDebug("Handling case clause: %+v", *caseClause)
+// handleCommAssignments declares or assigns the variables on the lhs of a
+// receive within a comm clause. Since the receive itself is already used as
+// the case expression, it cannot be the initializer of a variable as well.
+// The value and the ok flag of `v, ok := <-ch` are therefore connected to it
+// using a DestructureTupleExpression, similar to handleAssignStmt, whereas the
+// variable of `v := <-ch` directly receives its data flow.
+func (this *GoLanguageFrontend) handleCommAssignments(fset *token.FileSet, assignStmt *ast.AssignStmt, recv *cpg.Expression) (stmts []*cpg.Statement) {
+	for i, ls := range assignStmt.Lhs {
+		var value *cpg.Expression
 
-	var s *cpg.Statement
+		if len(assignStmt.Lhs) > 1 {
+			value = (*cpg.Expression)(this.destructure(fset, assignStmt, recv, assignStmt.Rhs, i))
+		}
 
-	if caseClause.List == nil {
-		s = (*cpg.Statement)(this.NewDefaultStatement(fset, nil))
-	} else {
-		c := this.NewCaseStatement(fset, caseClause)
-		c.SetCaseExpression(this.handleExpr(fset, caseClause.List[0]))
+		if assignStmt.Tok == token.DEFINE {
+			ident, ok := ls.(*ast.Ident)
+			if !ok || ident.Name == "_" {
+				continue
+			}
 
-		s = (*cpg.Statement)(c)
-	}
+			decStmt := this.NewDeclarationStatement(fset, assignStmt)
 
-	// need to find the current block / scope and add the statements to it
-	block := this.GetScopeManager().GetCurrentBlock()
+			d := this.NewVariableDeclaration(fset, ls, ident.Name)
+			this.registerDeclaration(ident, (*cpg.Declaration)(d))
 
-	// add the case statement
-	if s != nil && block != nil && !block.IsNil() {
-		block.AddStatement((*cpg.Statement)(s))
-	}
+			if this.Package != nil {
+				if t := this.Package.TypesInfo.TypeOf(ident); t != nil {
+					d.SetType(this.handleTypingType(t))
+				}
+			}
 
-	for _, stmt := range caseClause.Body {
-		s = this.handleStmt(fset, stmt)
+			if value != nil {
+				d.SetInitializer(value)
+			} else {
+				(*cpg.Node)(d).AddPrevDFG((*cpg.Node)(recv))
+			}
 
-		if s != nil && block != nil && !block.IsNil() {
-			// add statement
-			block.AddStatement(s)
+			decStmt.AddDeclaration((*cpg.Declaration)(d))
+
+			this.GetScopeManager().AddDeclaration((*cpg.Declaration)(d))
+			stmts = append(stmts, (*cpg.Statement)(decStmt))
+		} else if value != nil {
+			lhs := this.handleExpr(fset, ls)
+			if lhs == nil {
+				continue
+			}
+
+			b := this.NewBinaryOperator(fset, assignStmt, "=")
+			b.SetLHS(lhs)
+			b.SetRHS(value)
+
+			stmts = append(stmts, (*cpg.Statement)(b))
 		}
 	}
 
-	// this is a little trick, to not add the case statement in handleStmt because we added it already.
-	// otherwise, the order is screwed up.
-	return nil
+	return
 }
 
-func (this *GoLanguageFrontend) handleCallExpr(fset *token.FileSet, callExpr *ast.CallExpr) *cpg.Expression {
-	var c *cpg.CallExpression
-	// parse the Fun field, to see which kind of expression it is
-	var reference = this.handleExpr(fset, callExpr.Fun)
+// handleSendStmt models sending a value to a channel as a binary operator
+// with the operator code "<-", the channel being the lhs.
+func (this *GoLanguageFrontend) handleSendStmt(fset *token.FileSet, sendStmt *ast.SendStmt) *cpg.BinaryOperator {
+	this.LogDebug("Handling send statement: %+v", *sendStmt)
 
-	if reference == nil {
-		// Check if this is a possible cast
-		callType := this.handleType(callExpr.Fun)
-		if callType == nil {
-			return nil
-		}
+	b := this.NewBinaryOperator(fset, sendStmt, "<-")
 
-		if len(callExpr.Args) != 1 {
-			return nil
-		}
+	if lhs := this.handleExpr(fset, sendStmt.Chan); lhs != nil {
+		b.SetLHS(lhs)
+	}
+
+	if rhs := this.handleExpr(fset, sendStmt.Value); rhs != nil {
+		b.SetRHS(rhs)
+	}
 
-		cast := this.NewCastExpression(fset, callExpr)
+	return b
+}
 
-		e := this.handleExpr(fset, callExpr.Args[0])
+func (this *GoLanguageFrontend) handleCallExpr(fset *token.FileSet, callExpr *ast.CallExpr) *cpg.Expression {
+	var c *cpg.CallExpression
 
-		if e != nil {
-			cast.SetExpression(e)
-		} else {
-			cast.SetExpression(this.NewProblemExpression(
-				fset,
-				callExpr.Args[0],
-				"Could not parse argument.",
-			))
-		}
+	// conversions, such as string(b) or MyType(x), look like calls, but there
+	// is no function that is called
+	if this.isConversion(callExpr) {
+		return this.handleConversion(fset, callExpr)
+	}
+
+	// a chained call of a route needs to be known, before the route is handled
+	this.collectRouteMethods(callExpr)
 
-		cast.SetCastType(callType)
+	// parse the Fun field, to see which kind of expression it is
+	var reference = this.handleExpr(fset, callExpr.Fun)
 
-		return (*cpg.Expression)(cast)
+	if reference == nil {
+		// Check if this is a possible cast
+		return this.handleConversion(fset, callExpr)
 	}
 
 	name := reference.GetName()
@@ -1331,19 +3524,40 @@ func (this *GoLanguageFrontend) handleCallExpr(fset *token.FileSet, callExpr *as
 
 	isMemberExpression, err := (*jnigi.ObjectRef)(reference).IsInstanceOf(env, cpg.MemberExpressionClass)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
-	if isMemberExpression {
-		baseName := (*cpg.Node)((*cpg.MemberExpression)(reference).GetBase()).GetName()
-		// this is not 100% accurate since it should be rather the type not the base name
-		// but FQNs are really broken in the CPG so this is ok for now
-		fqn := fmt.Sprintf("%s.%s", baseName, name)
+	fn := this.calledFunc(callExpr)
+
+	if this.isComputedCallee(callExpr.Fun) {
+		// the called function is only known at runtime, so instead of a name,
+		// the call refers to the expression, which computes the function
+		c = this.NewCallExpression(fset, callExpr)
+		c.SetCallee(reference)
+
+		// otherwise, the call would be named like the callee, e.g. f for f()()
+		c.SetName("")
+	} else if isMemberExpression {
+		// the method is resolved within the record of its receiver type. Without
+		// type information, we can only fall back to the name of the base.
+		var fqn string
+		if fn != nil {
+			fqn = methodFqn(fn)
+		}
+
+		if fqn == "" {
+			baseName := (*cpg.Node)((*cpg.MemberExpression)(reference).GetBase()).GetName()
+			fqn = fmt.Sprintf("%s.%s", baseName, name)
+		}
 
 		member := this.NewDeclaredReferenceExpression(fset, nil, name)
 		m := this.NewMemberCallExpression(fset, callExpr, name, fqn, (*cpg.MemberExpression)(reference).GetBase(), member.Node())
 
 		c = (*cpg.CallExpression)(m)
+
+		if this.Config != nil && this.Config.InterfaceDispatch {
+			this.handleInterfaceDispatch(fset, c, callExpr)
+		}
 	} else {
 		this.LogDebug("Handling regular call expression to %s", name)
 
@@ -1363,25 +3577,55 @@ func (this *GoLanguageFrontend) handleCallExpr(fset *token.FileSet, callExpr *as
 		}
 	}
 
+	if fn != nil {
+		this.qualifyName((*cpg.Node)(c), fn)
+		this.handleSyncCall(fset, c, fn)
+	}
+
+	// explicit type arguments of a generic function, e.g. Map[int, string](xs, f)
+	for _, typeArg := range this.typeArguments(callExpr.Fun) {
+		c.AddTemplateParameter((*cpg.Node)(this.handleType(fset, typeArg)))
+	}
+
 	var fnType types.Type
 
 	if this.Package != nil {
 		fnType = this.Package.TypesInfo.TypeOf(callExpr.Fun)
 	}
 
+	var args []*cpg.Expression
+
 	for i, arg := range callExpr.Args {
 		e := this.handleExpr(fset, arg)
 
+		// the last argument can be spread into a variadic parameter, i.e., f(args...)
+		spread := callExpr.Ellipsis.IsValid() && i == len(callExpr.Args)-1
+
 		if e != nil {
-			c.AddArgument(e)
+			if spread {
+				op := this.NewUnaryOperator(fset, arg, "...", true, false)
+				op.SetInput(e)
+
+				e = (*cpg.Expression)(op)
+			}
 		} else {
-			c.AddArgument(this.NewProblemExpression(fset, arg, "Could not parse argument."))
+			e = this.NewProblemExpression(fset, arg, "Could not parse argument.")
 		}
 
+		c.AddArgument(e)
+		args = append(args, e)
+
 		if this.Package != nil && fnType != nil {
 			t, ok := fnType.(*types.Signature)
 
-			if ok && i < t.Params().Len() {
+			if ok && t.Variadic() && i >= t.Params().Len()-1 && !spread {
+				// all remaining arguments are matched against the element type
+				// of the variadic parameter
+				if slice, ok := t.Params().At(t.Params().Len() - 1).Type().(*types.Slice); ok {
+					argType := this.Package.TypesInfo.TypeOf(arg)
+					this.addPossibleExternalSubtypes(slice.Elem(), argType)
+				}
+			} else if ok && i < t.Params().Len() {
 				paramDefType := t.Params().At(i).Type()
 				argType := this.Package.TypesInfo.TypeOf(arg)
 				this.addPossibleExternalSubtypes(paramDefType, argType)
@@ -1389,24 +3633,264 @@ func (this *GoLanguageFrontend) handleCallExpr(fset *token.FileSet, callExpr *as
 		}
 	}
 
+	// calls to predeclared functions are resolved to their declarations in the
+	// global scope, but we need to take care of their data flow
+	builtin := this.builtinName(callExpr)
+	if builtin != "" {
+		this.handleBuiltinDataFlow(builtin, c, args)
+	}
+
+	this.handleNonReturningCall(fset, c, builtin, fn)
+
+	if fn != nil {
+		this.handleEndpoint(fset, c, callExpr, fn, args)
+		this.handleORMCall(fset, c, callExpr, fn)
+		this.handleSQLCall(fset, c, callExpr, fn)
+		this.handleEgressCall(fset, c, callExpr, fn)
+		this.handleConfigCall(fset, c, callExpr, fn)
+		this.handleLoggingCall(fset, c, callExpr, fn)
+	}
+
+	// reference.disconnectFromGraph()
+
+	return (*cpg.Expression)(c)
+}
+
+// isComputedCallee returns, whether the function called by a call is computed
+// by the expression fun, e.g. a function literal, another call or an element of
+// a slice, rather than denoted by a (qualified) name.
+func (this *GoLanguageFrontend) isComputedCallee(fun ast.Expr) bool {
+	switch v := fun.(type) {
+	case *ast.ParenExpr:
+		return this.isComputedCallee(v.X)
+	case *ast.FuncLit, *ast.CallExpr:
+		return true
+	case *ast.IndexExpr:
+		// unless it instantiates a generic function, e.g. Map[int](xs)
+		return len(this.typeArguments(v)) == 0
+	}
+
+	return false
+}
+
+// isConversion returns, whether the call is actually a conversion to the type
+// denoted by its function expression. It prefers the type checker and falls
+// back to the shape of the function expression.
+func (this *GoLanguageFrontend) isConversion(callExpr *ast.CallExpr) bool {
 	if this.Package != nil {
-		t := this.Package.TypesInfo.TypeOf(callExpr)
+		if tv, ok := this.Package.TypesInfo.Types[callExpr.Fun]; ok {
+			return tv.IsType()
+		}
+	}
 
-		if t != nil {
-			((*cpg.Expression)(c)).SetType(this.handleTypingType(t))
+	switch v := callExpr.Fun.(type) {
+	case *ast.ParenExpr:
+		return this.isConversion(&ast.CallExpr{Fun: v.X})
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		return true
+	case *ast.Ident:
+		return this.isBuiltinType(v.Name)
+	}
+
+	return false
+}
+
+// calledFunc returns the function or method called by callExpr according to
+// the type checker, or nil, if it calls something else, e.g. a function value.
+func (this *GoLanguageFrontend) calledFunc(callExpr *ast.CallExpr) *types.Func {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil
+	}
+
+	var fun = callExpr.Fun
+	for {
+		switch v := fun.(type) {
+		case *ast.ParenExpr:
+			fun = v.X
+			continue
+		case *ast.IndexExpr:
+			fun = v.X
+			continue
+		case *ast.IndexListExpr:
+			fun = v.X
+			continue
 		}
+
+		break
 	}
 
-	// reference.disconnectFromGraph()
+	var ident *ast.Ident
+	switch v := fun.(type) {
+	case *ast.Ident:
+		ident = v
+	case *ast.SelectorExpr:
+		ident = v.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := this.Package.TypesInfo.Uses[ident].(*types.Func)
+
+	return fn
+}
+
+// handleInterfaceDispatch annotates a call of a method through an interface
+// with the implementations of the method in the loaded packages, which are the
+// possible targets of the call. The ResolveGoInterfaceDispatch pass adds them to
+// the invoked functions, once the calls are resolved.
+func (this *GoLanguageFrontend) handleInterfaceDispatch(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return
+	}
+
+	selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	selection, ok := this.Package.TypesInfo.Selections[selectorExpr]
+	if !ok || selection.Kind() != types.MethodVal {
+		return
+	}
+
+	iface, ok := selection.Recv().Underlying().(*types.Interface)
+	if !ok {
+		return
+	}
+
+	var members []*cpg.AnnotationMember
+	for _, f := range this.Symbols.implementations(iface, selection.Obj().Name()) {
+		ref := this.NewDeclaredReferenceExpression(fset, nil, (*cpg.Node)(f).GetName())
+		ref.SetRefersTo((*cpg.Declaration)(f))
+
+		members = append(members, this.NewAnnotationMember(fset, nil, "target", (*cpg.Expression)(ref)))
+	}
+
+	if len(members) == 0 {
+		return
+	}
+
+	a := this.NewAnnotation(fset, nil, "dispatch")
+	a.SetMembers(members)
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{a})
+}
+
+// handleConversion handles a conversion, e.g. string(b), as cast expression,
+// so that the data still flows from its argument
+func (this *GoLanguageFrontend) handleConversion(fset *token.FileSet, callExpr *ast.CallExpr) *cpg.Expression {
+	callType := this.handleType(fset, callExpr.Fun)
+	if callType == nil {
+		return nil
+	}
+
+	if len(callExpr.Args) != 1 {
+		return nil
+	}
+
+	cast := this.NewCastExpression(fset, callExpr)
+
+	e := this.handleExpr(fset, callExpr.Args[0])
+
+	if e != nil {
+		cast.SetExpression(e)
+	} else {
+		cast.SetExpression(this.NewProblemExpression(
+			fset,
+			callExpr.Args[0],
+			"Could not parse argument.",
+		))
+	}
+
+	cast.SetCastType(callType)
+
+	return (*cpg.Expression)(cast)
+}
+
+func (this *GoLanguageFrontend) handleIndexExpr(fset *token.FileSet, indexExpr *ast.IndexExpr) *cpg.Expression {
+	a := this.NewArraySubscriptionExpression(fset, indexExpr)
+
+	a.SetArrayExpression(this.handleExpr(fset, indexExpr.X))
+	a.SetSubscriptExpression(this.handleExpr(fset, indexExpr.Index))
+
+	return (*cpg.Expression)(a)
+}
+
+// handleInstantiationExpr handles the instantiation of a generic function with
+// explicit type arguments, such as Map[int, string]. Since the CPG has no
+// dedicated node for this, the instantiation is represented by the expression
+// of the generic function itself; its type arguments are attached to the call
+// expression instead (see typeArguments).
+func (this *GoLanguageFrontend) handleInstantiationExpr(fset *token.FileSet, expr ast.Expr, x ast.Expr) *cpg.Expression {
+	// the type of the instantiated function, which differs from the generic
+	// one, is set by handleExpr for expr
+	return this.handleExpr(fset, x)
+}
+
+// isInstantiation checks, whether the indexed expression x is a generic
+// function, which is instantiated by the index expression, rather than an
+// array, slice or map that is accessed.
+func (this *GoLanguageFrontend) isInstantiation(x ast.Expr) bool {
+	if this.Package == nil {
+		return false
+	}
+
+	var ident *ast.Ident
+
+	switch v := x.(type) {
+	case *ast.Ident:
+		ident = v
+	case *ast.SelectorExpr:
+		ident = v.Sel
+	default:
+		return false
+	}
+
+	_, ok := this.Package.TypesInfo.Instances[ident]
+
+	return ok
+}
+
+// typeArguments returns the explicit type arguments of fun, if it is an
+// instantiation of a generic function.
+func (this *GoLanguageFrontend) typeArguments(fun ast.Expr) []ast.Expr {
+	switch v := fun.(type) {
+	case *ast.IndexExpr:
+		if this.isInstantiation(v.X) {
+			return []ast.Expr{v.Index}
+		}
+	case *ast.IndexListExpr:
+		return v.Indices
+	}
+
+	return nil
+}
+
+func (this *GoLanguageFrontend) handleSliceExpr(fset *token.FileSet, sliceExpr *ast.SliceExpr) *cpg.Expression {
+	a := this.NewArraySubscriptionExpression(fset, sliceExpr)
+
+	var low, high *cpg.Expression
+
+	// all indices of a slice expression are optional
+	if sliceExpr.Low != nil {
+		low = this.handleExpr(fset, sliceExpr.Low)
+	}
+
+	if sliceExpr.High != nil {
+		high = this.handleExpr(fset, sliceExpr.High)
+	}
 
-	return (*cpg.Expression)(c)
-}
+	r := this.NewArrayRangeExpression(fset, sliceExpr, low, high)
 
-func (this *GoLanguageFrontend) handleIndexExpr(fset *token.FileSet, indexExpr *ast.IndexExpr) *cpg.Expression {
-	a := this.NewArraySubscriptionExpression(fset, indexExpr)
+	// the capacity of a three-index slice, i.e., s[low:high:max]
+	if sliceExpr.Slice3 && sliceExpr.Max != nil {
+		r.SetMax(this.handleExpr(fset, sliceExpr.Max))
+	}
 
-	a.SetArrayExpression(this.handleExpr(fset, indexExpr.X))
-	a.SetSubscriptExpression(this.handleExpr(fset, indexExpr.Index))
+	// the subscript needs to be set before the array expression, so that the
+	// resulting type is the sliced type and not its element type
+	a.SetSubscriptExpression((*cpg.Expression)(r))
+	a.SetArrayExpression(this.handleExpr(fset, sliceExpr.X))
 
 	return (*cpg.Expression)(a)
 }
@@ -1415,13 +3899,13 @@ func (this *GoLanguageFrontend) handleNewExpr(fset *token.FileSet, callExpr *ast
 	n := this.NewNewExpression(fset, callExpr)
 
 	// first argument is type
-	t := this.handleType(callExpr.Args[0])
+	t := this.handleType(fset, callExpr.Args[0])
 
 	// new is a pointer, so need to reference the type with a pointer
 	var pointer = jnigi.NewObjectRef(cpg.PointerOriginClass)
 	err := env.GetStaticField(cpg.PointerOriginClass, "POINTER", pointer)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	(*cpg.HasType)(n).SetType(t.Reference(pointer))
@@ -1443,10 +3927,11 @@ func (this *GoLanguageFrontend) handleMakeExpr(fset *token.FileSet, callExpr *as
 	}
 
 	// first argument is always the type, handle it
-	t := this.handleType(callExpr.Args[0])
+	t := this.handleType(fset, callExpr.Args[0])
 
 	// actually make() can make more than just arrays, i.e. channels and maps
-	if _, isArray := callExpr.Args[0].(*ast.ArrayType); isArray {
+	switch this.makeKind(callExpr.Args[0]) {
+	case "slice":
 		r := this.NewArrayCreationExpression(fset, callExpr)
 
 		// second argument is a dimension (if this is an array), usually a literal
@@ -1457,10 +3942,32 @@ func (this *GoLanguageFrontend) handleMakeExpr(fset *token.FileSet, callExpr *as
 		}
 
 		n = (*cpg.Expression)(r)
-	} else {
-		// create at least a generic construct expression for the given map or channel type
-		// and provide the remaining arguments
+	case "map":
+		c := this.NewConstructExpression(fset, callExpr)
+
+		// the optional argument is only a hint for the initial size of the map
+		if len(callExpr.Args) > 1 {
+			c.AddNamedArgument(this.handleExpr(fset, callExpr.Args[1]), "size")
+		}
+
+		n = (*cpg.Expression)(c)
+	case "chan":
+		c := this.NewConstructExpression(fset, callExpr)
+
+		// without a capacity (or a capacity of zero), the channel is unbuffered
+		buffered := false
+		if len(callExpr.Args) > 1 {
+			c.AddNamedArgument(this.handleExpr(fset, callExpr.Args[1]), "capacity")
+
+			buffered = !this.isConstantZero(callExpr.Args[1])
+		}
+
+		(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{this.handleChannelBuffering(fset, buffered)})
 
+		n = (*cpg.Expression)(c)
+	default:
+		// create at least a generic construct expression for the given type
+		// and provide the remaining arguments
 		c := this.NewConstructExpression(fset, callExpr)
 
 		// pass the remaining arguments
@@ -1479,6 +3986,77 @@ func (this *GoLanguageFrontend) handleMakeExpr(fset *token.FileSet, callExpr *as
 	return n
 }
 
+// makeKind returns the kind of the type passed to make, i.e., "slice", "map" or
+// "chan". It prefers the type checker, so that named types are supported as
+// well, and falls back to the shape of the type expression.
+func (this *GoLanguageFrontend) makeKind(typ ast.Expr) string {
+	if this.Package != nil {
+		if t := this.Package.TypesInfo.TypeOf(typ); t != nil {
+			switch t.Underlying().(type) {
+			case *types.Slice:
+				return "slice"
+			case *types.Map:
+				return "map"
+			case *types.Chan:
+				return "chan"
+			}
+		}
+	}
+
+	switch typ.(type) {
+	case *ast.ArrayType:
+		return "slice"
+	case *ast.MapType:
+		return "map"
+	case *ast.ChanType:
+		return "chan"
+	}
+
+	return ""
+}
+
+// arrayLength evaluates the length of the array type typ. It prefers the type
+// checker, which also knows the length of [...]T and of lengths given by
+// constant expressions, and falls back to integer literals.
+func (this *GoLanguageFrontend) arrayLength(typ *ast.ArrayType) (l int64, ok bool) {
+	if this.Package != nil && this.Package.TypesInfo != nil {
+		if a, isArray := this.Package.TypesInfo.TypeOf(typ).(*types.Array); isArray {
+			return a.Len(), true
+		}
+	}
+
+	if lit, isLit := typ.Len.(*ast.BasicLit); isLit && lit.Kind == token.INT {
+		return constant.Int64Val(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
+	}
+
+	return 0, false
+}
+
+// isConstantZero returns, whether the expression is a constant with the value
+// zero, according to the type checker
+func (this *GoLanguageFrontend) isConstantZero(expr ast.Expr) bool {
+	if this.Package == nil {
+		return false
+	}
+
+	tv, ok := this.Package.TypesInfo.Types[expr]
+
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Int && constant.Sign(tv.Value) == 0
+}
+
+// handleChannelBuffering creates an annotation, which specifies whether a
+// channel created by make is buffered
+func (this *GoLanguageFrontend) handleChannelBuffering(fset *token.FileSet, buffered bool) *cpg.Annotation {
+	lit := this.NewLiteral(fset, nil, cpg.NewBoolean(buffered), this.parseType("bool"))
+
+	a := this.NewAnnotation(fset, nil, "channel")
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, nil, "buffered", (*cpg.Expression)(lit)),
+	})
+
+	return a
+}
+
 func (this *GoLanguageFrontend) handleBinaryExpr(fset *token.FileSet, binaryExpr *ast.BinaryExpr) *cpg.BinaryOperator {
 	b := this.NewBinaryOperator(fset, binaryExpr, binaryExpr.Op.String())
 
@@ -1518,33 +4096,138 @@ func (this *GoLanguageFrontend) handleStarExpr(fset *token.FileSet, unaryExpr *a
 	return u
 }
 
+// resolveSelector uses the type checker to decide, whether the selector
+// expression accesses a member (field or method) or a package-level symbol of
+// an imported package. In the latter case, the path of the package is returned
+// as well. If no type information is available, ok is false.
+func (this *GoLanguageFrontend) resolveSelector(selectorExpr *ast.SelectorExpr) (isMemberExpression bool, importPath string, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return false, "", false
+	}
+
+	info := this.Package.TypesInfo
+
+	// fields and methods, including promoted ones, are recorded as selections
+	if _, ok := info.Selections[selectorExpr]; ok {
+		return true, "", true
+	}
+
+	// a qualified identifier, which refers to a package-level symbol
+	if ident, ok := selectorExpr.X.(*ast.Ident); ok {
+		if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+			return false, pkgName.Imported().Path(), true
+		}
+	}
+
+	// the selected object itself tells us, where it is declared
+	if obj := info.Uses[selectorExpr.Sel]; obj != nil {
+		if obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+			return false, obj.Pkg().Path(), true
+		}
+
+		return true, "", true
+	}
+
+	return false, "", false
+}
+
+// resolveSelectorByImports decides, whether the selector expression accesses a
+// member or a package-level symbol, by comparing its base with the names of the
+// imports of the current file. This is only used as a fallback, if no type
+// information is available, since it does not consider shadowed identifiers.
+func (this *GoLanguageFrontend) resolveSelectorByImports(selectorExpr *ast.SelectorExpr, base *cpg.Expression) (isMemberExpression bool, importPath string) {
+	_, xident := selectorExpr.X.(*ast.Ident)
+
+	isMemberExpression = true
+
+	if imp := this.importByName(base.GetName()); imp != nil && xident {
+		// found a package name, so this is NOT a member expression
+		isMemberExpression = false
+		var err error
+		importPath, err = strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			this.LogError("Error resolving import: %s", imp.Path.Value)
+			importPath = this.getImportName(imp)
+		}
+	}
+
+	return
+}
+
+// handlePromotion makes the access of a promoted field or method explicit. A
+// selector, such as s.Promoted, might select a member of an embedded field of
+// s, or even of a field embedded therein. The type checker records the path to
+// the member as the indices of the embedded fields. For each of them, an
+// implicit member expression is wrapped around base, so that the result is
+// equivalent to s.Embedded.Promoted.
+func (this *GoLanguageFrontend) handlePromotion(fset *token.FileSet, selectorExpr *ast.SelectorExpr, base *cpg.Expression) *cpg.Expression {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return base
+	}
+
+	selection, ok := this.Package.TypesInfo.Selections[selectorExpr]
+	if !ok {
+		return base
+	}
+
+	var index = selection.Index()
+	var t = selection.Recv()
+
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok || i >= st.NumFields() {
+			this.LogDebug("Could not follow embedded field %d of %s", i, t)
+			return base
+		}
+
+		field := st.Field(i)
+
+		m := this.NewMemberExpression(fset, nil, field.Name(), base)
+		(*cpg.Node)(m).SetImplicit(true)
+		(*cpg.Expression)(m).SetType(this.handleTypingType(field.Type()))
+
+		base = (*cpg.Expression)(m)
+		t = field.Type()
+	}
+
+	return base
+}
+
 func (this *GoLanguageFrontend) handleSelectorExpr(fset *token.FileSet, selectorExpr *ast.SelectorExpr) *cpg.DeclaredReferenceExpression {
 	this.LogDebug("Handle selector: %+v", selectorExpr)
+
+	// a method expression, e.g. T.Method, has no base value
+	if selection, ok := this.selectionOf(selectorExpr); ok && selection.Kind() == types.MethodExpr {
+		return this.handleMethodExpr(fset, selectorExpr, selection.Obj().(*types.Func))
+	}
+
 	base := this.handleExpr(fset, selectorExpr.X)
-	_, xident := selectorExpr.X.(*ast.Ident)
 
 	// check, if this just a regular reference to a variable with a package scope and not a member expression
-	var isMemberExpression bool = true
-	importPath := ""
-
-	for _, imp := range this.File.Imports {
-		n := this.getImportName(imp)
-		if base.GetName() == n && xident {
-			// found a package name, so this is NOT a member expression
-			isMemberExpression = false
-			var err error
-			importPath, err = strconv.Unquote(imp.Path.Value)
-			if err != nil {
-				this.LogError("Error resolving import: %s", imp.Path.Value)
-				importPath = this.getImportName(imp)
-			}
-		}
+	isMemberExpression, importPath, ok := this.resolveSelector(selectorExpr)
+	if !ok {
+		isMemberExpression, importPath = this.resolveSelectorByImports(selectorExpr, base)
 	}
 
 	var decl *cpg.DeclaredReferenceExpression
 	if isMemberExpression {
+		base = this.handlePromotion(fset, selectorExpr, base)
+
 		m := this.NewMemberExpression(fset, selectorExpr, selectorExpr.Sel.Name, base)
 		decl = (*cpg.DeclaredReferenceExpression)(m)
+
+		// a method value, e.g. f := s.Method, binds its base as the receiver of
+		// the method. Since the resolver only looks for fields, we already
+		// resolve it to the method here.
+		if selection, ok := this.selectionOf(selectorExpr); ok && selection.Kind() == types.MethodVal {
+			if f, ok := this.Symbols.method(selection.Obj().(*types.Func).Origin()); ok {
+				decl.SetRefersTo((*cpg.Declaration)(f))
+			}
+		}
 	} else {
 		// we need to set the name to a FQN-style, including the package scope. the call resolver will then resolve this
 		fqn := fmt.Sprintf("%s.%s", importPath, selectorExpr.Sel.Name)
@@ -1552,13 +4235,6 @@ func (this *GoLanguageFrontend) handleSelectorExpr(fset *token.FileSet, selector
 		decl = this.NewDeclaredReferenceExpression(fset, selectorExpr, fqn)
 	}
 
-	if this.Package != nil {
-		t := this.Package.TypesInfo.TypeOf(selectorExpr)
-		if t != nil {
-			((*cpg.Expression)(decl)).SetType(this.handleTypingType(t))
-		}
-	}
-
 	// For now we just let the VariableUsageResolver handle this. Therefore,
 	// we can not differentiate between field access to a receiver, an object
 	// or a const field within a package at this point.
@@ -1579,17 +4255,48 @@ func (this *GoLanguageFrontend) handleSelectorExpr(fset *token.FileSet, selector
 	return decl
 }
 
+// handleMethodExpr handles a method expression, e.g. T.Method or (*T).Method,
+// which yields a function that takes the receiver as its first argument. Since
+// no receiver is bound, it is a reference to the method rather than a member
+// expression. It is named like the method is resolved within its record.
+func (this *GoLanguageFrontend) handleMethodExpr(fset *token.FileSet, selectorExpr *ast.SelectorExpr, fn *types.Func) *cpg.DeclaredReferenceExpression {
+	name := methodFqn(fn)
+	if name == "" {
+		name = selectorExpr.Sel.Name
+	}
+
+	ref := this.NewDeclaredReferenceExpression(fset, selectorExpr, name)
+
+	if f, ok := this.Symbols.method(fn.Origin()); ok {
+		ref.SetRefersTo((*cpg.Declaration)(f))
+	}
+
+	return ref
+}
+
+// selectionOf returns the selection of a field or method by selectorExpr
+// according to the type checker, if it is available.
+func (this *GoLanguageFrontend) selectionOf(selectorExpr *ast.SelectorExpr) (selection *types.Selection, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil, false
+	}
+
+	selection, ok = this.Package.TypesInfo.Selections[selectorExpr]
+
+	return
+}
+
 func (this *GoLanguageFrontend) handleKeyValueExpr(
 	fset *token.FileSet,
 	expr *ast.KeyValueExpr,
-	compositeLit bool,
+	fieldKey bool,
 ) *cpg.KeyValueExpression {
 	this.LogDebug("Handling key value expression %+v", *expr)
 
 	k := this.NewKeyValueExpression(fset, expr)
 
 	var keyExpr *cpg.Expression
-	if v, ok := expr.Key.(*ast.Ident); compositeLit && ok {
+	if v, ok := expr.Key.(*ast.Ident); fieldKey && ok {
 		keyExpr = (*cpg.Expression)(this.handleBasicLit(fset, &ast.BasicLit{
 			ValuePos: expr.Key.Pos(),
 			Kind:     token.STRING,
@@ -1617,29 +4324,35 @@ func (this *GoLanguageFrontend) handleBasicLit(fset *token.FileSet, lit *ast.Bas
 	var value cpg.Castable
 	var t *cpg.Type
 
-	lang, err := this.GetLanguage()
-	if err != nil {
-		panic(err)
-	}
-
 	switch lit.Kind {
 	case token.STRING:
-		// strip the "
-		value = cpg.NewString(lit.Value[1 : len(lit.Value)-1])
-		t = cpg.TypeParser_createFrom("string", lang)
+		// interpreted and raw string literals are unquoted to their actual
+		// value, i.e., escape sequences are resolved
+		str, err := strconv.Unquote(lit.Value)
+		if err != nil && len(lit.Value) >= 2 {
+			// strip the quotes, at least
+			str = lit.Value[1 : len(lit.Value)-1]
+		}
+
+		value = cpg.NewString(str)
+		t = this.parseType("string")
 	case token.INT:
-		i, _ := strconv.ParseInt(lit.Value, 10, 64)
-		value = cpg.NewInteger(int(i))
-		t = cpg.TypeParser_createFrom("int", lang)
+		// go/constant understands all forms of integer literals, i.e., hexadecimal, octal and
+		// binary ones as well as digit separators
+		var typ string
+		value, typ = integerValue(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
+		t = this.parseType(typ)
 	case token.FLOAT:
 		// default seems to be float64
-		f, _ := strconv.ParseFloat(lit.Value, 64)
+		f, _ := constant.Float64Val(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
 		value = cpg.NewDouble(f)
-		t = cpg.TypeParser_createFrom("float64", lang)
+		t = this.parseType("float64")
 	case token.IMAG:
+		value = complexValue(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
+		t = this.parseType("complex128")
 	case token.CHAR:
 		value = cpg.NewString(lit.Value)
-		t = cpg.TypeParser_createFrom("char", lang)
+		t = this.parseType("char")
 		break
 	}
 
@@ -1657,7 +4370,14 @@ func (this *GoLanguageFrontend) handleCompositeLit(fset *token.FileSet, lit *ast
 	c := this.NewConstructExpression(fset, lit)
 
 	// parse the type field, to see which kind of expression it is
-	var typ = this.handleType(lit.Type)
+	var typ *cpg.Type
+	if t := this.literalType(lit); lit.Type == nil && t != nil {
+		// the type of nested literals can be elided, e.g., in []Point{{1, 2}}, so we
+		// take the element type of the enclosing literal from the type checker
+		typ = this.handleTypingType(t)
+	} else {
+		typ = this.handleType(fset, lit.Type)
+	}
 
 	if typ != nil {
 		(*cpg.Node)(c).SetName(typ.GetName())
@@ -1677,12 +4397,14 @@ func (this *GoLanguageFrontend) handleCompositeLit(fset *token.FileSet, lit *ast
 	// from its initialization.
 	c.AddPrevDFG((*cpg.Node)(l))
 
+	fieldKeys := this.hasFieldKeys(lit)
+
 	for _, elem := range lit.Elts {
 		var expr *cpg.Expression
 
 		switch v := elem.(type) {
 		case *ast.KeyValueExpr:
-			expr = (*cpg.Expression)(this.handleKeyValueExpr(fset, v, true))
+			expr = (*cpg.Expression)(this.handleKeyValueExpr(fset, v, fieldKeys))
 		default:
 			expr = this.handleExpr(fset, elem)
 		}
@@ -1695,6 +4417,34 @@ func (this *GoLanguageFrontend) handleCompositeLit(fset *token.FileSet, lit *ast
 	return c
 }
 
+// literalType returns the type of the composite literal lit, as determined by the type checker.
+func (this *GoLanguageFrontend) literalType(lit *ast.CompositeLit) types.Type {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil
+	}
+
+	return this.Package.TypesInfo.TypeOf(lit)
+}
+
+// hasFieldKeys checks, whether the keys of the composite literal lit are names of struct fields.
+// Keys of array, slice and map literals are expressions on their own instead, e.g., the index 2
+// in [5]int{2: 7}. Without type information, we assume the former.
+func (this *GoLanguageFrontend) hasFieldKeys(lit *ast.CompositeLit) bool {
+	t := this.literalType(lit)
+	if t == nil {
+		return true
+	}
+
+	// elided types of nested literals, such as in []*Point{{X: 1}}, can be pointers
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	_, ok := t.Underlying().(*types.Struct)
+
+	return ok
+}
+
 func (this *GoLanguageFrontend) handleIdent(fset *token.FileSet, ident *ast.Ident) *cpg.Expression {
 	lang, err := this.GetLanguage()
 	if err != nil {
@@ -1710,22 +4460,44 @@ func (this *GoLanguageFrontend) handleIdent(fset *token.FileSet, ident *ast.Iden
 		return (*cpg.Expression)(lit)
 	}
 
-	ref := this.NewDeclaredReferenceExpression(fset, ident, ident.Name)
-
-	tu := this.CurrentTU
+	// iota is a predeclared constant, whose value depends on its position in a
+	// const block, so we directly use the value evaluated by the type checker
+	if ident.Name == "iota" && this.Package != nil {
+		if c, ok := this.Package.TypesInfo.Uses[ident].(*types.Const); ok && c.Parent() == types.Universe {
+			if tv, ok := this.Package.TypesInfo.Types[ident]; ok && tv.Value != nil {
+				lit := this.handleConstantValue(fset, ident, tv.Value, tv.Type)
 
-	// check, if this refers to a package import
-	i := tu.GetIncludeByName(ident.Name)
+				(*cpg.Node)(lit).SetName(ident.Name)
 
-	// then set the refersTo, because our regular CPG passes will not resolve them
-	if i != nil && !(*jnigi.ObjectRef)(i).IsNil() {
-		ref.SetRefersTo((*cpg.Declaration)(i))
+				return (*cpg.Expression)(lit)
+			}
+		}
 	}
 
+	ref := this.NewDeclaredReferenceExpression(fset, ident, this.dotImportedName(ident))
+
+	// if we know the declaration of the object this identifier refers to, we
+	// can directly set it, since the type checker correctly handles Go's
+	// scoping rules. otherwise, the VariableUsageResolver will take care of it.
 	if this.Package != nil {
-		t := this.Package.TypesInfo.TypeOf(ident)
-		if t != nil {
-			((*cpg.Expression)(ref)).SetType(this.handleTypingType(t))
+		var obj = this.Package.TypesInfo.Uses[ident]
+
+		if d, ok := this.declarations[obj]; ok {
+			ref.SetRefersTo(d)
+		} else if d, ok := this.Symbols.object(obj); ok {
+			ref.SetRefersTo(d)
+		}
+	}
+
+	tu := this.CurrentTU
+
+	// check, if this refers to a package import
+	if this.importByName(ident.Name) != nil {
+		i := tu.GetIncludeByName(ident.Name)
+
+		// then set the refersTo, because our regular CPG passes will not resolve them
+		if i != nil && !(*jnigi.ObjectRef)(i).IsNil() {
+			ref.SetRefersTo((*cpg.Declaration)(i))
 		}
 	}
 
@@ -1739,7 +4511,7 @@ func (this *GoLanguageFrontend) handleTypeAssertExpr(fset *token.FileSet, assert
 	expr := this.handleExpr(fset, assert.X)
 
 	// Parse the type
-	typ := this.handleType(assert.Type)
+	typ := this.handleType(fset, assert.Type)
 
 	cast.SetExpression(expr)
 	cast.SetCastType(typ)
@@ -1747,14 +4519,26 @@ func (this *GoLanguageFrontend) handleTypeAssertExpr(fset *token.FileSet, assert
 	return cast
 }
 
-func (this *GoLanguageFrontend) processIdentResolveImports(ident *ast.Ident) string {
-	for _, imp := range this.File.Imports {
-		if ident.Name == this.getImportName(imp) {
-			res, err := strconv.Unquote(imp.Path.Value)
-			if err != nil {
-				break
-			}
+// dotImportedName returns the name of the reference to ident. Identifiers,
+// which refer to a package-level symbol of a dot-imported package, are
+// qualified with the import path of the package, the same way a selector
+// would be, so that they can be resolved.
+func (this *GoLanguageFrontend) dotImportedName(ident *ast.Ident) string {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return ident.Name
+	}
+
+	obj := this.Package.TypesInfo.Uses[ident]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg() == this.Package.Types || obj.Parent() != obj.Pkg().Scope() {
+		return ident.Name
+	}
 
+	return fmt.Sprintf("%s.%s", obj.Pkg().Path(), ident.Name)
+}
+
+func (this *GoLanguageFrontend) processIdentResolveImports(ident *ast.Ident) string {
+	if imp := this.importByName(ident.Name); imp != nil {
+		if res, err := strconv.Unquote(imp.Path.Value); err == nil {
 			return res
 		}
 	}
@@ -1771,15 +4555,25 @@ func (this *GoLanguageFrontend) handleTypingType(ttype types.Type) *cpg.Type {
 	this.LogDebug("Handling type %s %T", ttype.String(), ttype)
 
 	switch v := ttype.(type) {
-	case *types.Named, *types.Interface, *types.Struct:
-		return cpg.TypeParser_createFrom(v.String(), lang)
+	case *types.Named:
+		t := this.parseType(v.String())
+
+		// link the type to its record, if it is declared in one of our
+		// packages. The record also knows the underlying type.
+		if r, ok := this.records[v.Origin().Obj()]; ok && r != nil && t.IsObjectType() {
+			(*cpg.ObjectType)(t).SetRecordDeclaration(r)
+		}
+
+		return t
+	case *types.Interface, *types.Struct:
+		return this.parseType(v.String())
 	case *types.Pointer:
 		t := this.handleTypingType(v.Elem())
 
 		var i = jnigi.NewObjectRef(cpg.PointerOriginClass)
 		err = env.GetStaticField(cpg.PointerOriginClass, "POINTER", i)
 		if err != nil {
-			log.Fatal(err)
+			panic(err)
 		}
 
 		return t.Reference(i)
@@ -1796,12 +4590,18 @@ func (this *GoLanguageFrontend) handleTypingType(ttype types.Type) *cpg.Type {
 		var i = jnigi.NewObjectRef(cpg.PointerOriginClass)
 		err = env.GetStaticField(cpg.PointerOriginClass, "ARRAY", i)
 		if err != nil {
-			log.Fatal(err)
+			panic(err)
 		}
 
 		this.LogDebug("Array of %s", t.GetName())
 
-		return t.Reference(i)
+		t = t.Reference(i)
+
+		if a, ok := v.(*types.Array); ok {
+			t.SetArrayLength(a.Len())
+		}
+
+		return t
 	case *types.Map:
 		// we cannot properly represent Golangs built-in map types, yet so we have
 		// to make a shortcut here and represent it as a Java-like map<K, V> type.
@@ -1823,20 +4623,20 @@ func (this *GoLanguageFrontend) handleTypingType(ttype types.Type) *cpg.Type {
 		return t
 	case *types.Basic:
 		if this.isBuiltinType(v.String()) {
-			return cpg.TypeParser_createFrom(v.String(), lang)
+			return this.parseType(v.String())
 		}
 	case *types.Signature:
-		var parametersTypesList, returnTypesList, name *jnigi.ObjectRef
 		var parameterTypes = []*cpg.Type{}
 		var returnTypes = []*cpg.Type{}
 
-		for i := 0; i < v.Params().Len(); i++ {
-			parameterTypes = append(parameterTypes, this.handleTypingType(v.Params().At(i).Type()))
+		// the signature of a method includes its receiver, just like the
+		// type of a method expression T.M
+		if v.Recv() != nil {
+			parameterTypes = append(parameterTypes, this.handleTypingType(v.Recv().Type()))
 		}
 
-		parametersTypesList, err = cpg.ListOf(parameterTypes)
-		if err != nil {
-			log.Fatal(err)
+		for i := 0; i < v.Params().Len(); i++ {
+			parameterTypes = append(parameterTypes, this.handleTypingType(v.Params().At(i).Type()))
 		}
 
 		if v.Results() != nil {
@@ -1845,37 +4645,31 @@ func (this *GoLanguageFrontend) handleTypingType(ttype types.Type) *cpg.Type {
 			}
 		}
 
-		returnTypesList, err = cpg.ListOf(returnTypes)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		name, err = cpg.StringOf(funcTypeName(parameterTypes, returnTypes))
-		if err != nil {
-			log.Fatal(err)
+		return newFunctionType(parameterTypes, returnTypes, lang)
+	case *types.Tuple:
+		// the multiple values of a call to a function with multiple results
+		// are a tuple of their types, which can be destructured
+		if v.Len() == 0 {
+			break
 		}
 
-		var t, err = env.NewObject(cpg.FunctionTypeClass,
-			name,
-			parametersTypesList.Cast("java/util/List"),
-			returnTypesList.Cast("java/util/List"),
-			lang)
-		if err != nil {
-			log.Fatal(err)
+		var elementTypes []*cpg.Type
+		for i := 0; i < v.Len(); i++ {
+			elementTypes = append(elementTypes, this.handleTypingType(v.At(i).Type()))
 		}
 
-		return (*cpg.Type)(t)
+		return cpg.NewTupleType(elementTypes)
 	default:
-		this.LogInfo("Can't parse %T", v)
+		this.LogWarn("Can't parse %T", v)
 	}
 
 	return (*cpg.Type)(cpg.UnknownType_getUnknown(lang))
 }
 
-func (this *GoLanguageFrontend) handleType(typeExpr ast.Expr) *cpg.Type {
+func (this *GoLanguageFrontend) handleType(fset *token.FileSet, typeExpr ast.Expr) *cpg.Type {
 	var err error
 
-	this.LogDebug("Parsing type %T: %+v %s", typeExpr, typeExpr)
+	this.LogDebug("Parsing type %T: %+v", typeExpr, typeExpr)
 
 	lang, err := this.GetLanguage()
 	if err != nil {
@@ -1888,40 +4682,60 @@ func (this *GoLanguageFrontend) handleType(typeExpr ast.Expr) *cpg.Type {
 		fqn := this.handleIdentAsName(v)
 
 		this.LogDebug("FQN type: %s", fqn)
-		return cpg.TypeParser_createFrom(fqn, lang)
+		return this.parseType(fqn)
 	case *ast.SelectorExpr:
 		// small shortcut
 		fqn := fmt.Sprintf("%s.%s", this.processIdentResolveImports(v.X.(*ast.Ident)), v.Sel.Name)
 		this.LogDebug("FQN type: %s", fqn)
-		return cpg.TypeParser_createFrom(fqn, lang)
+		return this.parseType(fqn)
 	case *ast.StarExpr:
-		t := this.handleType(v.X)
+		t := this.handleType(fset, v.X)
 
 		var i = jnigi.NewObjectRef(cpg.PointerOriginClass)
 		err = env.GetStaticField(cpg.PointerOriginClass, "POINTER", i)
 		if err != nil {
-			log.Fatal(err)
+			panic(err)
 		}
 
 		return t.Reference(i)
 	case *ast.ArrayType:
-		t := this.handleType(v.Elt)
+		t := this.handleType(fset, v.Elt)
 
 		var i = jnigi.NewObjectRef(cpg.PointerOriginClass)
 		err = env.GetStaticField(cpg.PointerOriginClass, "ARRAY", i)
 		if err != nil {
-			log.Fatal(err)
+			panic(err)
 		}
 
 		this.LogDebug("Array of %s", t.GetName())
 
+		t = t.Reference(i)
+
+		// a slice has no length, an array always has a constant one
+		if v.Len != nil {
+			if l, ok := this.arrayLength(v); ok {
+				t.SetArrayLength(l)
+			}
+		}
+
+		return t
+	case *ast.Ellipsis:
+		// a variadic parameter ...T is a slice of T within the function
+		t := this.handleType(fset, v.Elt)
+
+		var i = jnigi.NewObjectRef(cpg.PointerOriginClass)
+		err = env.GetStaticField(cpg.PointerOriginClass, "ARRAY", i)
+		if err != nil {
+			panic(err)
+		}
+
 		return t.Reference(i)
 	case *ast.MapType:
 		// we cannot properly represent Golangs built-in map types, yet so we have
 		// to make a shortcut here and represent it as a Java-like map<K, V> type.
 		t := cpg.TypeParser_createFrom("map", lang)
-		keyType := this.handleType(v.Key)
-		valueType := this.handleType(v.Value)
+		keyType := this.handleType(fset, v.Key)
+		valueType := this.handleType(fset, v.Value)
 
 		// TODO(oxisto): Find a better way to represent casts
 		(*cpg.ObjectType)(t).AddGeneric(keyType)
@@ -1931,56 +4745,118 @@ func (this *GoLanguageFrontend) handleType(typeExpr ast.Expr) *cpg.Type {
 	case *ast.ChanType:
 		// handle them similar to maps
 		t := cpg.TypeParser_createFrom("chan", lang)
-		chanType := this.handleType(v.Value)
+		chanType := this.handleType(fset, v.Value)
 
 		(*cpg.ObjectType)(t).AddGeneric(chanType)
 
 		return t
+	case *ast.ParenExpr:
+		return this.handleType(fset, v.X)
 	case *ast.InterfaceType:
-		return cpg.TypeParser_createFrom("interface", lang)
+		return this.parseType("interface")
+	case *ast.StructType:
+		return this.handleAnonymousStructType(fset, v)
 	case *ast.FuncType:
-		var parametersTypesList, returnTypesList, name *jnigi.ObjectRef
-		var parameterTypes = []*cpg.Type{}
-		var returnTypes = []*cpg.Type{}
+		return this.handleFuncType(fset, nil, v)
+	}
+
+	return (*cpg.Type)(cpg.UnknownType_getUnknown(lang))
+}
+
+// handleFuncType creates the function type of funcType. For a method, recv
+// contains its receiver, which becomes the first parameter of the type, just
+// like in the type of the method expression T.M. This way, methods with the same
+// signature on different types do not share their type.
+func (this *GoLanguageFrontend) handleFuncType(fset *token.FileSet, recv *ast.FieldList, funcType *ast.FuncType) *cpg.Type {
+	lang, err := this.GetLanguage()
+	if err != nil {
+		panic(err)
+	}
+
+	var parameterTypes = []*cpg.Type{}
+	var returnTypes = []*cpg.Type{}
 
-		for _, param := range v.Params.List {
-			parameterTypes = append(parameterTypes, this.handleType(param.Type))
+	if recv != nil {
+		for _, field := range recv.List {
+			parameterTypes = append(parameterTypes, this.handleType(fset, withoutTypeArguments(field.Type)))
 		}
+	}
 
-		parametersTypesList, err = cpg.ListOf(parameterTypes)
-		if err != nil {
-			log.Fatal(err)
+	// a group of parameters, e.g. a, b int, has one type for each name
+	for _, param := range funcType.Params.List {
+		t := this.handleType(fset, param.Type)
+
+		for i := 0; i < groupSize(param); i++ {
+			parameterTypes = append(parameterTypes, t)
 		}
+	}
 
-		if v.Results != nil {
-			for _, ret := range v.Results.List {
-				returnTypes = append(returnTypes, this.handleType(ret.Type))
+	if funcType.Results != nil {
+		for _, ret := range funcType.Results.List {
+			t := this.handleType(fset, ret.Type)
+
+			for i := 0; i < groupSize(ret); i++ {
+				returnTypes = append(returnTypes, t)
 			}
 		}
+	}
 
-		returnTypesList, err = cpg.ListOf(returnTypes)
-		if err != nil {
-			log.Fatal(err)
-		}
+	return newFunctionType(parameterTypes, returnTypes, lang)
+}
 
-		name, err = cpg.StringOf(funcTypeName(parameterTypes, returnTypes))
-		if err != nil {
-			log.Fatal(err)
-		}
+// newFunctionType creates a function type out of its parameter and return types.
+func newFunctionType(parameterTypes []*cpg.Type, returnTypes []*cpg.Type, lang *cpg.Language) *cpg.Type {
+	parametersTypesList, err := cpg.ListOf(parameterTypes)
+	if err != nil {
+		panic(err)
+	}
 
-		var t, err = env.NewObject(cpg.FunctionTypeClass,
-			name,
-			parametersTypesList.Cast("java/util/List"),
-			returnTypesList.Cast("java/util/List"),
-			lang)
-		if err != nil {
-			log.Fatal(err)
-		}
+	returnTypesList, err := cpg.ListOf(returnTypes)
+	if err != nil {
+		panic(err)
+	}
+
+	name, err := cpg.StringOf(funcTypeName(parameterTypes, returnTypes))
+	if err != nil {
+		panic(err)
+	}
 
-		return (*cpg.Type)(t)
+	t, err := env.NewObject(cpg.FunctionTypeClass,
+		name,
+		parametersTypesList.Cast("java/util/List"),
+		returnTypesList.Cast("java/util/List"),
+		lang)
+	if err != nil {
+		panic(err)
 	}
 
-	return (*cpg.Type)(cpg.UnknownType_getUnknown(lang))
+	return (*cpg.Type)(t)
+}
+
+// withoutTypeArguments strips the type arguments from a (pointer to a) generic
+// type, e.g. *List[T] of a generic receiver, since only the generic type itself
+// is declared.
+func withoutTypeArguments(typ ast.Expr) ast.Expr {
+	switch v := typ.(type) {
+	case *ast.StarExpr:
+		return &ast.StarExpr{Star: v.Star, X: withoutTypeArguments(v.X)}
+	case *ast.IndexExpr:
+		return v.X
+	case *ast.IndexListExpr:
+		return v.X
+	}
+
+	return typ
+}
+
+// groupSize returns the number of parameters or results declared by field,
+// which is one for an unnamed one.
+func groupSize(field *ast.Field) int {
+	if len(field.Names) == 0 {
+		return 1
+	}
+
+	return len(field.Names)
 }
 
 func (this *GoLanguageFrontend) isBuiltinType(s string) bool {
@@ -0,0 +1,271 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// The routes of a web server are registered by calls, which pass the path
+// pattern and the handler of the route, e.g., http.HandleFunc("/users", list).
+// Such calls, as well as the handlers, are marked by an "endpoint" annotation,
+// whose members contain the "framework", the HTTP "method" (ANY, if the route
+// matches all methods), the "path", if it is a constant, and the FQN of the
+// "handler", if it is a named function. The handlers are the places, where
+// external data enters the program.
+
+// httpRoute describes the arguments of a call, which registers a route. The
+// method is either fixed by the called function, e.g. GET for router.GET, or
+// passed as argument. A negative handlerArg counts from the last argument,
+// since some frameworks pass middlewares before or after the handler.
+type httpRoute struct {
+	framework  string
+	method     string
+	methodArg  int
+	pathArg    int
+	handlerArg int
+}
+
+// httpMethods are the methods of HTTP requests.
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+
+// httpRoutes are the functions of the supported frameworks, which register a
+// route.
+var httpRoutes = newHTTPRoutes()
+
+func newHTTPRoutes() map[libraryFunc]httpRoute {
+	var routes = map[libraryFunc]httpRoute{}
+
+	add := func(pkg string, recvs []string, name string, route httpRoute) {
+		for _, recv := range recvs {
+			routes[libraryFunc{pkg, recv, name}] = route
+		}
+	}
+
+	// net/http, including the method patterns of Go 1.22, e.g. "GET /users"
+	for _, name := range []string{"Handle", "HandleFunc"} {
+		add("net/http", []string{"", "ServeMux"}, name, httpRoute{"net/http", "", -1, 0, 1})
+	}
+
+	// github.com/gorilla/mux, whose routes can be restricted by Methods
+	for _, name := range []string{"Handle", "HandleFunc"} {
+		add("github.com/gorilla/mux", []string{"Router"}, name, httpRoute{"gorilla/mux", "", -1, 0, 1})
+	}
+
+	// github.com/gin-gonic/gin, where the last handler follows the middlewares.
+	// Unlike the other frameworks, gin has no methods for CONNECT and TRACE.
+	gin := []string{"RouterGroup", "IRoutes"}
+	for _, method := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"} {
+		add("github.com/gin-gonic/gin", gin, method, httpRoute{"gin", method, -1, 0, -1})
+	}
+	add("github.com/gin-gonic/gin", gin, "Any", httpRoute{"gin", "", -1, 0, -1})
+	add("github.com/gin-gonic/gin", gin, "Handle", httpRoute{"gin", "", 0, 1, -1})
+
+	// github.com/labstack/echo, where the middlewares follow the handler
+	echo := []string{"Echo", "Group"}
+	for _, method := range httpMethods {
+		add("github.com/labstack/echo", echo, method, httpRoute{"echo", method, -1, 0, 1})
+	}
+	add("github.com/labstack/echo", echo, "Any", httpRoute{"echo", "", -1, 0, 1})
+	add("github.com/labstack/echo", echo, "Add", httpRoute{"echo", "", 0, 1, 2})
+
+	// github.com/go-chi/chi, whose methods are named like Get
+	chi := []string{"Router", "Mux"}
+	for _, method := range httpMethods {
+		name := method[:1] + strings.ToLower(method[1:])
+		add("github.com/go-chi/chi", chi, name, httpRoute{"chi", method, -1, 0, 1})
+	}
+	for _, name := range []string{"Handle", "HandleFunc"} {
+		add("github.com/go-chi/chi", chi, name, httpRoute{"chi", "", -1, 0, 1})
+	}
+	for _, name := range []string{"Method", "MethodFunc"} {
+		add("github.com/go-chi/chi", chi, name, httpRoute{"chi", "", 0, 1, 2})
+	}
+
+	return routes
+}
+
+// gorillaMethods is the method of a gorilla/mux route, which restricts the
+// methods of the route registered by the call it is chained to, e.g.,
+// r.HandleFunc("/users", list).Methods("GET").
+var gorillaMethods = libraryFunc{"github.com/gorilla/mux", "Route", "Methods"}
+
+// collectRouteMethods remembers the methods of the route registered by the
+// call, on which the call callExpr of gorillaMethods is chained. It needs to
+// be called before the registering call is handled.
+func (this *GoLanguageFrontend) collectRouteMethods(callExpr *ast.CallExpr) {
+	fn := this.calledFunc(callExpr)
+	if fn == nil || libraryFuncOf(fn) != gorillaMethods {
+		return
+	}
+
+	sel, ok := unparen(callExpr.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	registration, ok := unparen(sel.X).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	var methods []string
+	for _, arg := range callExpr.Args {
+		if method, ok := this.stringConstant(arg); ok {
+			methods = append(methods, strings.ToUpper(method))
+		}
+	}
+
+	if this.routeMethods == nil {
+		this.routeMethods = map[*ast.CallExpr][]string{}
+	}
+
+	this.routeMethods[registration] = methods
+}
+
+// handleEndpoint adds the "endpoint" annotation to the call c of fn and to the
+// handler passed to it, if the call registers a route. The arguments are the
+// already handled ones of the call.
+func (this *GoLanguageFrontend) handleEndpoint(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func, args []*cpg.Expression) {
+	route, ok := httpRoutes[libraryFuncOf(fn)]
+	if !ok || route.pathArg >= len(callExpr.Args) {
+		return
+	}
+
+	handlerArg := route.handlerArg
+	if handlerArg < 0 {
+		handlerArg += len(callExpr.Args)
+	}
+
+	if handlerArg < 0 || handlerArg >= len(callExpr.Args) {
+		return
+	}
+
+	method := route.method
+	if route.methodArg >= 0 {
+		method, _ = this.stringConstant(callExpr.Args[route.methodArg])
+	}
+
+	path, hasPath := this.stringConstant(callExpr.Args[route.pathArg])
+
+	// e.g. "GET /users/{id}" or "POST example.com/users"
+	if prefix, rest, found := strings.Cut(path, " "); route.framework == "net/http" && found && isHTTPMethod(prefix) {
+		method = prefix
+		path = strings.TrimSpace(rest)
+	}
+
+	if methods, found := this.routeMethods[callExpr]; found {
+		method = strings.Join(methods, ",")
+		delete(this.routeMethods, callExpr)
+	}
+
+	if method == "" {
+		method = "ANY"
+	}
+
+	values := [][2]string{
+		{"framework", route.framework},
+		{"method", strings.ToUpper(method)},
+	}
+
+	if hasPath {
+		values = append(values, [2]string{"path", path})
+	}
+
+	handler := callExpr.Args[handlerArg]
+	handlerFn := this.handlerFunc(handler)
+
+	if handlerFn != nil {
+		values = append(values, [2]string{"handler", qualifier(handlerFn) + "." + handlerFn.Name()})
+	}
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{this.newStringAnnotation(fset, "endpoint", values)})
+
+	// the handler is either declared elsewhere or a function literal
+	var decl *cpg.Node
+	if handlerFn != nil {
+		decl = this.declarationOfFunc(handlerFn)
+	} else if _, isLit := unparen(handler).(*ast.FuncLit); isLit && handlerArg < len(args) {
+		decl = (*cpg.Node)(args[handlerArg])
+	}
+
+	if decl != nil {
+		decl.AddAnnotations([]*cpg.Annotation{this.newStringAnnotation(fset, "endpoint", values)})
+	}
+}
+
+// handlerFunc returns the named function or method, which is passed as
+// handler, e.g. list in http.HandleFunc("/users", list) or s.list for a method
+// value. Conversions, e.g. to http.HandlerFunc, are skipped.
+func (this *GoLanguageFrontend) handlerFunc(handler ast.Expr) *types.Func {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return nil
+	}
+
+	switch v := unparen(handler).(type) {
+	case *ast.Ident:
+		fn, _ := this.Package.TypesInfo.Uses[v].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		fn, _ := this.Package.TypesInfo.Uses[v.Sel].(*types.Func)
+		return fn
+	case *ast.CallExpr:
+		if len(v.Args) == 1 && this.isConversion(v) {
+			return this.handlerFunc(v.Args[0])
+		}
+	}
+
+	return nil
+}
+
+// declarationOfFunc returns the declaration of the function or method fn, if
+// it is declared in the loaded packages.
+func (this *GoLanguageFrontend) declarationOfFunc(fn *types.Func) *cpg.Node {
+	if fn.Type().(*types.Signature).Recv() != nil {
+		if f, ok := this.Symbols.method(fn); ok {
+			return (*cpg.Node)(f)
+		}
+	} else if f, ok := this.Symbols.functionOf(fn); ok {
+		return (*cpg.Node)(f)
+	}
+
+	return nil
+}
+
+// isHTTPMethod checks, whether s is the method of an HTTP request.
+func isHTTPMethod(s string) bool {
+	for _, method := range httpMethods {
+		if s == method {
+			return true
+		}
+	}
+
+	return false
+}
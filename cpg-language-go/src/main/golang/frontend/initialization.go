@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// The initialization of a package consists of the initializers of its
+// package-level variables, in the order determined by their dependencies,
+// followed by its init functions, in the order in which the files are presented
+// to the compiler and in which they appear within a file. Each part of it is
+// marked by an "initialization" annotation, whose members contain the package
+// and the position of the part within the initialization of the package. This
+// way, the order can be restored across all translation units of a package. The
+// package is given by its path, by which the full names of its package-level
+// declarations are qualified (see qualifier), and whether it is a command, i.e.,
+// a package named main, whose initialization is followed by its main function.
+
+// isInitFunction checks, whether funcDecl is an init function of its package.
+func isInitFunction(funcDecl *ast.FuncDecl) bool {
+	return funcDecl.Recv == nil && funcDecl.Name.Name == "init"
+}
+
+// initFunctionOrder returns the position of the init function funcDecl within the
+// initialization of the current package, i.e., after all variable initializers.
+func (this *GoLanguageFrontend) initFunctionOrder(funcDecl *ast.FuncDecl) (order int, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return 0, false
+	}
+
+	order = len(this.Package.TypesInfo.InitOrder)
+
+	for _, file := range this.Package.Syntax {
+		for _, decl := range file.Decls {
+			if f, isFunc := decl.(*ast.FuncDecl); isFunc && isInitFunction(f) {
+				if f == funcDecl {
+					return order, true
+				}
+
+				order++
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// variableInitializerOrder returns the position of the initializer of the
+// package-level variable defined by ident within the initialization of the
+// current package. Variables without an initializer are not part of it.
+func (this *GoLanguageFrontend) variableInitializerOrder(ident *ast.Ident) (order int, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return 0, false
+	}
+
+	v, isVar := this.Package.TypesInfo.Defs[ident].(*types.Var)
+	if !isVar {
+		return 0, false
+	}
+
+	for i, init := range this.Package.TypesInfo.InitOrder {
+		for _, lhs := range init.Lhs {
+			if lhs == v {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// newInitializationAnnotation creates the annotation, which marks a part of the
+// initialization of the current package at the position order.
+func (this *GoLanguageFrontend) newInitializationAnnotation(fset *token.FileSet, order int) *cpg.Annotation {
+	path := this.Package.PkgPath
+	if this.Package.Types != nil {
+		path = this.Package.Types.Path()
+	}
+
+	pkg := this.NewLiteral(fset, nil, cpg.NewString(path), this.parseType("string"))
+	pos := this.NewLiteral(fset, nil, cpg.NewInteger(order), this.parseType("int"))
+	command := this.NewLiteral(fset, nil, cpg.NewBoolean(this.Package.Name == "main"), this.parseType("bool"))
+
+	a := this.NewAnnotation(fset, nil, "initialization")
+	a.SetMembers([]*cpg.AnnotationMember{
+		this.NewAnnotationMember(fset, nil, "package", (*cpg.Expression)(pkg)),
+		this.NewAnnotationMember(fset, nil, "order", (*cpg.Expression)(pos)),
+		this.NewAnnotationMember(fset, nil, "command", (*cpg.Expression)(command)),
+	})
+
+	return a
+}
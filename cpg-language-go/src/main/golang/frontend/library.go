@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+)
+
+// libraryFunc identifies a function or method of a (third-party) library by
+// the path of its package, the name of its receiver type, if it is a method,
+// and its name. The major version suffix of the path of a module, e.g. /v5, is
+// omitted, also within the path of a package of the module, so that all
+// versions of a library are recognized alike.
+type libraryFunc struct {
+	pkg  string
+	recv string
+	name string
+}
+
+// majorVersionSuffix is the suffix of the path of a module with a major
+// version of 2 or higher, e.g., in github.com/jackc/pgx/v5/pgxpool.
+var majorVersionSuffix = regexp.MustCompile(`/v[0-9]+(/|$)`)
+
+// libraryFuncOf returns the libraryFunc of fn. The receiver of a promoted
+// method is the type, which declares it, e.g., gin.RouterGroup for a method
+// called on a gin.Engine.
+func libraryFuncOf(fn *types.Func) (lf libraryFunc) {
+	lf.name = fn.Name()
+
+	if fn.Pkg() != nil {
+		lf.pkg = majorVersionSuffix.ReplaceAllString(fn.Pkg().Path(), "$1")
+	}
+
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		if named, _ := receiverType(recv.Type()); named != nil {
+			lf.recv = named.Obj().Name()
+		}
+	}
+
+	return
+}
+
+// stringConstant returns the value of expr, if it is a constant string, e.g., a
+// literal or a named constant.
+func (this *GoLanguageFrontend) stringConstant(expr ast.Expr) (s string, ok bool) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		if lit, isLit := expr.(*ast.BasicLit); isLit && lit.Kind == token.STRING {
+			return constant.StringVal(constant.MakeFromLiteral(lit.Value, lit.Kind, 0)), true
+		}
+
+		return "", false
+	}
+
+	tv, found := this.Package.TypesInfo.Types[expr]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
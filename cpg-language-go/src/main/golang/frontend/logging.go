@@ -0,0 +1,264 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Calls, which write to a log, are marked by a "logging" annotation, whose
+// members contain the "library", the logger "method", the "severity", if it is
+// known, and the "argument" indices of the logged values, one member for each.
+// The logged values are all arguments except for contexts, levels and the call
+// depth of log.Output. The calls are recognized by the types of their
+// receivers and packages, so that other functions named, e.g., Info are not
+// mistaken for loggers.
+
+// logSeverities are the severities of the logging methods, by the prefix of
+// their name, e.g. Info for Infof, Infoln, Infow and InfoContext.
+var logSeverities = []struct {
+	prefix   string
+	severity string
+}{
+	{"Trace", "trace"},
+	{"Debug", "debug"},
+	{"Info", "info"},
+	{"Print", "info"},
+	{"Warning", "warn"},
+	{"Warn", "warn"},
+	{"Error", "error"},
+	{"DPanic", "panic"},
+	{"Panic", "panic"},
+	{"Fatal", "fatal"},
+	{"Log", ""},
+}
+
+// logMethodSuffixes are the suffixes of the variants of a logging method.
+var logMethodSuffixes = map[string]bool{
+	"":        true,
+	"f":       true,
+	"ln":      true,
+	"w":       true,
+	"Fn":      true,
+	"Attrs":   true,
+	"Context": true,
+}
+
+// logSeverity returns the severity of the logging method with the given name,
+// e.g. Errorf, and whether it is a logging method at all.
+func logSeverity(name string) (severity string, ok bool) {
+	for _, s := range logSeverities {
+		if strings.HasPrefix(name, s.prefix) && logMethodSuffixes[strings.TrimPrefix(name, s.prefix)] {
+			return s.severity, true
+		}
+	}
+
+	return "", false
+}
+
+// loggingCallOf returns the library and severity of fn, if it writes to a log.
+// The severity of zerolog is determined by the call, which starts an event,
+// rather than by fn, which adds to the event.
+func loggingCallOf(fn *types.Func) (library string, severity string, ok bool) {
+	lf := libraryFuncOf(fn)
+	severity, isLevel := logSeverity(lf.name)
+
+	switch lf.pkg {
+	case "log":
+		if lf.recv != "" && lf.recv != "Logger" {
+			return
+		}
+
+		// the standard logger has no levels, but Fatal and Panic exit
+		if lf.name == "Output" || strings.HasPrefix(lf.name, "Print") {
+			return "log", "", true
+		}
+
+		if isLevel && (severity == "fatal" || severity == "panic") {
+			return "log", severity, true
+		}
+	case "log/slog":
+		if lf.recv != "" && lf.recv != "Logger" {
+			return
+		}
+
+		// the attributes are logged along with each message of the logger
+		if lf.name == "With" {
+			return "slog", "", true
+		}
+
+		if isLevel && !strings.HasPrefix(lf.name, "Print") {
+			return "slog", severity, true
+		}
+	case "github.com/sirupsen/logrus":
+		switch lf.recv {
+		case "", "Logger", "Entry", "FieldLogger", "Ext1FieldLogger", "StdLogger":
+		default:
+			return
+		}
+
+		// the fields are logged along with the message of the entry
+		if lf.name == "WithField" || lf.name == "WithFields" || lf.name == "WithError" {
+			return "logrus", "", true
+		}
+
+		if isLevel {
+			return "logrus", severity, true
+		}
+	case "go.uber.org/zap":
+		if lf.recv != "Logger" && lf.recv != "SugaredLogger" {
+			return
+		}
+
+		// the fields are logged along with each message of the logger
+		if lf.name == "With" {
+			return "zap", "", true
+		}
+
+		if isLevel && !strings.HasPrefix(lf.name, "Print") {
+			return "zap", severity, true
+		}
+	case "github.com/rs/zerolog", "github.com/rs/zerolog/log":
+		// e.g. log.Info().Str("email", email).Msg("login")
+		if lf.recv == "Event" && fn.Type().(*types.Signature).Params().Len() > 0 {
+			return "zerolog", "", true
+		}
+
+		// Print logs at the debug level
+		if (lf.recv == "" || lf.recv == "Logger") && strings.HasPrefix(lf.name, "Print") && isLevel {
+			return "zerolog", "debug", true
+		}
+	}
+
+	return "", "", false
+}
+
+// handleLoggingCall adds the "logging" annotation to the call c of fn, if it
+// writes to a log.
+func (this *GoLanguageFrontend) handleLoggingCall(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func) {
+	library, severity, ok := loggingCallOf(fn)
+	if !ok || this.Package == nil || this.Package.TypesInfo == nil {
+		return
+	}
+
+	if library == "zerolog" && severity == "" {
+		severity = this.zerologSeverity(callExpr)
+	}
+
+	values := [][2]string{
+		{"library", library},
+		{"method", fn.Name()},
+	}
+
+	if severity != "" {
+		values = append(values, [2]string{"severity", severity})
+	}
+
+	members := this.newStringMembers(fset, values)
+
+	for i, arg := range callExpr.Args {
+		if i == 0 && library == "log" && fn.Name() == "Output" {
+			continue
+		}
+
+		if isLogOption(this.Package.TypesInfo.TypeOf(arg)) {
+			continue
+		}
+
+		lit := this.NewLiteral(fset, nil, cpg.NewInteger(i), this.parseType("int"))
+		members = append(members, this.NewAnnotationMember(fset, nil, "argument", (*cpg.Expression)(lit)))
+	}
+
+	a := this.NewAnnotation(fset, nil, "logging")
+	a.SetMembers(members)
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{a})
+}
+
+// logLevelPackages are the packages of the logging libraries, which declare
+// a Level type.
+var logLevelPackages = map[string]bool{
+	"log/slog":                   true,
+	"github.com/sirupsen/logrus": true,
+	"go.uber.org/zap/zapcore":    true,
+	"github.com/rs/zerolog":      true,
+}
+
+// isLogOption checks, whether the argument of type t controls the logging,
+// rather than being logged, i.e., it is a context or a level.
+func isLogOption(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	name := named.Obj().Name()
+	path := named.Obj().Pkg().Path()
+
+	return (path == "context" && name == "Context") || (logLevelPackages[path] && name == "Level")
+}
+
+// zerologSeverity returns the severity of the zerolog event, to which the call
+// callExpr adds, by following the chain of calls to the one, which started the
+// event, e.g. Info in log.Info().Str("email", email).Msg("login").
+func (this *GoLanguageFrontend) zerologSeverity(callExpr *ast.CallExpr) string {
+	for {
+		sel, ok := unparen(callExpr.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+
+		callExpr, ok = unparen(sel.X).(*ast.CallExpr)
+		if !ok {
+			return ""
+		}
+
+		fn := this.calledFunc(callExpr)
+		if fn == nil {
+			return ""
+		}
+
+		lf := libraryFuncOf(fn)
+		if lf.recv == "Event" {
+			continue
+		}
+
+		if (lf.pkg == "github.com/rs/zerolog" && lf.recv == "Logger") || (lf.pkg == "github.com/rs/zerolog/log" && lf.recv == "") {
+			if lf.name == "Err" {
+				return "error"
+			}
+
+			severity, _ := logSeverity(lf.name)
+			return severity
+		}
+
+		return ""
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"go/ast"
+	"go/token"
+
+	"tekao.net/jnigi"
+)
+
+// nodeMetadata encodes the code and location of a node, so that they can be
+// transferred to the JVM without any JNI call. Otherwise, setting them requires
+// several JNI calls for each node. The data is placed in a direct buffer, which
+// is shared with the JVM and read by GoLanguageFrontend.setCodeAndLocation.
+type nodeMetadata struct {
+	data bytes.Buffer
+
+	// shared is the memory of the direct buffer of the JVM. It is retrieved
+	// once per file and only again, if the data of a node does not fit.
+	shared []byte
+}
+
+func (m *nodeMetadata) encode(fset *token.FileSet, astNode ast.Node, code string) []byte {
+	m.data.Reset()
+	m.writeString(code)
+
+	var file *token.File
+	if astNode != nil {
+		file = fset.File(astNode.Pos())
+	}
+
+	if file == nil {
+		m.data.WriteByte(0)
+		return m.data.Bytes()
+	}
+
+	// the location honors //line directives, which map generated code back to
+	// its original source, e.g., a grammar of goyacc
+	start := fset.PositionFor(astNode.Pos(), true)
+	end := fset.PositionFor(astNode.End(), true)
+
+	physicalStart := fset.PositionFor(astNode.Pos(), false)
+	physicalEnd := fset.PositionFor(astNode.End(), false)
+
+	m.data.WriteByte(1)
+
+	// A node spanning a directive would have a region across two files, and the
+	// file as a whole is not remapped at all. Then, only the file, from which it
+	// was parsed, is used.
+	_, isFile := astNode.(*ast.File)
+	if isFile || start.Filename != end.Filename {
+		start, end = physicalStart, physicalEnd
+	}
+
+	if start == physicalStart && end == physicalEnd {
+		m.writeRegion(file.Name(), start, end, true)
+		m.data.WriteByte(0)
+		return m.data.Bytes()
+	}
+
+	// the byte offsets only refer to the file, from which the node was parsed,
+	// which is kept as its physical location
+	m.writeRegion(start.Filename, start, end, false)
+	m.data.WriteByte(1)
+	m.writeRegion(file.Name(), physicalStart, physicalEnd, true)
+
+	return m.data.Bytes()
+}
+
+func (m *nodeMetadata) writeRegion(filename string, start token.Position, end token.Position, withOffsets bool) {
+	m.writeString(filename)
+	m.writeInt(start.Line)
+	m.writeInt(start.Column)
+	m.writeInt(end.Line)
+	m.writeInt(end.Column)
+
+	if withOffsets {
+		m.writeInt(start.Offset)
+		m.writeInt(end.Offset)
+	} else {
+		m.writeInt(-1)
+		m.writeInt(-1)
+	}
+}
+
+func (m *nodeMetadata) writeString(s string) {
+	m.writeInt(len(s))
+	m.data.WriteString(s)
+}
+
+func (m *nodeMetadata) writeInt(i int) {
+	_ = binary.Write(&m.data, binary.BigEndian, int32(i))
+}
+
+// setMetadata transfers the code and location of astNode to the JVM, where they
+// are applied to the next node created by a builder. This way, they are already
+// set while the node is created, i.e., before it is added to any scope or
+// collection, which relies on its hash code.
+func (this *GoLanguageFrontend) setMetadata(fset *token.FileSet, astNode ast.Node) {
+	data := this.metadata.encode(fset, astNode, this.GetCodeFromRawNode(fset, astNode))
+
+	// the data is preceded by its length, which the JVM resets, once the data
+	// is applied
+	size := len(data) + 4
+	if len(this.metadata.shared) < size {
+		this.metadata.shared = this.metadataBuffer(size)
+	}
+
+	copy(this.metadata.shared[4:], data)
+	binary.BigEndian.PutUint32(this.metadata.shared, uint32(len(data)))
+}
+
+// metadataBuffer returns the memory of the direct buffer of the JVM, which holds
+// at least size bytes.
+func (this *GoLanguageFrontend) metadataBuffer(size int) []byte {
+	var buffer = jnigi.NewObjectRef("java/nio/ByteBuffer")
+
+	err := this.ObjectRef.CallMethod(env, "metadataBuffer", buffer, size)
+	if err != nil {
+		panic(err)
+	}
+
+	defer env.DeleteLocalRef(buffer)
+
+	return this.DirectBuffer(buffer)
+}
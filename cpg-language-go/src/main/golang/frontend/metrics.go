@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"time"
+)
+
+// maxSamples is the maximum number of samples, which are kept for each kind of
+// node that is not supported
+const maxSamples = 5
+
+// Metrics holds the statistics of the translation of a project, i.e., how often
+// each kind of node was handled or was not supported, as well as the time spent
+// in each phase of the translation. It is retrieved by the JVM as JSON, where it
+// is represented by the GoMetrics class.
+type Metrics struct {
+	// Handled counts the nodes of each kind, e.g., "CallExpr", which were translated
+	Handled map[string]int `json:"handled"`
+
+	// Unhandled counts the nodes of each kind, which are not supported yet
+	Unhandled map[string]int `json:"unhandled"`
+
+	// Samples contains the positions of some of the unsupported nodes of each kind
+	Samples map[string][]string `json:"samples"`
+
+	// Phases contains the time spent in each phase, e.g., "load", in milliseconds
+	Phases map[string]int64 `json:"phases"`
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		Handled:   map[string]int{},
+		Unhandled: map[string]int{},
+		Samples:   map[string][]string{},
+		Phases:    map[string]int64{},
+	}
+}
+
+// kindOf returns the kind of the node, i.e., the name of its type without the
+// package.
+func kindOf(node ast.Node) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+}
+
+// handled counts the node as translated.
+func (m *Metrics) handled(node ast.Node) {
+	if m == nil {
+		return
+	}
+
+	m.Handled[kindOf(node)]++
+}
+
+// unhandled counts the node as not supported and keeps its position as sample.
+func (m *Metrics) unhandled(fset *token.FileSet, node ast.Node) {
+	if m == nil {
+		return
+	}
+
+	kind := kindOf(node)
+	m.Unhandled[kind]++
+
+	if len(m.Samples[kind]) < maxSamples {
+		m.Samples[kind] = append(m.Samples[kind], fset.Position(node.Pos()).String())
+	}
+}
+
+// Track adds the time elapsed since start to the given phase, since a phase may
+// consist of several steps, e.g., the translation of each file.
+func (m *Metrics) Track(phase string, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.Phases[phase] += time.Since(start).Milliseconds()
+}
+
+// JSON returns the JSON representation of the metrics.
+func (m *Metrics) JSON() (string, error) {
+	b, err := json.Marshal(m)
+
+	return string(b), err
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"fmt"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Module is a Go module of the project, which is defined by a go.mod file.
+type Module struct {
+	// Path is the effective path of the module, under which its packages are
+	// imported. It differs from the path declared in its go.mod file, if the
+	// module replaces another one.
+	Path string
+
+	// Dir is the slash-separated root directory of the module relative to the
+	// top level
+	Dir string
+
+	// File is the parsed go.mod file of the module
+	File *modfile.File
+
+	// ReplacedBy is the module, whose replace directive points to the directory
+	// of this module, if any. Its packages are loaded as part of the replacing
+	// module, so that both agree on the types of the packages.
+	ReplacedBy *Module
+}
+
+// Modules holds the Go modules of a project. Besides the module at the top
+// level, monorepos often contain nested modules in their subdirectories, each
+// with a module path of its own. The packages of a nested module do not belong
+// to any enclosing module.
+type Modules struct {
+	topLevel string
+
+	// modules contains the module of each directory, which was looked up, keyed
+	// by its slash-separated path relative to the top level, or nil, if the
+	// directory does not contain a go.mod file
+	modules map[string]*Module
+}
+
+// NewModules creates the modules of the project at topLevel. The module at the
+// top level is read right away, so that its replace directives are honored
+// for all of its nested modules. The ones of nested modules are honored, once
+// the replacing module was looked up.
+func NewModules(topLevel string) *Modules {
+	m := &Modules{
+		topLevel: topLevel,
+		modules:  map[string]*Module{},
+	}
+
+	m.module("")
+
+	return m
+}
+
+// Lookup returns the innermost module, which contains the directory with the
+// given slash-separated path relative to the top level.
+func (m *Modules) Lookup(dir string) *Module {
+	for {
+		if mod := m.module(dir); mod != nil {
+			return mod
+		}
+
+		if dir == "" {
+			return nil
+		}
+
+		dir = path.Dir(dir)
+		if dir == "." {
+			dir = ""
+		}
+	}
+}
+
+// PackagePath returns the import path of the package in the directory with the
+// given slash-separated path relative to the top level, as well as the root
+// directory of the module, from which it is loaded. Without any module, it is
+// the directory itself.
+func (m *Modules) PackagePath(dir string) (pkgPath string, root string) {
+	mod := m.Lookup(dir)
+	if mod == nil {
+		return dir, ""
+	}
+
+	pkgPath = strings.TrimRight(mod.Path+"/"+relativeToRoot(dir, mod.Dir), "/")
+
+	// replace directives can be chained, but also form cycles
+	seen := map[*Module]bool{mod: true}
+	for mod.ReplacedBy != nil && !seen[mod.ReplacedBy] {
+		mod = mod.ReplacedBy
+		seen[mod] = true
+	}
+
+	return pkgPath, mod.Dir
+}
+
+// module returns the module, whose go.mod file is located in the directory with
+// the given slash-separated path relative to the top level.
+func (m *Modules) module(dir string) *Module {
+	if mod, ok := m.modules[dir]; ok {
+		return mod
+	}
+
+	// a broken go.mod file is treated like a missing one, the go command
+	// reports it anyway when loading the packages
+	file, _ := readModule(filepath.Join(m.topLevel, filepath.FromSlash(dir)))
+	if file == nil {
+		m.modules[dir] = nil
+		return nil
+	}
+
+	mod := &Module{
+		Path: file.Module.Mod.Path,
+		Dir:  dir,
+		File: file,
+	}
+
+	m.modules[dir] = mod
+	m.replace(mod)
+
+	return mod
+}
+
+// replace applies the replace directives of mod, which point to a local
+// directory within the top level. The packages of the module in this directory
+// are imported under the replaced module path. Directories outside of the top
+// level are not part of the project and are resolved by the go command like
+// any other dependency.
+func (m *Modules) replace(mod *Module) {
+	for _, r := range mod.File.Replace {
+		if !modfile.IsDirectoryPath(r.New.Path) {
+			continue
+		}
+
+		target := filepath.Join(m.topLevel, filepath.FromSlash(mod.Dir), filepath.FromSlash(r.New.Path))
+
+		rel, ok := RelativePath(m.topLevel, target)
+		if !ok {
+			continue
+		}
+
+		dir := filepath.ToSlash(rel)
+		if dir == "." {
+			dir = ""
+		}
+
+		if replaced := m.module(dir); replaced != nil && replaced != mod {
+			replaced.Path = r.Old.Path
+			replaced.ReplacedBy = mod
+		}
+	}
+}
+
+// relativeToRoot returns the slash-separated directory dir relative to the root
+// directory of its module.
+func relativeToRoot(dir string, root string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(dir, root), "/")
+}
+
+// readModule parses the go.mod file in the given directory. It returns nil, if
+// there is none.
+func readModule(dir string) (*modfile.File, error) {
+	mod := filepath.Join(dir, "go.mod")
+
+	b, err := os.ReadFile(mod)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read go.mod: %w", err)
+	}
+
+	module, err := modfile.Parse(mod, b, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse mod file: %w", err)
+	}
+
+	if module.Module == nil {
+		return nil, fmt.Errorf("mod file %s has no module directive", mod)
+	}
+
+	return module, nil
+}
+
+// SetModuleOf sets the module of the file with the given path, as well as its
+// directory relative to the root of the module, from which the path of its
+// package is derived.
+func (this *GoLanguageFrontend) SetModuleOf(modules *Modules, path string) {
+	dir := RelativeDir(modules.topLevel, path)
+
+	this.Module = modules.Lookup(dir)
+	this.RelativeFilePath = dir
+
+	if this.Module != nil {
+		this.RelativeFilePath = relativeToRoot(dir, this.Module.Dir)
+	}
+}
+
+// handleModule creates the annotations, which describe the module of the
+// current file, as declared in its go.mod file. Its module annotation contains
+// the effective path of the module, its root directory relative to the top
+// level and the declared Go version, as well as the path declared in its go.mod
+// file, if the module replaces another one. Each requirement of the module
+// results in a require annotation, whose members contain the required module,
+// its version and whether it is an indirect one. Each replace directive results
+// in a replace annotation, whose members contain the replaced module and its
+// replacement, i.e., a module version or a local directory.
+func (this *GoLanguageFrontend) handleModule(fset *token.FileSet) (annotations []*cpg.Annotation) {
+	var (
+		file   = this.Module.File
+		values = [][2]string{
+			{"path", this.Module.Path},
+			{"dir", this.Module.Dir},
+		}
+	)
+
+	if file.Go != nil {
+		values = append(values, [2]string{"go", file.Go.Version})
+	}
+
+	if declared := file.Module.Mod.Path; declared != this.Module.Path {
+		values = append(values, [2]string{"declared", declared})
+	}
+
+	annotations = append(annotations, this.newStringAnnotation(fset, "module", values))
+
+	for _, r := range file.Require {
+		members := this.newStringMembers(fset, [][2]string{
+			{"path", r.Mod.Path},
+			{"version", r.Mod.Version},
+		})
+
+		lit := this.NewLiteral(fset, nil, cpg.NewBoolean(r.Indirect), this.parseType("bool"))
+		members = append(members, this.NewAnnotationMember(fset, nil, "indirect", (*cpg.Expression)(lit)))
+
+		a := this.NewAnnotation(fset, nil, "require")
+		a.SetMembers(members)
+
+		annotations = append(annotations, a)
+	}
+
+	for _, r := range file.Replace {
+		annotations = append(annotations, this.newStringAnnotation(fset, "replace", [][2]string{
+			{"old", r.Old.String()},
+			{"new", r.New.String()},
+		}))
+	}
+
+	return
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2022, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"tekao.net/jnigi"
+)
+
+func (frontend *GoLanguageFrontend) NewAnnotation(fset *token.FileSet, astNode ast.Node, name string) *cpg.Annotation {
+	return (*cpg.Annotation)(frontend.NewNode("Annotation", fset, astNode, name))
+}
+
+func (frontend *GoLanguageFrontend) NewAnnotationMember(fset *token.FileSet, astNode ast.Node, name string, value *cpg.Expression) *cpg.AnnotationMember {
+	return (*cpg.AnnotationMember)(frontend.NewNode("AnnotationMember", fset, astNode, name, value.Cast(cpg.ExpressionClass)))
+}
+
+func (frontend *GoLanguageFrontend) NewNode(typ string, fset *token.FileSet, astNode ast.Node, args ...any) *jnigi.ObjectRef {
+	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.GraphPackage, typ))
+
+	frontend.setMetadata(fset, astNode)
+	frontend.callBuilder("NodeBuilderKt", typ, node, args...)
+
+	return node
+}
+
+// callBuilder calls the builder function new<typ> of the given builder class,
+// e.g., ExpressionBuilderKt, which stores the created node in node. The frontend
+// is prepended to args as the receiver. Go strings among args are passed as
+// Java strings, which are only created once per project, see StringCache.
+// Without a cache, their local references are released after the call, so that
+// they do not pile up until the translation of the whole file is finished.
+func (frontend *GoLanguageFrontend) callBuilder(builder string, typ string, node *jnigi.ObjectRef, args ...any) {
+	var javaArgs = []any{frontend.Cast(MetadataProviderClass)}
+
+	for _, arg := range args {
+		if s, ok := arg.(string); ok {
+			str, cached := frontend.Strings.get(s)
+			if !cached {
+				defer env.DeleteLocalRef(str)
+			}
+
+			arg = str
+		}
+
+		javaArgs = append(javaArgs, arg)
+	}
+
+	err := env.CallStaticMethod(
+		cpg.GraphPackage+"/"+builder,
+		fmt.Sprintf("new%s", typ), node,
+		javaArgs...,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/token"
+	"go/types"
+)
+
+// Some calls never return to their caller. Instead, they either unwind the
+// stack of the goroutine, running its deferred calls, or terminate the whole
+// program right away. Such calls are marked by a "noreturn" annotation, whose
+// "kind" member is one of
+//   - "panic", which unwinds the stack, but can be recovered by a deferred call,
+//   - "goexit", which unwinds the stack of the goroutine and cannot be recovered,
+//     e.g., t.Fatal of a test,
+//   - "exit", which terminates the program without running any deferred call,
+//     e.g., os.Exit or log.Fatal.
+//
+// The ResolveGoNonReturningCalls pass removes the fall-through of these calls
+// from the EOG.
+
+// nonReturningFuncs maps the functions and methods, which never return, to the
+// kind of their exit, keyed by the path of their package and their name.
+var nonReturningFuncs = map[string]map[string]string{
+	"log": {
+		"Fatal":   "exit",
+		"Fatalf":  "exit",
+		"Fatalln": "exit",
+		"Panic":   "panic",
+		"Panicf":  "panic",
+		"Panicln": "panic",
+	},
+	"os": {
+		"Exit": "exit",
+	},
+	"runtime": {
+		"Goexit": "goexit",
+	},
+	"syscall": {
+		"Exit": "exit",
+	},
+	"testing": {
+		"Fatal":   "goexit",
+		"Fatalf":  "goexit",
+		"FailNow": "goexit",
+		"Skip":    "goexit",
+		"Skipf":   "goexit",
+		"SkipNow": "goexit",
+	},
+}
+
+// handleNonReturningCall adds the "noreturn" annotation to the call c, if it
+// is a call of the builtin panic or of fn, which is one of nonReturningFuncs.
+func (this *GoLanguageFrontend) handleNonReturningCall(fset *token.FileSet, c *cpg.CallExpression, builtin string, fn *types.Func) {
+	var kind string
+
+	if builtin == "panic" {
+		kind = "panic"
+	} else if fn != nil && fn.Pkg() != nil {
+		kind = nonReturningFuncs[fn.Pkg().Path()][fn.Name()]
+	}
+
+	if kind == "" {
+		return
+	}
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{
+		this.newStringAnnotation(fset, "noreturn", [][2]string{{"kind", kind}}),
+	})
+}
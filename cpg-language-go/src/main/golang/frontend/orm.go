@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Object-relational mappers (ORMs) store the values of structs, the models, in
+// the tables of a database. The calls of an ORM, which refer to models, are
+// marked by an "orm" annotation, whose members contain the "framework", the
+// "operation" (migrate, model, query, create, update or delete) and the type
+// of each "model". For the generated models of ent, whose table is declared by
+// a constant, the "table" is contained as well. Based on this, the
+// ResolveGoORMModels pass links the fields of the models to their columns.
+
+// ormCall describes a call of an ORM, whose arguments, starting at modelArg,
+// are models. A negative modelArg means, that the model is not passed as an
+// argument, but follows from the receiver, like for the clients of ent.
+type ormCall struct {
+	framework string
+	operation string
+	modelArg  int
+	variadic  bool
+}
+
+// ormCalls are the functions of the supported ORMs, which refer to models.
+var ormCalls = newORMCalls()
+
+func newORMCalls() map[libraryFunc]ormCall {
+	var calls = map[libraryFunc]ormCall{}
+
+	add := func(pkg string, recvs []string, operation string, names []string, call ormCall) {
+		call.operation = operation
+
+		for _, recv := range recvs {
+			for _, name := range names {
+				calls[libraryFunc{pkg, recv, name}] = call
+			}
+		}
+	}
+
+	// gorm.io/gorm and its predecessor github.com/jinzhu/gorm
+	for _, pkg := range []string{"gorm.io/gorm", "github.com/jinzhu/gorm"} {
+		gorm := ormCall{framework: "gorm"}
+		db := []string{"DB"}
+
+		add(pkg, db, "migrate", []string{"AutoMigrate"}, ormCall{framework: "gorm", variadic: true})
+		add(pkg, db, "model", []string{"Model"}, gorm)
+		add(pkg, db, "query", []string{"Find", "First", "Last", "Take", "Scan", "FirstOrInit", "FirstOrCreate"}, gorm)
+		add(pkg, db, "create", []string{"Create", "CreateInBatches"}, gorm)
+		add(pkg, db, "update", []string{"Save", "Updates"}, gorm)
+		add(pkg, db, "delete", []string{"Delete"}, gorm)
+	}
+
+	// github.com/jmoiron/sqlx, which scans rows into structs
+	sqlx := []string{"DB", "Tx", "Conn", "Stmt", "NamedStmt"}
+	add("github.com/jmoiron/sqlx", sqlx, "query", []string{"Get", "Select"}, ormCall{framework: "sqlx"})
+	add("github.com/jmoiron/sqlx", sqlx, "query", []string{"GetContext", "SelectContext"}, ormCall{framework: "sqlx", modelArg: 1})
+	add("github.com/jmoiron/sqlx", []string{"Row", "Rows"}, "query", []string{"StructScan"}, ormCall{framework: "sqlx"})
+	add("github.com/jmoiron/sqlx", []string{"DB", "Tx"}, "update", []string{"NamedExec", "NamedQuery"}, ormCall{framework: "sqlx", modelArg: 1})
+	add("github.com/jmoiron/sqlx", []string{"DB", "Tx"}, "update", []string{"NamedExecContext"}, ormCall{framework: "sqlx", modelArg: 2})
+
+	// github.com/uptrace/bun, whose queries are built for a model
+	bun := ormCall{framework: "bun"}
+	add("github.com/uptrace/bun", []string{"SelectQuery"}, "query", []string{"Model"}, bun)
+	add("github.com/uptrace/bun", []string{"InsertQuery"}, "create", []string{"Model"}, bun)
+	add("github.com/uptrace/bun", []string{"UpdateQuery"}, "update", []string{"Model"}, bun)
+	add("github.com/uptrace/bun", []string{"DeleteQuery"}, "delete", []string{"Model"}, bun)
+	add("github.com/uptrace/bun", []string{"CreateTableQuery"}, "migrate", []string{"Model"}, bun)
+
+	return calls
+}
+
+// entOperations are the operations of the methods of a generated ent client,
+// e.g., UserClient.Create.
+var entOperations = map[string]string{
+	"Create":      "create",
+	"CreateBulk":  "create",
+	"Query":       "query",
+	"Get":         "query",
+	"GetX":        "query",
+	"Update":      "update",
+	"UpdateOne":   "update",
+	"UpdateOneID": "update",
+	"Delete":      "delete",
+	"DeleteOne":   "delete",
+	"DeleteOneID": "delete",
+}
+
+// handleORMCall adds the "orm" annotation to the call c of fn, if it refers to
+// a model of an ORM.
+func (this *GoLanguageFrontend) handleORMCall(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func) {
+	if this.Package == nil || this.Package.TypesInfo == nil {
+		return
+	}
+
+	call, ok := ormCalls[libraryFuncOf(fn)]
+	if !ok {
+		call, ok = entCall(fn)
+	}
+
+	if !ok {
+		return
+	}
+
+	var models []*types.Named
+	var table string
+
+	if call.modelArg < 0 {
+		models, table = entModel(fn)
+	} else if call.modelArg < len(callExpr.Args) {
+		args := callExpr.Args[call.modelArg : call.modelArg+1]
+		if call.variadic {
+			args = callExpr.Args[call.modelArg:]
+		}
+
+		for _, arg := range args {
+			if model := modelType(this.Package.TypesInfo.TypeOf(arg)); model != nil {
+				models = append(models, model)
+			}
+		}
+	}
+
+	if len(models) == 0 {
+		return
+	}
+
+	a := this.NewAnnotation(fset, nil, "orm")
+
+	members := this.newStringMembers(fset, [][2]string{
+		{"framework", call.framework},
+		{"operation", call.operation},
+	})
+
+	for _, model := range models {
+		typ := this.NewTypeExpression(fset, nil, model.Obj().Name(), this.handleTypingType(model))
+		members = append(members, this.NewAnnotationMember(fset, nil, "model", (*cpg.Expression)(typ)))
+	}
+
+	if table != "" {
+		members = append(members, this.newStringMembers(fset, [][2]string{{"table", table}})...)
+	}
+
+	a.SetMembers(members)
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{a})
+}
+
+// modelType returns the struct type, which is referred to by the type t of an
+// argument, e.g., User for *User or *[]*User.
+func modelType(t types.Type) *types.Named {
+	for t != nil {
+		switch v := t.(type) {
+		case *types.Pointer:
+			t = v.Elem()
+		case *types.Slice:
+			t = v.Elem()
+		case *types.Array:
+			t = v.Elem()
+		case *types.Named:
+			if _, isStruct := v.Underlying().(*types.Struct); isStruct {
+				return v
+			}
+
+			return nil
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// entCall returns the ormCall of fn, if it is a method of a client generated
+// by ent, e.g., UserClient.Create. The package of such a client imports the
+// ent runtime.
+func entCall(fn *types.Func) (call ormCall, ok bool) {
+	operation, ok := entOperations[fn.Name()]
+	if !ok || fn.Pkg() == nil {
+		return call, false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return call, false
+	}
+
+	named, _ := receiverType(recv.Type())
+	if named == nil || !strings.HasSuffix(named.Obj().Name(), "Client") {
+		return call, false
+	}
+
+	for _, imported := range fn.Pkg().Imports() {
+		if imported.Path() == "entgo.io/ent" || imported.Path() == "github.com/facebook/ent" {
+			return ormCall{framework: "ent", operation: operation, modelArg: -1}, true
+		}
+	}
+
+	return call, false
+}
+
+// entModel returns the model of the ent client method fn, e.g., User for
+// UserClient.Create, as well as its table, which is declared as the constant
+// Table in the generated package of the model, e.g., ent/user.
+func entModel(fn *types.Func) (models []*types.Named, table string) {
+	named, _ := receiverType(fn.Type().(*types.Signature).Recv().Type())
+	name := strings.TrimSuffix(named.Obj().Name(), "Client")
+
+	obj, ok := fn.Pkg().Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, ""
+	}
+
+	model, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, ""
+	}
+
+	for _, imported := range fn.Pkg().Imports() {
+		if imported.Name() != strings.ToLower(name) {
+			continue
+		}
+
+		if c, ok := imported.Scope().Lookup("Table").(*types.Const); ok && c.Val().Kind() == constant.String {
+			table = constant.StringVal(c.Val())
+		}
+	}
+
+	return []*types.Named{model}, table
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// The paths of files are native paths of the operating system, e.g.,
+// C:\project\main.go on Windows, whereas package paths and the patterns of the
+// configuration are always separated by slashes. The following functions
+// convert between them, so that nothing depends on the separator of the
+// platform.
+
+// longPathPrefix is the prefix of extended-length paths on Windows, such as
+// \\?\C:\project or \\?\UNC\server\share, which Go handles transparently
+const longPathPrefix = `\\?\`
+
+// AbsPath returns the absolute, clean form of the path. Extended-length paths
+// on Windows are converted to their regular form, so that they can be related
+// to other paths.
+func AbsPath(path string) (string, error) {
+	if filepath.Separator == '\\' && strings.HasPrefix(path, longPathPrefix) {
+		path = path[len(longPathPrefix):]
+
+		if strings.HasPrefix(strings.ToUpper(path), `UNC\`) {
+			path = `\\` + path[len(`UNC\`):]
+		}
+	}
+
+	return filepath.Abs(path)
+}
+
+// RelativePath returns the path of target relative to base, if target is located
+// within base. Paths on different volumes, e.g., different drives on Windows,
+// are never located within each other.
+func RelativePath(base string, target string) (rel string, ok bool) {
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
+
+// RelativeDir returns the slash-separated directory of the file with the given
+// path relative to base, e.g., to be used in a package path. It is empty, if the
+// file is located directly in base or outside of it.
+func RelativeDir(base string, path string) string {
+	rel, ok := RelativePath(base, path)
+	if !ok {
+		return ""
+	}
+
+	dir := filepath.ToSlash(filepath.Dir(rel))
+	if dir == "." {
+		return ""
+	}
+
+	return dir
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// windows is true, if paths are separated by backslashes. Paths of the other
+// platform cannot be tested, since the functions rely on the ones of filepath.
+const windows = filepath.Separator == '\\'
+
+func TestAbsPath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		windows bool
+		path    string
+		want    string
+	}{
+		{"absolute", false, "/project/main.go", "/project/main.go"},
+		{"unclean", false, "/project/./cmd/../main.go", "/project/main.go"},
+		{"trailing separator", false, "/project/", "/project"},
+		{"relative", false, "main.go", filepath.Join(wd, "main.go")},
+		{"drive", true, `C:\project\main.go`, `C:\project\main.go`},
+		{"unclean drive", true, `C:\project\cmd\..\main.go`, `C:\project\main.go`},
+		{"slashes", true, `C:/project/main.go`, `C:\project\main.go`},
+		{"UNC", true, `\\server\share\main.go`, `\\server\share\main.go`},
+		{"long drive", true, `\\?\C:\project\main.go`, `C:\project\main.go`},
+		{"long UNC", true, `\\?\UNC\server\share\main.go`, `\\server\share\main.go`},
+		{"long UNC lower case", true, `\\?\unc\server\share\main.go`, `\\server\share\main.go`},
+		{"relative", true, "main.go", filepath.Join(wd, "main.go")},
+	}
+
+	for _, tt := range tests {
+		if tt.windows != windows {
+			continue
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AbsPath(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("AbsPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows bool
+		base    string
+		target  string
+		want    string
+		wantOk  bool
+	}{
+		{"file", false, "/project", "/project/main.go", "main.go", true},
+		{"nested", false, "/project", "/project/cmd/app/main.go", "cmd/app/main.go", true},
+		{"same", false, "/project", "/project", ".", true},
+		{"parent", false, "/project/cmd", "/project", "", false},
+		{"sibling", false, "/project", "/other/main.go", "", false},
+		{"common prefix", false, "/project", "/projects/main.go", "", false},
+		{"dots in name", false, "/project", "/project/..main.go", "..main.go", true},
+		{"drive", true, `C:\project`, `C:\project\cmd\main.go`, `cmd\main.go`, true},
+		{"case of drive", true, `c:\project`, `C:\project\main.go`, "main.go", true},
+		{"other drive", true, `C:\project`, `D:\project\main.go`, "", false},
+		{"UNC", true, `\\server\share\project`, `\\server\share\project\main.go`, "main.go", true},
+		{"other share", true, `\\server\share\project`, `\\server\other\project\main.go`, "", false},
+		{"UNC and drive", true, `\\server\share\project`, `C:\project\main.go`, "", false},
+	}
+
+	for _, tt := range tests {
+		if tt.windows != windows {
+			continue
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RelativePath(tt.base, tt.target)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("RelativePath(%q, %q) = %q, %v, want %q, %v", tt.base, tt.target, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRelativeDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows bool
+		base    string
+		path    string
+		want    string
+	}{
+		{"top level", false, "/project", "/project/main.go", ""},
+		{"package", false, "/project", "/project/cmd/app/main.go", "cmd/app"},
+		{"outside", false, "/project", "/other/pkg/main.go", ""},
+		{"top level", true, `C:\project`, `C:\project\main.go`, ""},
+		{"package", true, `C:\project`, `C:\project\cmd\app\main.go`, "cmd/app"},
+		{"outside", true, `C:\project`, `D:\project\pkg\main.go`, ""},
+		{"UNC", true, `\\server\share`, `\\server\share\pkg\main.go`, "pkg"},
+	}
+
+	for _, tt := range tests {
+		if tt.windows != windows {
+			continue
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RelativeDir(tt.base, tt.path); got != tt.want {
+				t.Errorf("RelativeDir(%q, %q) = %q, want %q", tt.base, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// The messages of a .proto file are translated by protoc into structs, whose
+// fields carry the name and number of the proto field in a struct tag, e.g.,
+// `protobuf:"bytes,1,opt,name=email,json=emailAddress,proto3"`. In files
+// generated by protoc, such a struct is marked by a "protoMessage" annotation.
+// Its fields are marked by a "protoField" annotation, whose members contain the
+// proto "name", the field "number", the "wire" type, the "cardinality" (opt,
+// req or rep) and the "json" name, if it differs. The field, which holds the
+// value of a oneof, is marked by a "protoOneof" annotation with its "name".
+
+// protoField is the information of a field, which is parsed from its struct
+// tag.
+type protoField struct {
+	name        string
+	number      int
+	wire        string
+	cardinality string
+	json        string
+}
+
+// isProtocGenerated checks, whether the current file was generated by protoc,
+// e.g., by protoc-gen-go or protoc-gen-gogo.
+func (this *GoLanguageFrontend) isProtocGenerated() bool {
+	if this.File == nil {
+		return false
+	}
+
+	for _, group := range this.File.Comments {
+		if group.Pos() >= this.File.Package {
+			break
+		}
+
+		for _, c := range group.List {
+			if generatedComment.MatchString(c.Text) && strings.Contains(c.Text, "protoc-gen-") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isProtoMessage checks, whether structType is a message, i.e., any of its
+// fields is a proto field or oneof.
+func isProtoMessage(structType *ast.StructType) bool {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		value, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		for _, kv := range parseStructTag(value) {
+			if kv[0] == "protobuf" || kv[0] == "protobuf_oneof" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// handleProtoField creates the "protoField" or "protoOneof" annotation of a
+// field of a message with the struct tag tag.
+func (this *GoLanguageFrontend) handleProtoField(fset *token.FileSet, tag *ast.BasicLit) *cpg.Annotation {
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return nil
+	}
+
+	for _, kv := range parseStructTag(value) {
+		switch kv[0] {
+		case "protobuf_oneof":
+			return this.newStringAnnotation(fset, "protoOneof", [][2]string{{"name", kv[1]}})
+		case "protobuf":
+			field, ok := parseProtoField(kv[1])
+			if !ok {
+				return nil
+			}
+
+			a := this.NewAnnotation(fset, nil, "protoField")
+
+			values := [][2]string{{"name", field.name}}
+			members := this.newStringMembers(fset, values)
+
+			number := this.NewLiteral(fset, nil, cpg.NewInteger(field.number), this.parseType("int"))
+			members = append(members, this.NewAnnotationMember(fset, nil, "number", (*cpg.Expression)(number)))
+
+			values = [][2]string{
+				{"wire", field.wire},
+				{"cardinality", field.cardinality},
+			}
+
+			if field.json != "" && field.json != field.name {
+				values = append(values, [2]string{"json", field.json})
+			}
+
+			a.SetMembers(append(members, this.newStringMembers(fset, values)...))
+
+			return a
+		}
+	}
+
+	return nil
+}
+
+// parseProtoField parses the value of the protobuf struct tag, e.g.,
+// bytes,1,opt,name=email,json=emailAddress,proto3. It starts with the wire
+// type, the number and the cardinality, followed by optional key/value pairs
+// and flags.
+func parseProtoField(value string) (field protoField, ok bool) {
+	parts := strings.Split(value, ",")
+	if len(parts) < 3 {
+		return field, false
+	}
+
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return field, false
+	}
+
+	field.wire = parts[0]
+	field.number = number
+	field.cardinality = parts[2]
+
+	for _, part := range parts[3:] {
+		if k, v, found := strings.Cut(part, "="); found {
+			switch k {
+			case "name":
+				field.name = v
+			case "json":
+				field.json = v
+			}
+		}
+	}
+
+	return field, field.name != ""
+}
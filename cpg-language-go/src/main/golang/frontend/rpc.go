@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// The methods of a gRPC service are implemented by a type, which implements
+// the service interface generated by protoc-gen-go-grpc, e.g., GreeterServer.
+// Such methods are the entry points of remote procedure calls and are marked by
+// an "rpc" annotation, whose members contain the full name of the "service",
+// e.g., helloworld.Greeter, the name of the "method" and the full "name" of
+// the RPC, e.g., /helloworld.Greeter/SayHello.
+
+// rpcService is a service interface generated by protoc-gen-go-grpc.
+type rpcService struct {
+	obj   *types.TypeName
+	iface *types.Interface
+
+	// the full name of the service, including its proto package
+	name string
+
+	// the full names of the RPCs, keyed by the name of their method
+	methods map[string]string
+}
+
+// handleRPCMethod adds the "rpc" annotation to the method f, if it implements
+// an RPC of a service in the current package or one of its imports.
+func (this *GoLanguageFrontend) handleRPCMethod(fset *token.FileSet, f *cpg.FunctionDeclaration, fn *types.Func) {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || !fn.Exported() {
+		return
+	}
+
+	named, _ := receiverType(recv.Type())
+	if named == nil || !isImplementable(named) {
+		return
+	}
+
+	if _, isInterface := named.Underlying().(*types.Interface); isInterface {
+		return
+	}
+
+	for _, service := range this.rpcServices() {
+		fullName, ok := service.methods[fn.Name()]
+		if !ok {
+			continue
+		}
+
+		// the generated stubs, which need to be embedded, are no entry points
+		if named.Obj().Name() == "Unimplemented"+service.obj.Name() && named.Obj().Pkg() == service.obj.Pkg() {
+			continue
+		}
+
+		// methods with a pointer receiver are only in the method set of the pointer type
+		if !types.Implements(named, service.iface) && !types.Implements(types.NewPointer(named), service.iface) {
+			continue
+		}
+
+		(*cpg.Node)(f).AddAnnotations([]*cpg.Annotation{this.newStringAnnotation(fset, "rpc", [][2]string{
+			{"service", service.name},
+			{"method", fn.Name()},
+			{"name", fullName},
+		})})
+	}
+}
+
+// rpcServices returns the services declared in the current package and its
+// imports, in the order of their package paths.
+func (this *GoLanguageFrontend) rpcServices() (services []rpcService) {
+	if this.Package == nil || this.Package.Types == nil {
+		return nil
+	}
+
+	pkgs := []*packages.Package{this.Package}
+
+	var paths []string
+	for path := range this.Package.Imports {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pkgs = append(pkgs, this.Package.Imports[path])
+	}
+
+	if this.RPCServices == nil {
+		this.RPCServices = NewRPCServiceCache(nil)
+	}
+
+	for _, p := range pkgs {
+		if p == nil || p.Types == nil {
+			continue
+		}
+
+		services = append(services, this.RPCServices.get(p)...)
+	}
+
+	return
+}
+
+// RPCServiceCache holds the gRPC services declared in the packages of a project.
+// Their names may be taken from the syntax of any file of their package, which
+// might already be released, once a file is translated. Therefore, they are
+// determined for all packages, before any file is translated.
+type RPCServiceCache struct {
+	services map[*types.Package][]rpcService
+}
+
+// NewRPCServiceCache returns a cache, which contains the services of pkgs and
+// all their imports.
+func NewRPCServiceCache(pkgs []*packages.Package) *RPCServiceCache {
+	c := &RPCServiceCache{services: map[*types.Package][]rpcService{}}
+
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Types != nil {
+			c.get(p)
+		}
+	})
+
+	return c
+}
+
+func (c *RPCServiceCache) get(p *packages.Package) []rpcService {
+	services, ok := c.services[p.Types]
+	if !ok {
+		services = rpcServicesOf(p)
+		c.services[p.Types] = services
+	}
+
+	return services
+}
+
+// rpcServicesOf returns the service interfaces generated in the package p. They
+// are recognized by their name, e.g., GreeterServer, and the accompanying
+// function, which registers an implementation, e.g., RegisterGreeterServer.
+func rpcServicesOf(p *packages.Package) (services []rpcService) {
+	scope := p.Types.Scope()
+
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !strings.HasSuffix(name, "Server") || !obj.Exported() {
+			continue
+		}
+
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok || iface.Empty() {
+			continue
+		}
+
+		if _, ok := scope.Lookup("Register" + name).(*types.Func); !ok {
+			continue
+		}
+
+		service := rpcService{
+			obj:     obj,
+			iface:   iface,
+			name:    rpcServiceName(p, name),
+			methods: map[string]string{},
+		}
+
+		for i := 0; i < iface.NumMethods(); i++ {
+			m := iface.Method(i)
+			if !m.Exported() {
+				continue
+			}
+
+			service.methods[m.Name()] = "/" + service.name + "/" + m.Name()
+		}
+
+		services = append(services, service)
+	}
+
+	return
+}
+
+// rpcServiceName returns the full name of the service, whose interface has the
+// given name. Since the proto package is not part of the Go declarations, it is
+// taken from the full method names, which newer versions of protoc-gen-go-grpc
+// declare as constants, or from the ServiceName of the service descriptor. If
+// neither is available, the name of the Go package is used instead.
+func rpcServiceName(p *packages.Package, name string) string {
+	service := strings.TrimSuffix(name, "Server")
+	scope := p.Types.Scope()
+
+	// e.g. const Greeter_SayHello_FullMethodName = "/helloworld.Greeter/SayHello"
+	for _, constName := range scope.Names() {
+		c, ok := scope.Lookup(constName).(*types.Const)
+		if !ok || !strings.HasPrefix(constName, service+"_") || !strings.HasSuffix(constName, "_FullMethodName") {
+			continue
+		}
+
+		if c.Val().Kind() != constant.String {
+			continue
+		}
+
+		fullName := constant.StringVal(c.Val())
+		if i := strings.LastIndex(fullName, "/"); strings.HasPrefix(fullName, "/") && i > 0 {
+			return fullName[1:i]
+		}
+	}
+
+	// e.g. grpc.ServiceDesc{ServiceName: "helloworld.Greeter", HandlerType: (*GreeterServer)(nil)}
+	for _, file := range p.Syntax {
+		var found string
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.CompositeLit); ok && found == "" {
+				found, _ = serviceDescName(lit, name)
+			}
+
+			return found == ""
+		})
+
+		if found != "" {
+			return found
+		}
+	}
+
+	return p.Types.Name() + "." + service
+}
+
+// serviceDescName returns the ServiceName of the service descriptor lit, if
+// its HandlerType is the service interface with the given name.
+func serviceDescName(lit *ast.CompositeLit, name string) (serviceName string, ok bool) {
+	var handles bool
+
+	for _, elt := range lit.Elts {
+		kv, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		key, isIdent := kv.Key.(*ast.Ident)
+		if !isIdent {
+			continue
+		}
+
+		switch key.Name {
+		case "ServiceName":
+			if basic, isBasic := kv.Value.(*ast.BasicLit); isBasic && basic.Kind == token.STRING {
+				if unquoted, err := strconv.Unquote(basic.Value); err == nil {
+					serviceName, ok = unquoted, true
+				}
+			}
+		case "HandlerType":
+			// (*GreeterServer)(nil)
+			if call, isCall := unparen(kv.Value).(*ast.CallExpr); isCall {
+				if star, isStar := unparen(call.Fun).(*ast.StarExpr); isStar {
+					ident, isIdent := unparen(star.X).(*ast.Ident)
+					handles = isIdent && ident.Name == name
+				}
+			}
+		}
+	}
+
+	if !ok || !handles {
+		return "", false
+	}
+
+	return serviceName, true
+}
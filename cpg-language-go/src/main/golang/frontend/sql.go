@@ -0,0 +1,475 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+// SQL statements are usually passed as strings to a database driver, e.g.,
+// db.Query("SELECT name FROM users WHERE id = ?", id). Such calls are marked by
+// a "sql" annotation, whose members contain the "query", the "kind" of the
+// statement, e.g., SELECT, and the referenced "table" and "column" names, one
+// member for each. The query can be a constant or a concatenation of strings.
+// In the latter case, its non-constant parts are replaced by a ? and the query
+// is marked as "dynamic".
+
+// sqlCalls are the functions of the supported database libraries, which
+// execute or prepare a statement, together with the index of the argument,
+// which holds the statement.
+var sqlCalls = newSQLCalls()
+
+func newSQLCalls() map[libraryFunc]int {
+	var calls = map[libraryFunc]int{}
+
+	add := func(pkg string, recvs []string, names []string, arg int) {
+		for _, recv := range recvs {
+			for _, name := range names {
+				calls[libraryFunc{pkg, recv, name}] = arg
+			}
+		}
+	}
+
+	// database/sql, whose methods are also promoted to the types of sqlx
+	sql := []string{"DB", "Tx", "Conn"}
+	add("database/sql", sql, []string{"Query", "QueryRow", "Exec", "Prepare"}, 0)
+	add("database/sql", sql, []string{"QueryContext", "QueryRowContext", "ExecContext", "PrepareContext"}, 1)
+
+	// github.com/jmoiron/sqlx
+	sqlx := []string{"DB", "Tx", "Conn"}
+	add("github.com/jmoiron/sqlx", sqlx, []string{"Queryx", "QueryRowx", "MustExec", "NamedExec", "NamedQuery", "Preparex", "PrepareNamed"}, 0)
+	add("github.com/jmoiron/sqlx", sqlx, []string{"QueryxContext", "QueryRowxContext", "MustExecContext", "NamedExecContext", "PreparexContext", "PrepareNamedContext"}, 1)
+	add("github.com/jmoiron/sqlx", sqlx, []string{"Get", "Select"}, 1)
+	add("github.com/jmoiron/sqlx", sqlx, []string{"GetContext", "SelectContext"}, 2)
+
+	// github.com/jackc/pgx, whose methods always take a context first
+	pgx := []string{"Conn", "Tx", "dbTx"}
+	add("github.com/jackc/pgx", pgx, []string{"Query", "QueryRow", "Exec"}, 1)
+
+	// Prepare(ctx, name, sql) names the statement first
+	add("github.com/jackc/pgx", pgx, []string{"Prepare"}, 2)
+	add("github.com/jackc/pgx/pgxpool", []string{"Pool", "Conn", "Tx"}, []string{"Query", "QueryRow", "Exec"}, 1)
+
+	return calls
+}
+
+// handleSQLCall adds the "sql" annotation to the call c of fn, if it executes
+// or prepares a statement, which is (partly) known.
+func (this *GoLanguageFrontend) handleSQLCall(fset *token.FileSet, c *cpg.CallExpression, callExpr *ast.CallExpr, fn *types.Func) {
+	arg, ok := sqlCalls[libraryFuncOf(fn)]
+	if !ok || arg >= len(callExpr.Args) {
+		return
+	}
+
+	query, dynamic, ok := this.sqlString(callExpr.Args[arg])
+	if !ok {
+		return
+	}
+
+	stmt := parseSQL(query)
+
+	values := [][2]string{{"query", query}}
+	if stmt.kind != "" {
+		values = append(values, [2]string{"kind", stmt.kind})
+	}
+
+	for _, table := range stmt.tables {
+		values = append(values, [2]string{"table", table})
+	}
+
+	for _, column := range stmt.columns {
+		values = append(values, [2]string{"column", column})
+	}
+
+	a := this.NewAnnotation(fset, nil, "sql")
+	members := this.newStringMembers(fset, values)
+
+	if dynamic {
+		lit := this.NewLiteral(fset, nil, cpg.NewBoolean(true), this.parseType("bool"))
+		members = append(members, this.NewAnnotationMember(fset, nil, "dynamic", (*cpg.Expression)(lit)))
+	}
+
+	a.SetMembers(members)
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{a})
+}
+
+// sqlString returns the statement held by expr, which is either a constant or
+// a concatenation of strings. Each non-constant part of a concatenation is
+// replaced by a ?, in which case the statement is dynamic. If no part of it is
+// constant, the statement is unknown.
+func (this *GoLanguageFrontend) sqlString(expr ast.Expr) (query string, dynamic bool, ok bool) {
+	expr = unparen(expr)
+
+	if s, isConstant := this.stringConstant(expr); isConstant {
+		return s, false, true
+	}
+
+	if binary, isBinary := expr.(*ast.BinaryExpr); isBinary && binary.Op == token.ADD {
+		left, leftDynamic, leftOk := this.sqlString(binary.X)
+		right, rightDynamic, rightOk := this.sqlString(binary.Y)
+
+		if !leftOk && !rightOk {
+			return "", false, false
+		}
+
+		if !leftOk {
+			left, leftDynamic = "?", true
+		}
+
+		if !rightOk {
+			right, rightDynamic = "?", true
+		}
+
+		return left + right, leftDynamic || rightDynamic, true
+	}
+
+	return "", false, false
+}
+
+// sqlStatement holds the information, which is (lightly) parsed from a SQL
+// statement.
+type sqlStatement struct {
+	kind    string
+	tables  []string
+	columns []string
+}
+
+// sqlToken is a token of a SQL statement. Keywords and names are identifiers,
+// whose quotes are removed.
+type sqlToken struct {
+	text  string
+	ident bool
+}
+
+// sqlKeywords are the keywords, which end a list of tables or columns and thus
+// cannot be names or aliases.
+var sqlKeywords = map[string]bool{}
+
+func init() {
+	for _, keyword := range strings.Fields(`SELECT DISTINCT FROM WHERE JOIN INNER LEFT RIGHT FULL OUTER
+		CROSS NATURAL ON USING GROUP BY ORDER HAVING LIMIT OFFSET UNION ALL EXCEPT INTERSECT INSERT INTO
+		VALUES UPDATE SET DELETE CREATE ALTER DROP TABLE IF NOT EXISTS ONLY AS AND OR IN IS NULL LIKE
+		ILIKE BETWEEN RETURNING WITH CONFLICT DO NOTHING DEFAULT ASC DESC FOR LATERAL TRUNCATE REPLACE
+		CASE WHEN THEN ELSE END`) {
+		sqlKeywords[keyword] = true
+	}
+}
+
+// parseSQL extracts the kind, tables and columns of the statement query. It
+// does not validate the statement, but only looks at the common clauses, i.e.,
+// the tables following FROM, JOIN, INTO, UPDATE and TABLE, the columns of a
+// SELECT list, an INSERT column list, an UPDATE SET list or a CREATE TABLE
+// definition, as well as the columns, which are compared in a condition.
+func parseSQL(query string) (stmt sqlStatement) {
+	tokens := tokenizeSQL(query)
+
+	text := func(i int) string {
+		if i < len(tokens) {
+			return tokens[i].text
+		}
+
+		return ""
+	}
+
+	upper := func(i int) string {
+		if i < len(tokens) && tokens[i].ident {
+			return strings.ToUpper(tokens[i].text)
+		}
+
+		return ""
+	}
+
+	isName := func(i int) bool {
+		return i < len(tokens) && tokens[i].ident && !sqlKeywords[strings.ToUpper(tokens[i].text)]
+	}
+
+	var isComparison func(i int) bool
+	isComparison = func(i int) bool {
+		switch upper(i) {
+		case "LIKE", "ILIKE", "IN", "IS", "BETWEEN":
+			return true
+		case "NOT":
+			return isComparison(i + 1)
+		}
+
+		switch text(i) {
+		case "=", "<>", "!=", "<", ">", "<=", ">=":
+			return true
+		}
+
+		return false
+	}
+
+	var seenTables = map[string]bool{}
+	var seenColumns = map[string]bool{}
+
+	// the names of common table expressions, e.g. WITH recent AS (...), are
+	// no tables
+	for i := range tokens {
+		if isName(i) && upper(i+1) == "AS" && text(i+2) == "(" {
+			seenTables[text(i)] = true
+		}
+	}
+
+	addTable := func(name string) {
+		if !seenTables[name] {
+			seenTables[name] = true
+			stmt.tables = append(stmt.tables, name)
+		}
+	}
+
+	addColumn := func(name string) {
+		// only the column of a qualified name, e.g. u.name, is of interest
+		name = name[strings.LastIndex(name, ".")+1:]
+
+		if !seenColumns[name] {
+			seenColumns[name] = true
+			stmt.columns = append(stmt.columns, name)
+		}
+	}
+
+	// addColumns adds the first name of each item of the list, which starts
+	// at start, e.g. the SELECT list. The list ends at a keyword or, if it is
+	// in parentheses, at the closing one.
+	addColumns := func(start int, parenthesized bool) {
+		depth := 0
+		first := true
+
+		for j := start; j < len(tokens); j++ {
+			switch text(j) {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+
+			if depth < 0 || (!parenthesized && depth == 0 && sqlKeywords[upper(j)] && upper(j) != "AS") {
+				return
+			}
+
+			if depth == 0 && text(j) == "," {
+				first = true
+				continue
+			}
+
+			// a function call, e.g. COUNT(*), is not a column
+			if first && (isName(j) || text(j) == "*") && text(j+1) != "(" && !sqlConstraints[upper(j)] {
+				addColumn(text(j))
+			}
+
+			first = false
+		}
+	}
+
+	// the kind of a statement with a common table expression is the one of
+	// its main statement
+	depth := 0
+	for i := range tokens {
+		switch text(i) {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+
+		keyword := upper(i)
+		if keyword == "" || keyword == "WITH" {
+			continue
+		}
+
+		if i == 0 || (depth == 0 && (keyword == "SELECT" || keyword == "INSERT" || keyword == "UPDATE" || keyword == "DELETE")) {
+			stmt.kind = keyword
+			break
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch upper(i) {
+		case "FROM", "JOIN", "INTO", "UPDATE", "TABLE":
+			j := i + 1
+			for upper(j) == "IF" || upper(j) == "NOT" || upper(j) == "EXISTS" || upper(j) == "ONLY" {
+				j++
+			}
+
+			// a list of tables, e.g. FROM users u, orders o
+			for isName(j) {
+				addTable(text(j))
+				j++
+
+				if upper(j) == "AS" {
+					j++
+				}
+
+				if isName(j) {
+					j++
+				}
+
+				if upper(i) != "FROM" || text(j) != "," {
+					break
+				}
+
+				j++
+			}
+
+			// e.g. INSERT INTO users (name, email) or CREATE TABLE users (id INT)
+			if (upper(i) == "INTO" || upper(i) == "TABLE") && text(j) == "(" {
+				addColumns(j+1, true)
+			}
+		case "SELECT":
+			j := i + 1
+			if upper(j) == "DISTINCT" {
+				j++
+			}
+
+			addColumns(j, false)
+		case "SET":
+			// e.g. UPDATE users SET name = ?, email = ?
+			addColumns(i+1, false)
+		}
+
+		// a column in a condition, e.g. WHERE id = ?
+		if isName(i) && isComparison(i+1) {
+			addColumn(text(i))
+		}
+	}
+
+	return
+}
+
+// sqlConstraints are the keywords, which start a constraint within the
+// definition of a table, rather than a column.
+var sqlConstraints = map[string]bool{
+	"PRIMARY":    true,
+	"FOREIGN":    true,
+	"UNIQUE":     true,
+	"CHECK":      true,
+	"CONSTRAINT": true,
+	"KEY":        true,
+	"INDEX":      true,
+}
+
+// tokenizeSQL splits query into names, operators and other symbols. Its
+// literals, placeholders and comments are skipped.
+func tokenizeSQL(query string) (tokens []sqlToken) {
+	runes := []rune(query)
+
+	isIdentRune := func(r rune) bool {
+		return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	isNameStart := func(i int) bool {
+		return i < len(runes) && (isIdentRune(runes[i]) || runes[i] == '"' || runes[i] == '`')
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			for i += 2; i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/'); i++ {
+			}
+
+			i += 2
+		case r == '\'':
+			// a string literal, in which a quote is escaped by another one
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+
+					break
+				}
+			}
+
+			i++
+		case r == '?' || ((r == ':' || r == '@' || r == '$') && i+1 < len(runes) && isIdentRune(runes[i+1])):
+			// a placeholder, e.g. ?, $1, :name or @name
+			for i++; i < len(runes) && isIdentRune(runes[i]); i++ {
+			}
+		case unicode.IsDigit(r):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+		case isNameStart(i):
+			var name strings.Builder
+
+			for {
+				// each part of a name can be quoted, e.g. "public"."users"
+				if runes[i] == '"' || runes[i] == '`' {
+					quote := runes[i]
+					for i++; i < len(runes) && runes[i] != quote; i++ {
+						name.WriteRune(runes[i])
+					}
+
+					i++
+				} else {
+					for ; i < len(runes) && isIdentRune(runes[i]); i++ {
+						name.WriteRune(runes[i])
+					}
+				}
+
+				if i+1 < len(runes) && runes[i] == '.' && runes[i+1] == '*' {
+					name.WriteString(".*")
+					i += 2
+				} else if i < len(runes) && runes[i] == '.' && isNameStart(i+1) {
+					name.WriteRune('.')
+					i++
+					continue
+				}
+
+				break
+			}
+
+			tokens = append(tokens, sqlToken{name.String(), true})
+		case strings.ContainsRune("<>!=", r):
+			start := i
+			for i < len(runes) && strings.ContainsRune("<>!=", runes[i]) {
+				i++
+			}
+
+			tokens = append(tokens, sqlToken{string(runes[start:i]), false})
+		default:
+			tokens = append(tokens, sqlToken{string(r), false})
+			i++
+		}
+	}
+
+	return
+}
@@ -70,23 +70,35 @@ func (frontend *GoLanguageFrontend) NewDefaultStatement(fset *token.FileSet, ast
 	return (*cpg.DefaultStatement)(frontend.NewStatement("DefaultStatement", fset, astNode))
 }
 
-func (frontend *GoLanguageFrontend) NewStatement(typ string, fset *token.FileSet, astNode ast.Node, args ...any) *jnigi.ObjectRef {
-	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.StatementsPackage, typ))
+func (frontend *GoLanguageFrontend) NewLabelStatement(fset *token.FileSet, astNode ast.Node) *cpg.LabelStatement {
+	return (*cpg.LabelStatement)(frontend.NewStatement("LabelStatement", fset, astNode))
+}
+
+func (frontend *GoLanguageFrontend) NewGotoStatement(fset *token.FileSet, astNode ast.Node) *cpg.GotoStatement {
+	return (*cpg.GotoStatement)(frontend.NewStatement("GotoStatement", fset, astNode))
+}
+
+func (frontend *GoLanguageFrontend) NewBreakStatement(fset *token.FileSet, astNode ast.Node) *cpg.BreakStatement {
+	return (*cpg.BreakStatement)(frontend.NewStatement("BreakStatement", fset, astNode))
+}
 
-	// Prepend the frontend as the receiver
-	args = append([]any{frontend.Cast(cpg.GraphPackage + "/MetadataProvider")}, args...)
+func (frontend *GoLanguageFrontend) NewContinueStatement(fset *token.FileSet, astNode ast.Node) *cpg.ContinueStatement {
+	return (*cpg.ContinueStatement)(frontend.NewStatement("ContinueStatement", fset, astNode))
+}
+
+func (frontend *GoLanguageFrontend) NewEmptyStatement(fset *token.FileSet, astNode ast.Node) *cpg.EmptyStatement {
+	return (*cpg.EmptyStatement)(frontend.NewStatement("EmptyStatement", fset, astNode))
+}
 
-	err := env.CallStaticMethod(
-		cpg.GraphPackage+"/StatementBuilderKt",
-		fmt.Sprintf("new%s", typ), node,
-		args...,
-	)
-	if err != nil {
-		panic(err)
-	}
+func (frontend *GoLanguageFrontend) NewTryStatement(fset *token.FileSet, astNode ast.Node) *cpg.TryStatement {
+	return (*cpg.TryStatement)(frontend.NewStatement("TryStatement", fset, astNode))
+}
+
+func (frontend *GoLanguageFrontend) NewStatement(typ string, fset *token.FileSet, astNode ast.Node, args ...any) *jnigi.ObjectRef {
+	var node = jnigi.NewObjectRef(fmt.Sprintf("%s/%s", cpg.StatementsPackage, typ))
 
-	updateCode(fset, (*cpg.Node)(node), astNode)
-	updateLocation(fset, (*cpg.Node)(node), astNode)
+	frontend.setMetadata(fset, astNode)
+	frontend.callBuilder("StatementBuilderKt", typ, node, args...)
 
 	return node
 }
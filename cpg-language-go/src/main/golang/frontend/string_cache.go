@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+
+	"tekao.net/jnigi"
+)
+
+// StringCache memoizes the strings of the JVM, which are passed to the builders
+// of the nodes. These are mostly names, which occur over and over again, and
+// each of them would otherwise require a JNI call to create a new string. Like
+// the TypeCache, the cache is shared between several calls from the JVM and
+// therefore only holds global references.
+type StringCache struct {
+	strings map[string]*jnigi.ObjectRef
+}
+
+func NewStringCache() *StringCache {
+	return &StringCache{
+		strings: map[string]*jnigi.ObjectRef{},
+	}
+}
+
+// get returns the string of the JVM with the given content, which is only
+// created once and interned, so that it is shared with the equal strings of the
+// JVM as well. Without a cache, a new local reference is returned, which is not
+// cached and needs to be deleted by the caller.
+func (c *StringCache) get(s string) (str *jnigi.ObjectRef, cached bool) {
+	if c == nil {
+		return cpg.NewString(s), false
+	}
+
+	if str, ok := c.strings[s]; ok {
+		return str, true
+	}
+
+	local := cpg.NewString(s)
+	defer env.DeleteLocalRef(local)
+
+	interned := jnigi.NewObjectRef("java/lang/String")
+	if err := local.CallMethod(env, "intern", interned); err != nil {
+		panic(err)
+	}
+	defer env.DeleteLocalRef(interned)
+
+	str = env.NewGlobalRef(interned)
+
+	c.strings[s] = str
+
+	return str, true
+}
+
+// Release deletes all global references held by the cache.
+func (c *StringCache) Release() {
+	for _, str := range c.strings {
+		env.DeleteGlobalRef(str)
+	}
+
+	c.strings = map[string]*jnigi.ObjectRef{}
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/ast"
+	"go/types"
+
+	"tekao.net/jnigi"
+)
+
+// SymbolTable holds the declarations of package-level symbols, i.e., functions,
+// methods and variables, which are declared by HandleFileSymbols before any
+// function body is handled. Since the table is shared between several calls
+// from the JVM, it only holds global references.
+type SymbolTable struct {
+	functions map[*ast.FuncDecl]declaredFunction
+	variables map[*ast.Ident]*cpg.VariableDeclaration
+	objects   map[types.Object]*cpg.Declaration
+
+	// methods of named types, indexed by their name, which are the candidates
+	// for calls through an interface
+	methods map[string][]declaredFunc
+
+	// package-level functions, indexed by their libraryFunc, e.g., to find the
+	// declaration of a handler, which is passed by its name
+	packageFunctions map[libraryFunc][]declaredFunc
+}
+
+type declaredFunction struct {
+	f      *cpg.FunctionDeclaration
+	record *cpg.RecordDeclaration
+}
+
+type declaredFunc struct {
+	fn *types.Func
+	f  *cpg.FunctionDeclaration
+}
+
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{
+		functions: map[*ast.FuncDecl]declaredFunction{},
+		variables: map[*ast.Ident]*cpg.VariableDeclaration{},
+		objects:   map[types.Object]*cpg.Declaration{},
+		methods:   map[string][]declaredFunc{},
+
+		packageFunctions: map[libraryFunc][]declaredFunc{},
+	}
+}
+
+// len returns the number of declared functions and variables.
+func (s *SymbolTable) len() int {
+	return len(s.functions) + len(s.variables)
+}
+
+func (s *SymbolTable) addFunction(funcDecl *ast.FuncDecl, f *cpg.FunctionDeclaration, record *cpg.RecordDeclaration) {
+	var declared = declaredFunction{
+		f: (*cpg.FunctionDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(f))),
+	}
+
+	if record != nil && !record.IsNil() {
+		declared.record = (*cpg.RecordDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(record)))
+	}
+
+	s.functions[funcDecl] = declared
+}
+
+// function returns the declaration of funcDecl, if it was already declared.
+func (s *SymbolTable) function(funcDecl *ast.FuncDecl) (f *cpg.FunctionDeclaration, record *cpg.RecordDeclaration, ok bool) {
+	if s == nil {
+		return nil, nil, false
+	}
+
+	declared, ok := s.functions[funcDecl]
+
+	return declared.f, declared.record, ok
+}
+
+func (s *SymbolTable) addPackageFunction(fn *types.Func, f *cpg.FunctionDeclaration) {
+	lf := libraryFuncOf(fn)
+
+	s.packageFunctions[lf] = append(s.packageFunctions[lf], declaredFunc{
+		fn: fn,
+		f:  (*cpg.FunctionDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(f))),
+	})
+}
+
+// functionOf returns the declaration of the package-level function fn, if it
+// was already declared. The function might be an object of another variant of
+// its package, e.g., the test variant, so it is compared by its position.
+func (s *SymbolTable) functionOf(fn *types.Func) (f *cpg.FunctionDeclaration, ok bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	for _, declared := range s.packageFunctions[libraryFuncOf(fn)] {
+		if declared.fn.Pos() == fn.Pos() {
+			return declared.f, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *SymbolTable) addVariable(ident *ast.Ident, d *cpg.VariableDeclaration) {
+	s.variables[ident] = (*cpg.VariableDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(d)))
+}
+
+// variable returns the declaration of the variable defined by ident, if it was
+// already declared.
+func (s *SymbolTable) variable(ident *ast.Ident) *cpg.VariableDeclaration {
+	if s == nil {
+		return nil
+	}
+
+	return s.variables[ident]
+}
+
+func (s *SymbolTable) addObject(obj types.Object, d *cpg.Declaration) {
+	s.objects[obj] = (*cpg.Declaration)(env.NewGlobalRef((*jnigi.ObjectRef)(d)))
+}
+
+// object returns the declaration of the type checker object obj, if it was
+// already declared.
+func (s *SymbolTable) object(obj types.Object) (d *cpg.Declaration, ok bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	d, ok = s.objects[obj]
+
+	return
+}
+
+func (s *SymbolTable) addMethod(fn *types.Func, f *cpg.FunctionDeclaration) {
+	s.methods[fn.Name()] = append(s.methods[fn.Name()], declaredFunc{
+		fn: fn,
+		f:  (*cpg.FunctionDeclaration)(env.NewGlobalRef((*jnigi.ObjectRef)(f))),
+	})
+}
+
+// method returns the declaration of the method fn, if it was already declared.
+func (s *SymbolTable) method(fn *types.Func) (f *cpg.FunctionDeclaration, ok bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	for _, declared := range s.methods[fn.Name()] {
+		if declared.fn == fn {
+			return declared.f, true
+		}
+	}
+
+	return nil, false
+}
+
+// implementations returns the declarations of the methods with the given name,
+// whose receiver type implements iface. These are the possible targets of a
+// call of the method through the interface.
+func (s *SymbolTable) implementations(iface *types.Interface, name string) (methods []*cpg.FunctionDeclaration) {
+	if s == nil {
+		return nil
+	}
+
+	for _, declared := range s.methods[name] {
+		recv := declared.fn.Type().(*types.Signature).Recv()
+		if recv == nil {
+			continue
+		}
+
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+
+		if !isImplementable(t) {
+			continue
+		}
+
+		// methods with a pointer receiver only belong to the method set of the
+		// pointer type
+		if types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface) {
+			methods = append(methods, declared.f)
+		}
+	}
+
+	return
+}
+
+// Release deletes all global references held by the symbol table.
+func (s *SymbolTable) Release() {
+	for _, declared := range s.functions {
+		env.DeleteGlobalRef((*jnigi.ObjectRef)(declared.f))
+
+		if declared.record != nil {
+			env.DeleteGlobalRef((*jnigi.ObjectRef)(declared.record))
+		}
+	}
+
+	for _, d := range s.variables {
+		env.DeleteGlobalRef((*jnigi.ObjectRef)(d))
+	}
+
+	for _, d := range s.objects {
+		env.DeleteGlobalRef((*jnigi.ObjectRef)(d))
+	}
+
+	for _, declared := range s.methods {
+		for _, m := range declared {
+			env.DeleteGlobalRef((*jnigi.ObjectRef)(m.f))
+		}
+	}
+
+	for _, declared := range s.packageFunctions {
+		for _, f := range declared {
+			env.DeleteGlobalRef((*jnigi.ObjectRef)(f.f))
+		}
+	}
+
+	s.functions = map[*ast.FuncDecl]declaredFunction{}
+	s.variables = map[*ast.Ident]*cpg.VariableDeclaration{}
+	s.objects = map[types.Object]*cpg.Declaration{}
+	s.methods = map[string][]declaredFunc{}
+	s.packageFunctions = map[libraryFunc][]declaredFunc{}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+	"go/token"
+	"go/types"
+)
+
+// The synchronization primitives of the sync package establish a happens-before
+// relation between goroutines, e.g., an Unlock of a mutex happens before any
+// following Lock of it returns. Calls of their methods are marked by a "sync"
+// annotation, whose members contain the primitive, the method and its kind:
+//   - "acquire" waits for a preceding "release" of the same primitive, i.e.,
+//     Lock and RLock of a (RW)Mutex or Wait of a WaitGroup,
+//   - "release" is the counterpart, i.e., Unlock and RUnlock of a (RW)Mutex or
+//     Done of a WaitGroup,
+//   - "add" increments the counter of a WaitGroup, which needs to happen before
+//     the corresponding Wait,
+//   - "once" is Do of a Once, where the first call of the function happens
+//     before any call of Do returns.
+//
+// The primitive itself is the base of the call.
+
+// syncOperations maps the methods of the primitives to their kind.
+var syncOperations = map[string]map[string]string{
+	"Mutex": {
+		"Lock":    "acquire",
+		"TryLock": "acquire",
+		"Unlock":  "release",
+	},
+	"RWMutex": {
+		"Lock":     "acquire",
+		"TryLock":  "acquire",
+		"Unlock":   "release",
+		"RLock":    "acquire",
+		"TryRLock": "acquire",
+		"RUnlock":  "release",
+	},
+	"WaitGroup": {
+		"Add":  "add",
+		"Done": "release",
+		"Wait": "acquire",
+	},
+	"Once": {
+		"Do": "once",
+	},
+}
+
+// syncOperation returns the primitive of the sync package and the kind of the
+// operation, if fn is one of the methods of syncOperations.
+func syncOperation(fn *types.Func) (primitive string, kind string, ok bool) {
+	if fn.Pkg() == nil || fn.Pkg().Path() != "sync" {
+		return "", "", false
+	}
+
+	sig, isSig := fn.Type().(*types.Signature)
+	if !isSig || sig.Recv() == nil {
+		return "", "", false
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, isPtr := recv.(*types.Pointer); isPtr {
+		recv = ptr.Elem()
+	}
+
+	named, isNamed := recv.(*types.Named)
+	if !isNamed {
+		return "", "", false
+	}
+
+	primitive = named.Obj().Name()
+	kind, ok = syncOperations[primitive][fn.Name()]
+
+	return
+}
+
+// handleSyncCall adds the "sync" annotation to the call c of fn, if it is an
+// operation of a synchronization primitive. Since the method is taken from the
+// type checker, this includes calls of promoted methods, e.g., of a mutex
+// embedded in a struct.
+func (this *GoLanguageFrontend) handleSyncCall(fset *token.FileSet, c *cpg.CallExpression, fn *types.Func) {
+	primitive, kind, ok := syncOperation(fn)
+	if !ok {
+		return
+	}
+
+	(*cpg.Node)(c).AddAnnotations([]*cpg.Annotation{
+		this.newStringAnnotation(fset, "sync", [][2]string{
+			{"primitive", primitive},
+			{"method", fn.Name()},
+			{"kind", kind},
+		}),
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package frontend
+
+import (
+	"cpg"
+
+	"tekao.net/jnigi"
+)
+
+// TypeCache memoizes the types created by the TypeParser, since the same type
+// names occur over and over again and each of them would otherwise require a
+// JNI call. All files of a project are translated by the same language, so the
+// name of a type suffices as key. Like the SymbolTable, the cache is shared
+// between several calls from the JVM and therefore only holds global references.
+type TypeCache struct {
+	types map[string]*cpg.Type
+}
+
+func NewTypeCache() *TypeCache {
+	return &TypeCache{
+		types: map[string]*cpg.Type{},
+	}
+}
+
+// get returns the cached type with the given name.
+func (c *TypeCache) get(name string) (t *cpg.Type, ok bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	t, ok = c.types[name]
+
+	return
+}
+
+func (c *TypeCache) add(name string, t *cpg.Type) {
+	if c == nil {
+		return
+	}
+
+	c.types[name] = (*cpg.Type)(env.NewGlobalRef((*jnigi.ObjectRef)(t)))
+}
+
+// Release deletes all global references held by the cache.
+func (c *TypeCache) Release() {
+	for _, t := range c.types {
+		env.DeleteGlobalRef((*jnigi.ObjectRef)(t))
+	}
+
+	c.types = map[string]*cpg.Type{}
+}
+
+// parseType returns the type with the given name, as created by the TypeParser
+// for the language of the frontend. The type is shared by all its occurrences,
+// so it must be duplicated before it is modified.
+func (this *GoLanguageFrontend) parseType(name string) *cpg.Type {
+	if t, ok := this.Types.get(name); ok {
+		return t
+	}
+
+	lang, err := this.GetLanguage()
+	if err != nil {
+		panic(err)
+	}
+
+	t := cpg.TypeParser_createFrom(name, lang)
+	this.Types.add(name, t)
+
+	return t
+}
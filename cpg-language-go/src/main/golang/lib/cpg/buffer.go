@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"errors"
+	"unsafe"
+
+	"tekao.net/jnigi"
+)
+
+//#include <jni.h>
+//
+//static void *directBufferAddress(JNIEnv *env, jobject buffer) {
+//	return (*env)->GetDirectBufferAddress(env, buffer);
+//}
+//
+//static jlong directBufferCapacity(JNIEnv *env, jobject buffer) {
+//	return (*env)->GetDirectBufferCapacity(env, buffer);
+//}
+import "C"
+
+// directBuffer returns the memory of a direct ByteBuffer of the JVM, so that it
+// can be written without any further JNI call. The memory is not moved by the
+// JVM, and it stays valid, as long as the buffer is referenced there.
+func directBuffer(envPointer *C.JNIEnv, buffer *jnigi.ObjectRef) []byte {
+	address := C.directBufferAddress(envPointer, C.jobject(buffer.JObject()))
+	capacity := C.directBufferCapacity(envPointer, C.jobject(buffer.JObject()))
+
+	if address == nil || capacity < 0 {
+		panic(errors.New("not a direct buffer"))
+	}
+
+	return unsafe.Slice((*byte)(address), int(capacity))
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+//#include <jni.h>
+import "C"
+
+// cancellation holds the contexts of the running translations, keyed by the
+// top level path of their project, which are cancelled by the JVM. It has its
+// own mutex, since dataMutex is held by the translation, while it is cancelled.
+var cancellation struct {
+	sync.Mutex
+
+	contexts map[string]context.Context
+	cancels  map[string]context.CancelFunc
+}
+
+// translationContext returns the context of the running translation of the
+// project at topLevel, which can be cancelled by the JVM. It is created by the
+// first file of a translation and shared by all its following files, until the
+// state of the project is reset, so that a cancellation also aborts the files,
+// which are not yet translated.
+func translationContext(topLevel string) context.Context {
+	cancellation.Lock()
+	defer cancellation.Unlock()
+
+	if ctx, ok := cancellation.contexts[topLevel]; ok {
+		return ctx
+	}
+
+	if cancellation.contexts == nil {
+		cancellation.contexts = map[string]context.Context{}
+		cancellation.cancels = map[string]context.CancelFunc{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancellation.contexts[topLevel] = ctx
+	cancellation.cancels[topLevel] = cancel
+
+	return ctx
+}
+
+// finishTranslation discards the context of the translation of the project at
+// topLevel, so that a cancellation, which is requested while no translation is
+// running, does not affect the following ones. It is called, once the state of
+// the project is reset.
+func finishTranslation(topLevel string) {
+	cancellation.Lock()
+	defer cancellation.Unlock()
+
+	if cancel, ok := cancellation.cancels[topLevel]; ok {
+		cancel()
+	}
+
+	delete(cancellation.contexts, topLevel)
+	delete(cancellation.cancels, topLevel)
+}
+
+// checkCancelled returns an error, if the translation was cancelled.
+func checkCancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("translation was cancelled: %w", err)
+	}
+
+	return nil
+}
+
+//export Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_cancel
+func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_cancel(envPointer *C.JNIEnv, clazz C.jclass) {
+	cancellation.Lock()
+	defer cancellation.Unlock()
+
+	// the translations check their context between their steps, e.g., after
+	// each file, and abort with an error. There is nothing to cancel, if no
+	// translation is running.
+	for _, cancel := range cancellation.cancels {
+		cancel()
+	}
+}
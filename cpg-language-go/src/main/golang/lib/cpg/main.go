@@ -26,17 +26,24 @@
 package main
 
 import (
+	"bytes"
 	"cpg"
 	"cpg/frontend"
+	"encoding/json"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"log"
 	"unsafe"
 
 	"golang.org/x/tools/go/packages"
@@ -44,6 +51,14 @@ import (
 )
 
 //#include <jni.h>
+//#include <stdlib.h>
+//
+//static void throwTranslationException(JNIEnv *env, const char *msg) {
+//	jclass cls = (*env)->FindClass(env, "de/fraunhofer/aisec/cpg/frontends/TranslationException");
+//	if (cls != NULL) {
+//		(*env)->ThrowNew(env, cls, msg);
+//	}
+//}
 import "C"
 
 type PackageFile struct {
@@ -52,19 +67,160 @@ type PackageFile struct {
 }
 
 type GlobalData struct {
+	// rootPath is the directory, in which the packages of the project were
+	// discovered
+	rootPath string
+
 	pkgs    []*packages.Package
 	fileMap map[string]PackageFile
 	fset    *token.FileSet
+	symbols *frontend.SymbolTable
+	types   *frontend.TypeCache
+	strings *frontend.StringCache
+	rpc     *frontend.RPCServiceCache
+	metrics *frontend.Metrics
+
+	// files is the number of files of the project, which are translated, and
+	// translated contains the paths of the ones that already were
+	files      int
+	translated map[string]bool
+
+	// overlay contains the sources that differed from the files on disk, when
+	// the packages were loaded
+	overlay map[string][]byte
+}
+
+// isLoaded returns, whether the packages were loaded with the given source of
+// the file, either from the overlay or from disk.
+func (d *GlobalData) isLoaded(path string, src []byte) bool {
+	if s, ok := d.overlay[path]; ok {
+		return bytes.Equal(s, src)
+	}
+
+	return isOnDisk(path, src)
+}
+
+// release drops the syntax tree of the file with the given path, which was
+// translated, from the loaded packages. Only the package-level information that
+// is needed to translate the other files of its package is kept, i.e., its init
+// functions. Afterwards, the file can only be translated on its own.
+func (d *GlobalData) release(path string) {
+	pkgFile, ok := d.fileMap[path]
+	if !ok || pkgFile.file == nil {
+		return
+	}
+
+	// the file is part of every variant of its package, e.g., the test variant
+	for _, p := range d.pkgs {
+		for i, f := range p.Syntax {
+			if d.fset.Position(f.Package).Filename != path {
+				continue
+			}
+
+			releaseTypesInfo(p.TypesInfo, f)
+			p.Syntax[i] = strippedFile(f)
+		}
+	}
+
+	d.fileMap[path] = PackageFile{pkg: pkgFile.pkg}
+}
+
+// isReleased returns, whether the syntax tree of the file with the given path
+// was already released.
+func (d *GlobalData) isReleased(path string) bool {
+	pkgFile, ok := d.fileMap[path]
+
+	return ok && pkgFile.file == nil
+}
+
+// strippedFile returns a copy of the file, which only contains (empty) init
+// functions, so that their order within the package can still be determined,
+// as well as the documentation of the package.
+func strippedFile(f *ast.File) *ast.File {
+	stripped := &ast.File{
+		Doc:     f.Doc,
+		Package: f.Package,
+		Name:    f.Name,
+	}
+
+	for _, decl := range f.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Name.Name == "init" {
+			stripped.Decls = append(stripped.Decls, &ast.FuncDecl{Name: funcDecl.Name})
+		}
+	}
+
+	return stripped
+}
+
+// releaseTypesInfo deletes the type information of all nodes of the file, since
+// it references them. The nodes are looked up by key, so that the type
+// information of the whole package does not need to be iterated for each file.
+func releaseTypesInfo(info *types.Info, f *ast.File) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+
+		switch v := n.(type) {
+		case *ast.Ident:
+			delete(info.Defs, v)
+			delete(info.Uses, v)
+			delete(info.Instances, v)
+		case *ast.SelectorExpr:
+			delete(info.Selections, v)
+		}
+
+		if e, ok := n.(ast.Expr); ok {
+			delete(info.Types, e)
+		}
+
+		delete(info.Implicits, n)
+		delete(info.Scopes, n)
+
+		return true
+	})
+
+	inFile := func(n ast.Node) bool {
+		return n.Pos() >= f.Pos() && n.Pos() <= f.End()
+	}
+
+	// only the variables of the initializers are needed to determine their order
+	for _, init := range info.InitOrder {
+		if init.Rhs != nil && inFile(init.Rhs) {
+			init.Rhs = nil
+		}
+	}
+}
+
+// isOnDisk returns, whether the file on disk has the given source
+func isOnDisk(path string, src []byte) bool {
+	onDisk, err := os.ReadFile(path)
+
+	return err == nil && bytes.Equal(onDisk, src)
 }
 
-var data *GlobalData
+// dataMutex guards data and selectedFiles as well as the JNI environment, which is shared by the
+// cpg and frontend packages. Therefore, concurrent translations are serialized.
+var dataMutex sync.Mutex
+
+// data holds the loaded packages of each project, keyed by its top level path,
+// so that translations of different projects do not interfere with each other
+var data = map[string]*GlobalData{}
+
+// selectedFiles holds the files, which were selected for translation by the
+// JVM, keyed by the top level path of their project. They are passed once, when
+// the state of the project is reset, instead of with every file.
+var selectedFiles = map[string][]string{}
 
 func main() {
 
 }
 
 //export Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_parseInternal
-func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_parseInternal(envPointer *C.JNIEnv, thisPtr C.jobject, arg1 C.jobject, arg2 C.jobject, arg3 C.jobject) C.jobject {
+func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_parseInternal(envPointer *C.JNIEnv, thisPtr C.jobject, arg1 C.jobject, arg2 C.jobject, arg3 C.jobject, arg4 C.jobject) C.jobject {
+	dataMutex.Lock()
+	defer dataMutex.Unlock()
+
 	env := jnigi.WrapEnv(unsafe.Pointer(envPointer))
 
 	goFrontend := &frontend.GoLanguageFrontend{
@@ -79,78 +235,152 @@ func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_parseInter
 		CommentMap:       ast.CommentMap{},
 		CurrentTU:        nil,
 	}
+	defer goFrontend.Release()
+
+	goFrontend.DirectBuffer = func(buffer *jnigi.ObjectRef) []byte {
+		return directBuffer(envPointer, buffer)
+	}
 
 	srcObject := jnigi.WrapJObject(uintptr(arg1), "java/lang/String", false)
 	pathObject := jnigi.WrapJObject(uintptr(arg2), "java/lang/String", false)
 	topLevelObject := jnigi.WrapJObject(uintptr(arg3), "java/lang/String", false)
+	configObject := jnigi.WrapJObject(uintptr(arg4), "java/lang/String", false)
 
 	frontend.InitEnv(env)
 	cpg.InitEnv(env)
 
+	tu, err := parse(env, goFrontend, srcObject, pathObject, topLevelObject, configObject)
+
+	if err != nil {
+		msg := C.CString(err.Error())
+		defer C.free(unsafe.Pointer(msg))
+
+		// the exception is thrown, once we return to the JVM
+		C.throwTranslationException(envPointer, msg)
+
+		return 0
+	}
+
+	return C.jobject((*jnigi.ObjectRef)(tu).JObject())
+}
+
+// parse handles the file with the given path and returns its translation unit.
+// Errors, including panics caused by failing JNI calls within the handlers, are
+// returned instead of terminating the process, so that only the translation of
+// this file fails. The translation is aborted, once it is cancelled.
+func parse(
+	env *jnigi.Env,
+	goFrontend *frontend.GoLanguageFrontend,
+	srcObject *jnigi.ObjectRef,
+	pathObject *jnigi.ObjectRef,
+	topLevelObject *jnigi.ObjectRef,
+	configObject *jnigi.ObjectRef,
+) (tu *cpg.TranslationUnitDeclaration, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("could not parse file: %v", r)
+		}
+	}()
+
 	var src []byte
-	err := srcObject.CallMethod(env, "getBytes", &src)
+	err = srcObject.CallMethod(env, "getBytes", &src)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+
+	// Get the configuration of the frontend
+	var configBytes []byte
+	err = configObject.CallMethod(env, "getBytes", &configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	goFrontend.Config, err = frontend.ParseConfig(string(configBytes))
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the path to the file(s) to analyze
 	var pathBytes []byte
 	err = pathObject.CallMethod(env, "getBytes", &pathBytes)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	path, err := filepath.Abs(string(pathBytes))
+	path, err := frontend.AbsPath(string(pathBytes))
 	if err != nil {
-		log.Fatalf("Invalid path: %v", err)
+		return nil, fmt.Errorf("invalid path: %w", err)
 	}
 
+	// the code of the nodes is taken from the source we received, which might
+	// differ from the file on disk
+	goFrontend.AddSource(path, src)
+
 	// Get the path to the project that contains the file (which may contain the go.mod file)
 	var topLevelByte []byte
 	err = topLevelObject.CallMethod(env, "getBytes", &topLevelByte)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	topLevel := ""
 
+	// the modules of the project, which may contain nested ones
+	var modules *frontend.Modules
+
 	if len(topLevelByte) != 0 {
-		topLevel, err = filepath.Abs(string(topLevelByte))
+		topLevel, err = frontend.AbsPath(string(topLevelByte))
 		if err != nil {
-			log.Fatalf("Invalid path: %v", err)
+			return nil, fmt.Errorf("invalid path: %w", err)
 		}
 
-		if ok, err := goFrontend.ParseModule(topLevel); !ok || err != nil {
+		modules = frontend.NewModules(topLevel)
+		goFrontend.SetModuleOf(modules, path)
+
+		if goFrontend.Module != nil {
+			goFrontend.LogInfo("Go application has module support with path %s", goFrontend.Module.Path)
+		} else {
 			goFrontend.LogInfo("Did not find go module file.")
 		}
+	}
 
-		rel, err := filepath.Rel(topLevel, path)
+	// once a translation is cancelled, none of its remaining files is translated
+	ctx := translationContext(topLevel)
+	if err := checkCancelled(ctx); err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			log.Fatalf("Could not find path from file to root path. %s %s %v", topLevel, path, err)
-		}
+	projectData := data[topLevel]
 
-		rel = filepath.Dir(rel)
+	goFrontend.LogDebug("Data: %v", projectData)
 
-		if !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) && rel != "." {
-			goFrontend.LogInfo("Rel: %s", rel)
-			goFrontend.RelativeFilePath = rel
-		} else {
-			goFrontend.LogInfo("Could not find module.")
+	if projectData == nil {
+		if len(goFrontend.Config.Files) == 0 {
+			goFrontend.Config.Files = selectedFiles[topLevel]
 		}
-	}
-
-	goFrontend.LogInfo("Data: %v", data)
 
-	if data == nil {
 		fset := token.NewFileSet()
 		fileMap := map[string]PackageFile{}
 
-		packageMap := map[string]bool{}
+		// the types are cached for the whole project, starting with its records
+		typeCache := frontend.NewTypeCache()
+		goFrontend.Types = typeCache
+
+		// as are the strings, e.g., the names of the nodes
+		stringCache := frontend.NewStringCache()
+		goFrontend.Strings = stringCache
+
+		metrics := frontend.NewMetrics()
+		goFrontend.Metrics = metrics
+		start := time.Now()
+
+		// the packages of each module, keyed by its root directory relative to
+		// the top level
+		packageMap := map[string]map[string]bool{}
 
 		fileInfo, err := os.Stat(topLevel)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
 		rootPath := topLevel
@@ -158,180 +388,746 @@ func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_parseInter
 			rootPath = filepath.Dir(rootPath)
 		}
 
-		goFrontend.LogInfo("Root Path: %s", rootPath)
+		goFrontend.LogDebug("Root Path: %s", rootPath)
 
-		if err := filepath.Walk(rootPath, func(path string, info fs.FileInfo, err error) error {
-			goFrontend.LogInfo("Walk: %s %v", path, err)
-			if err != nil {
-				return err
+		discovered := 0
+
+		// addFile adds the package of the file with the given path, relative to
+		// the root path
+		addFile := func(rel string) {
+			if filepath.Ext(rel) != ".go" {
+				return
 			}
 
-			rel, err := filepath.Rel(rootPath, path)
-			if err != nil {
-				return err
+			discovered++
+			goFrontend.ReportProgress(frontend.PhaseDiscovered, discovered, len(goFrontend.Config.Files))
+
+			dir := filepath.ToSlash(filepath.Dir(rel))
+
+			if dir == "." {
+				dir = ""
 			}
 
-			pkgName := filepath.Dir(rel)
+			var pkgName, root string
 
-			if pkgName == "." {
-				pkgName = ""
+			if isVendored(rel) {
+				// vendored packages are imported by their original path
+				pkgName = strings.TrimPrefix(dir, "vendor/")
+			} else if modules != nil {
+				pkgName, root = modules.PackagePath(dir)
+			} else {
+				pkgName = dir
 			}
 
-			if goFrontend.Module != nil {
-				pkgName = goFrontend.Module.Module.Mod.Path + "/" + pkgName
+			if packageMap[root] == nil {
+				packageMap[root] = map[string]bool{}
 			}
 
-			pkgName = strings.TrimRight(pkgName, "/")
+			packageMap[root][pkgName] = true
+		}
+
+		if len(goFrontend.Config.Files) > 0 {
+			// the files were already selected by the JVM, so we can spare us
+			// the walk through the whole project
+			for _, file := range goFrontend.Config.Files {
+				file, err := frontend.AbsPath(file)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path: %w", err)
+				}
+
+				rel, ok := frontend.RelativePath(rootPath, file)
+				if !ok {
+					goFrontend.LogInfo("Skipping file outside of the project: %s", file)
+					continue
+				}
+
+				if !isSkipped(goFrontend.Config, rel) {
+					addFile(rel)
+				}
+			}
+		} else if err := walk(rootPath, goFrontend.Config.FollowSymlinks, func(path string, rel string, d fs.DirEntry) error {
+			goFrontend.LogDebug("Walk: %s", path)
 
-			if filepath.Ext(path) == ".go" {
-				packageMap[pkgName] = true
+			if err := checkCancelled(ctx); err != nil {
+				return err
 			}
 
+			if d.IsDir() {
+				if skipDir(goFrontend.Config, rel) {
+					goFrontend.LogDebug("Skipping directory: %s", path)
+
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			addFile(rel)
+
 			return nil
 		}); err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
-		packageArr := make([]string, 0, len(packageMap))
-		for p := range packageMap {
-			packageArr = append(packageArr, p)
+		// while walking, the total is not known in advance, and some of the
+		// selected files may have been skipped, so the discovered files are the
+		// total of this phase
+		goFrontend.ReportProgress(frontend.PhaseDiscovered, discovered, discovered)
+
+		metrics.Track("discover", start)
+
+		// selected external packages are loaded from the module cache as well,
+		// so that calls into them can be resolved
+		if len(goFrontend.Config.Dependencies) > 0 && packageMap[""] == nil {
+			packageMap[""] = map[string]bool{}
 		}
 
-		parsedPkgs, err := packages.Load(&packages.Config{
-			Fset: fset,
-			Dir:  rootPath,
-			Mode: packages.NeedFiles | packages.NeedSyntax | packages.NeedImports |
-				packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo,
-		}, packageArr...)
-		if err != nil {
-			log.Fatal(err)
+		// the modules and their packages are loaded in a fixed order, so that
+		// identical projects always result in an identical graph
+		roots := make([]string, 0, len(packageMap))
+		for root := range packageMap {
+			roots = append(roots, root)
+		}
+
+		sort.Strings(roots)
+
+		mode := packages.NeedFiles | packages.NeedSyntax | packages.NeedImports | packages.NeedName
+		if goFrontend.Config.TypeCheck {
+			mode |= packages.NeedTypes | packages.NeedTypesInfo
+		}
+
+		if len(goFrontend.Config.Dependencies) > 0 {
+			mode |= packages.NeedModule
 		}
 
-		goFrontend.LogInfo("Files: %+v %s", parsedPkgs, topLevel)
+		// The sources passed by the JVM may differ from the files on disk, e.g.,
+		// for unsaved buffers of an editor. Therefore, we overlay them, so that
+		// the type information reflects the actual content. Only the source of
+		// the current file is passed with it, the ones of all other files of the
+		// project are part of the configuration.
+		overlay := map[string][]byte{}
+		for file, content := range goFrontend.Config.Overlay {
+			file, err := frontend.AbsPath(file)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path: %w", err)
+			}
+
+			overlay[file] = []byte(content)
+		}
+
+		if !isOnDisk(path, src) {
+			overlay[path] = src
+		}
+
+		start = time.Now()
+
+		// each module is loaded on its own, since the packages of nested modules
+		// are not part of the module at the top level
+		var parsedPkgs []*packages.Package
+		for _, root := range roots {
+			packageArr := make([]string, 0, len(packageMap[root]))
+			for p := range packageMap[root] {
+				packageArr = append(packageArr, p)
+			}
 
+			sort.Strings(packageArr)
+
+			if root == "" {
+				packageArr = append(packageArr, goFrontend.Config.Dependencies...)
+			}
+
+			pkgs, err := packages.Load(&packages.Config{
+				Context:    ctx,
+				Fset:       fset,
+				Dir:        filepath.Join(rootPath, filepath.FromSlash(root)),
+				Mode:       mode,
+				Tests:      goFrontend.Config.IncludeTests,
+				BuildFlags: goFrontend.Config.BuildFlags(),
+				Env:        goFrontend.Config.Env(),
+				Overlay:    overlay,
+			}, packageArr...)
+
+			if err := checkCancelled(ctx); err != nil {
+				return nil, err
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			parsedPkgs = append(parsedPkgs, pkgs...)
+		}
+
+		metrics.Track("load", start)
+
+		// The test variant of a package contains the files of the package as
+		// well, so we prefer it, and skip the files that we already know.
+		// Otherwise, the packages are ordered by their ID, independent of the
+		// order in which they were loaded.
+		sort.SliceStable(parsedPkgs, func(i, j int) bool {
+			iTest := strings.Contains(parsedPkgs[i].ID, " [")
+			jTest := strings.Contains(parsedPkgs[j].ID, " [")
+
+			if iTest != jTest {
+				return iTest
+			}
+
+			return parsedPkgs[i].ID < parsedPkgs[j].ID
+		})
+
+		var pkgs []*packages.Package
+		for _, p := range parsedPkgs {
+			// skip the generated main packages of tests
+			if strings.HasSuffix(p.ID, ".test") {
+				continue
+			}
+
+			// without type checking, the handlers simply do not find any type information
+			if p.TypesInfo == nil {
+				p.TypesInfo = &types.Info{}
+			}
+
+			pkgs = append(pkgs, p)
+		}
+		parsedPkgs = pkgs
+
+		goFrontend.LogDebug("Files: %+v %s", parsedPkgs, topLevel)
+
+		start = time.Now()
+
+		// the services need the syntax of their packages, which might be
+		// released, once their files are translated
+		rpcServices := frontend.NewRPCServiceCache(parsedPkgs)
+		goFrontend.RPCServices = rpcServices
+
+		// a file may be part of several variants of its package
+		loadedFiles := map[string]bool{}
 		for _, p := range parsedPkgs {
-			goFrontend.LogInfo("Files: %s %s %+v %+v", p.Name, p.PkgPath, p.GoFiles, p.Errors)
+			for _, f := range p.Syntax {
+				if !isSkippedGenerated(goFrontend.Config, f) {
+					loadedFiles[fset.Position(f.Package).Filename] = true
+				}
+			}
+		}
+
+		for _, p := range parsedPkgs {
+			goFrontend.LogDebug("Files: %s %s %+v %+v", p.Name, p.PkgPath, p.GoFiles, p.Errors)
+
+			// files with syntax errors are still translated, as far as possible
+			for _, e := range p.Errors {
+				if e.Kind == packages.ParseError {
+					goFrontend.LogWarn("Syntax error in package %s: %v", p.PkgPath, e)
+				}
+			}
 
 			for _, f := range p.Syntax {
+				// files without a package clause are no Go files at all, so
+				// they fail on their own, once they are translated
+				if !f.Package.IsValid() {
+					continue
+				}
+
 				fpath := fset.Position(f.Package).Filename
 
-				goFrontend.CommentMap = ast.NewCommentMap(fset, f, f.Comments)
-				goFrontend.File = f
-				goFrontend.Package = p
+				if _, ok := fileMap[fpath]; ok {
+					continue
+				}
 
-				if len(topLevel) != 0 {
-					rel, err := filepath.Rel(topLevel, fpath)
+				// skipped generated files do not declare anything, so that
+				// references to their declarations remain unresolved
+				if isSkippedGenerated(goFrontend.Config, f) {
+					goFrontend.LogDebug("Skipping generated file: %s", fpath)
+					continue
+				}
 
-					if err != nil {
-						log.Fatal("Could not find path from file to mod path.")
+				if err := checkCancelled(ctx); err != nil {
+					return nil, err
+				}
+
+				err := goFrontend.HandleInLocalFrame(func() error {
+					goFrontend.CommentMap = ast.NewCommentMap(fset, f, f.Comments)
+					goFrontend.File = f
+					goFrontend.Package = p
+					goFrontend.Dependency = isDependency(rootPath, fpath)
+
+					if len(topLevel) != 0 {
+						goFrontend.SetModuleOf(modules, fpath)
 					}
 
-					rel = filepath.Dir(rel)
+					tu, err := goFrontend.HandleFileRecordDeclarations(fset, f, fpath)
+					if err != nil {
+						return err
+					}
 
-					if !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) && rel != "." {
-						goFrontend.RelativeFilePath = rel
-					} else {
-						goFrontend.RelativeFilePath = ""
+					fpathObject := cpg.NewString(fpath)
+					goFrontend.ObjectRef.CallMethod(
+						env,
+						"addActiveTranslationUnit",
+						nil,
+						fpathObject,
+						(*jnigi.ObjectRef)(tu).Cast(cpg.TranslationUnitDeclarationClass),
+					)
+					env.DeleteLocalRef(fpathObject)
+
+					// dependencies are not among the files of the JVM, so their
+					// translation units are returned along with the current file
+					if goFrontend.Dependency {
+						err = goFrontend.ObjectRef.CallMethod(
+							env,
+							"addDependencyTranslationUnit",
+							nil,
+							(*jnigi.ObjectRef)(tu).Cast(cpg.TranslationUnitDeclarationClass),
+						)
+						if err != nil {
+							return err
+						}
 					}
-				}
 
-				tu, err := goFrontend.HandleFileRecordDeclarations(fset, f, fpath)
+					return nil
+				})
 				if err != nil {
-					log.Fatal(err)
+					return nil, err
 				}
 
-				goFrontend.ObjectRef.CallMethod(
-					env,
-					"addActiveTranslationUnit",
-					nil,
-					cpg.NewString(fpath),
-					(*jnigi.ObjectRef)(tu).Cast(cpg.TranslationUnitDeclarationClass),
-				)
-
 				fileMap[fpath] = PackageFile{
 					file: f,
 					pkg:  p,
 				}
+
+				goFrontend.ReportProgress(frontend.PhaseLoaded, len(fileMap), len(loadedFiles))
 			}
 		}
 
-		data = &GlobalData{
-			fset:    fset,
-			fileMap: fileMap,
-			pkgs:    parsedPkgs,
+		// now that all records are known, we can check which interfaces they implement
+		goFrontend.HandleInterfaceImplementations(parsedPkgs)
+
+		// declare the predeclared functions, so that calls to them can be resolved
+		goFrontend.File = nil
+		goFrontend.Package = nil
+
+		if err := declareBuiltins(env, goFrontend, fset); err != nil {
+			return nil, err
 		}
+
+		// declare all package-level symbols, before any function body is handled
+		symbols := frontend.NewSymbolTable()
+		goFrontend.Symbols = symbols
+
+		for _, p := range parsedPkgs {
+			for _, f := range p.Syntax {
+				fpath := fset.Position(f.Package).Filename
+
+				if fileMap[fpath].pkg != p {
+					continue
+				}
+
+				err := goFrontend.HandleInLocalFrame(func() error {
+					goFrontend.CommentMap = ast.NewCommentMap(fset, f, f.Comments)
+					goFrontend.File = f
+					goFrontend.Package = p
+					goFrontend.Dependency = isDependency(rootPath, fpath)
+
+					if len(topLevel) != 0 {
+						goFrontend.SetModuleOf(modules, fpath)
+					}
+
+					var tu = jnigi.NewObjectRef(cpg.TranslationUnitDeclarationClass)
+					fpathObject := cpg.NewString(fpath)
+					err := goFrontend.ObjectRef.CallMethod(
+						env,
+						"getActiveTranslationUnit",
+						tu,
+						fpathObject,
+					)
+					env.DeleteLocalRef(fpathObject)
+					if err != nil {
+						return err
+					}
+
+					err = goFrontend.HandleFileSymbols(fset, f, (*cpg.TranslationUnitDeclaration)(tu))
+					if err != nil {
+						return err
+					}
+
+					return nil
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		metrics.Track("declare", start)
+
+		files := 0
+		for fpath := range fileMap {
+			if !isDependency(rootPath, fpath) {
+				files++
+			}
+		}
+
+		projectData = &GlobalData{
+			fset:       fset,
+			fileMap:    fileMap,
+			pkgs:       parsedPkgs,
+			symbols:    symbols,
+			rootPath:   rootPath,
+			types:      typeCache,
+			strings:    stringCache,
+			rpc:        rpcServices,
+			metrics:    metrics,
+			files:      files,
+			translated: map[string]bool{},
+			overlay:    overlay,
+		}
+		data[topLevel] = projectData
 	}
 
+	goFrontend.Symbols = projectData.symbols
+	goFrontend.Types = projectData.types
+	goFrontend.Strings = projectData.strings
+	goFrontend.RPCServices = projectData.rpc
+	goFrontend.Metrics = projectData.metrics
+
+	goFrontend.Dependency = false
 	goFrontend.CommentMap = nil
 	goFrontend.File = nil
 	goFrontend.Package = nil
+	goFrontend.Module = nil
 	goFrontend.RelativeFilePath = ""
 
 	if len(topLevel) != 0 {
-		rel, err := filepath.Rel(topLevel, path)
+		goFrontend.SetModuleOf(modules, path)
+	}
 
-		if err != nil {
-			log.Fatal("Could not find path from file to mod path.")
-		}
+	// test files are excluded entirely, unless requested, so we only create an
+	// empty translation unit for them
+	if !goFrontend.Config.IncludeTests && strings.HasSuffix(path, "_test.go") {
+		goFrontend.LogDebug("Skipping test file: %s", path)
 
-		rel = filepath.Dir(rel)
+		tu = goFrontend.NewTranslationUnitDeclaration(projectData.fset, nil, path)
 
-		if !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) && rel != "." {
-			goFrontend.RelativeFilePath = rel
-		} else {
-			goFrontend.LogInfo("Could not find module: %s %s %s", rel, topLevel, path)
+		return tu, nil
+	}
+
+	// generated files are skipped as well, if requested, for which the package
+	// clause and the comments before it suffice
+	if goFrontend.Config.SkipGenerated {
+		header, _ := parser.ParseFile(token.NewFileSet(), path, src, parser.PackageClauseOnly|parser.ParseComments)
+
+		if header != nil && frontend.IsGenerated(header) {
+			goFrontend.LogDebug("Skipping generated file: %s", path)
+
+			tu = goFrontend.NewTranslationUnitDeclaration(projectData.fset, nil, path)
+			tu.SetGenerated(true)
+
+			return tu, nil
 		}
 	}
 
+	// files, which are excluded by their build constraints, are not part of any
+	// loaded package, and they are not translated on their own either
+	if _, loaded := projectData.fileMap[path]; !loaded && !matchesBuildConstraints(goFrontend.Config, path, src) {
+		goFrontend.LogDebug("Skipping file excluded by build constraints: %s", path)
+
+		tu = goFrontend.NewTranslationUnitDeclaration(projectData.fset, nil, path)
+
+		return tu, nil
+	}
+
+	// neither are vendored files or the ones in ignored directories, which were
+	// skipped when discovering the packages
+	if rel, inside := frontend.RelativePath(projectData.rootPath, path); inside && isSkipped(goFrontend.Config, rel) {
+		goFrontend.LogDebug("Skipping file in skipped directory: %s", path)
+
+		tu = goFrontend.NewTranslationUnitDeclaration(projectData.fset, nil, path)
+
+		return tu, nil
+	}
+
 	var file *ast.File
-	var tu *cpg.TranslationUnitDeclaration
 
-	pkgFile, ok := data.fileMap[path]
+	pkgFile, ok := projectData.fileMap[path]
+	if ok && projectData.isReleased(path) {
+		goFrontend.LogWarn("Syntax tree of %s was already released, translating it without type information", path)
+		ok = false
+	} else if ok && !projectData.isLoaded(path, src) {
+		// we cannot reload all packages for every changed file, so we need to
+		// parse it on its own
+		goFrontend.LogWarn("Source of %s changed after its package was loaded, translating it without type information", path)
+		ok = false
+	}
+
 	if !ok {
-		goFrontend.LogInfo("Not found file")
-		file, err = parser.ParseFile(data.fset, path, string(src), parser.ParseComments)
+		goFrontend.LogDebug("Not found file")
+		file, err = parser.ParseFile(projectData.fset, path, string(src), parser.ParseComments|parser.AllErrors)
+		if file == nil {
+			return nil, err
+		}
+
+		if !file.Package.IsValid() {
+			return nil, fmt.Errorf("%s is not a Go file: %w", path, err)
+		}
+
+		// the parser still returns a partial AST, in which the broken regions
+		// are represented by "bad" nodes, so we translate what we can
 		if err != nil {
-			log.Fatal(err)
+			goFrontend.LogWarn("Syntax errors in %s: %v", path, err)
 		}
 
-		goFrontend.CommentMap = ast.NewCommentMap(data.fset, file, file.Comments)
+		goFrontend.CommentMap = ast.NewCommentMap(projectData.fset, file, file.Comments)
 		goFrontend.File = file
 
-		tu, err = goFrontend.HandleFileRecordDeclarations(data.fset, file, path)
+		tu, err = goFrontend.HandleFileRecordDeclarations(projectData.fset, file, path)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 	} else {
 		file = pkgFile.file
-		goFrontend.LogInfo("Found file: %s", file.Name.Name)
+		goFrontend.LogDebug("Found file: %s", file.Name.Name)
 
 		var i = jnigi.NewObjectRef(cpg.TranslationUnitDeclarationClass)
-		if err := goFrontend.ObjectRef.CallMethod(
+		fileObject := cpg.NewString(path)
+		err := goFrontend.ObjectRef.CallMethod(
 			env,
 			"getActiveTranslationUnit",
 			i,
-			cpg.NewString(path),
-		); err != nil {
+			fileObject,
+		)
+		env.DeleteLocalRef(fileObject)
+		if err != nil {
 			goFrontend.LogError("%v", err)
-			tu = goFrontend.NewTranslationUnitDeclaration(data.fset, file, path)
+			tu = goFrontend.NewTranslationUnitDeclaration(projectData.fset, file, path)
 		} else {
 			tu = (*cpg.TranslationUnitDeclaration)(i)
 		}
 
 		goFrontend.Package = pkgFile.pkg
-		goFrontend.CommentMap = ast.NewCommentMap(data.fset, file, file.Comments)
+		goFrontend.CommentMap = ast.NewCommentMap(projectData.fset, file, file.Comments)
 		goFrontend.File = file
 	}
 
-	err = goFrontend.HandleFileContent(data.fset, file, tu)
+	if err := checkCancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	err = goFrontend.HandleFileContent(projectData.fset, file, tu)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	return C.jobject((*jnigi.ObjectRef)(tu).JObject())
+	projectData.metrics.Track("translate", start)
+
+	// files outside of the loaded packages are not part of the progress
+	if _, loaded := projectData.fileMap[path]; loaded {
+		projectData.translated[path] = true
+	}
+
+	goFrontend.ReportProgress(frontend.PhaseTranslated, len(projectData.translated), projectData.files)
+
+	if ok && goFrontend.Config.ReleaseSyntax {
+		projectData.release(path)
+	}
+
+	return tu, nil
+}
+
+// matchesBuildConstraints returns, whether the file with the given source is
+// selected by the build context of the configuration, e.g., by its //go:build
+// line or the GOOS suffix of its name. Files, whose constraints cannot be read,
+// are still translated.
+func matchesBuildConstraints(config *frontend.Config, path string, src []byte) bool {
+	ctxt := config.BuildContext()
+	ctxt.OpenFile = func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(src)), nil
+	}
+
+	match, err := ctxt.MatchFile(filepath.Dir(path), filepath.Base(path))
+
+	return match || err != nil
+}
+
+// builtinName is the name, by which the translation unit of the predeclared
+// functions is registered among the active translation units
+const builtinName = "builtin"
+
+// declareBuiltins declares the predeclared functions, unless they were already
+// declared in the current translation. It may load several projects, e.g., one
+// per directory without a top level, but shares the global scope and the
+// active translation units among them.
+func declareBuiltins(env *jnigi.Env, goFrontend *frontend.GoLanguageFrontend, fset *token.FileSet) error {
+	nameObject := cpg.NewString(builtinName)
+	defer env.DeleteLocalRef(nameObject)
+
+	declared := jnigi.NewObjectRef(cpg.TranslationUnitDeclarationClass)
+	err := goFrontend.ObjectRef.CallMethod(env, "getActiveTranslationUnit", declared, nameObject)
+	if err != nil {
+		return err
+	}
+
+	if !declared.IsNil() {
+		env.DeleteLocalRef(declared)
+		return nil
+	}
+
+	tu, err := goFrontend.HandleBuiltins(fset)
+	if err != nil {
+		return err
+	}
+
+	return goFrontend.ObjectRef.CallMethod(
+		env,
+		"addActiveTranslationUnit",
+		nil,
+		nameObject,
+		(*jnigi.ObjectRef)(tu).Cast(cpg.TranslationUnitDeclarationClass),
+	)
+}
+
+// isSkippedGenerated returns, whether the file is a generated one, which is
+// skipped according to the configuration
+func isSkippedGenerated(config *frontend.Config, file *ast.File) bool {
+	return config.SkipGenerated && frontend.IsGenerated(file)
+}
+
+// skipDir returns, whether the directory with the given path, relative to the
+// root path, is skipped when discovering the packages
+func skipDir(config *frontend.Config, rel string) bool {
+	if rel == "." {
+		return false
+	}
+
+	if config.IsIgnored(filepath.ToSlash(rel)) {
+		return true
+	}
+
+	// Vendored packages are only translated if explicitly requested, otherwise
+	// they are only used to resolve imports.
+	return isVendored(rel) && !config.TranslateVendor
+}
+
+// isSkipped returns, whether the file with the given path, relative to the root
+// path, is located in a directory, which is skipped, see skipDir
+func isSkipped(config *frontend.Config, rel string) bool {
+	for dir := filepath.Dir(rel); dir != "."; dir = filepath.Dir(dir) {
+		if skipDir(config, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isVendored returns, whether the path, relative to the root path, is located in
+// the vendor directory
+func isVendored(rel string) bool {
+	rel = filepath.ToSlash(rel)
+
+	return rel == "vendor" || strings.HasPrefix(rel, "vendor/")
+}
+
+// isDependency returns, whether the file is located outside of the project, i.e.,
+// it belongs to an external package, which was loaded from the module cache. Of
+// these, only the exported declarations are translated as stubs.
+func isDependency(rootPath string, fpath string) bool {
+	_, ok := frontend.RelativePath(rootPath, fpath)
+
+	return !ok
 }
 
 //export Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_resetState
-func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_resetState(envPointer *C.JNIEnv, thisPtr C.jobject) {
-	data = nil
+func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_resetState(envPointer *C.JNIEnv, thisPtr C.jobject, arg1 C.jobject, arg2 C.jobject) {
+	dataMutex.Lock()
+	defer dataMutex.Unlock()
+
+	env := jnigi.WrapEnv(unsafe.Pointer(envPointer))
+
+	frontend.InitEnv(env)
+	cpg.InitEnv(env)
+
+	var topLevelBytes []byte
+	err := jnigi.WrapJObject(uintptr(arg1), "java/lang/String", false).CallMethod(env, "getBytes", &topLevelBytes)
+	if err != nil {
+		return
+	}
+
+	// only the given project is reset, since other ones might be translated
+	// concurrently
+	if len(topLevelBytes) == 0 {
+		return
+	}
+
+	topLevel, err := frontend.AbsPath(string(topLevelBytes))
+	if err != nil {
+		return
+	}
+
+	// a new translation starts, which is not affected by a cancellation of the
+	// previous one
+	finishTranslation(topLevel)
+
+	// the files are passed as a JSON array
+	var filesBytes []byte
+	err = jnigi.WrapJObject(uintptr(arg2), "java/lang/String", false).CallMethod(env, "getBytes", &filesBytes)
+	if err != nil {
+		return
+	}
+
+	var files []string
+	if err = json.Unmarshal(filesBytes, &files); err != nil {
+		return
+	}
+
+	if projectData, ok := data[topLevel]; ok {
+		projectData.symbols.Release()
+		projectData.types.Release()
+		projectData.strings.Release()
+		delete(data, topLevel)
+	}
+
+	delete(selectedFiles, topLevel)
+
+	if len(files) > 0 {
+		selectedFiles[topLevel] = files
+	}
+}
+
+//export Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_getMetricsInternal
+func Java_de_fraunhofer_aisec_cpg_frontends_golang_GoLanguageFrontend_getMetricsInternal(envPointer *C.JNIEnv, thisPtr C.jobject, arg1 C.jobject) C.jobject {
+	dataMutex.Lock()
+	defer dataMutex.Unlock()
+
+	env := jnigi.WrapEnv(unsafe.Pointer(envPointer))
+
+	frontend.InitEnv(env)
+	cpg.InitEnv(env)
+
+	var topLevelBytes []byte
+	err := jnigi.WrapJObject(uintptr(arg1), "java/lang/String", false).CallMethod(env, "getBytes", &topLevelBytes)
+	if err != nil {
+		return 0
+	}
+
+	topLevel := ""
+	if len(topLevelBytes) != 0 {
+		topLevel, err = frontend.AbsPath(string(topLevelBytes))
+		if err != nil {
+			return 0
+		}
+	}
+
+	// without any translation of the project, there are no metrics either
+	projectData := data[topLevel]
+	if projectData == nil {
+		return 0
+	}
+
+	json, err := projectData.metrics.JSON()
+	if err != nil {
+		return 0
+	}
+
+	return C.jobject(cpg.NewString(json).JObject())
 }
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"cpg/frontend"
+	"testing"
+)
+
+func TestIsSkipped(t *testing.T) {
+	config := frontend.DefaultConfig()
+	config.Ignore = append(config.Ignore, "generated", "internal/mock*")
+
+	tests := []struct {
+		rel             string
+		translateVendor bool
+		want            bool
+	}{
+		{rel: "main.go"},
+		{rel: "pkg/pkg.go"},
+		// the default patterns
+		{rel: "testdata/data.go", want: true},
+		{rel: "pkg/testdata/data.go", want: true},
+		{rel: ".git/hooks/hook.go", want: true},
+		{rel: "_old/old.go", want: true},
+		{rel: "web/node_modules/x/x.go", want: true},
+		// a configured name is matched in every directory
+		{rel: "generated/gen.go", want: true},
+		{rel: "pkg/generated/gen.go", want: true},
+		{rel: "pkg/generated.go"},
+		// a configured path only relative to the top level
+		{rel: "internal/mocks/mock.go", want: true},
+		{rel: "pkg/internal/mocks/mock.go"},
+		// vendored packages, unless they are translated
+		{rel: "vendor/example.io/x/x.go", want: true},
+		{rel: "vendor/example.io/x/x.go", translateVendor: true},
+	}
+
+	for _, tt := range tests {
+		config.TranslateVendor = tt.translateVendor
+
+		if got := isSkipped(config, tt.rel); got != tt.want {
+			t.Errorf("isSkipped(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"cpg/frontend"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// walkFunc is called for each file and directory within the root path of a
+// walk, with its path and the path relative to the root path. Like for
+// filepath.WalkDir, returning filepath.SkipDir skips a directory.
+type walkFunc func(path string, rel string, d fs.DirEntry) error
+
+// walker walks through the directories of a project like filepath.WalkDir, but
+// optionally follows symbolic links to directories. These are only followed, if
+// they point to a directory within the root path, which was not walked through
+// yet. This way, cycles of links are not followed, and directories reachable by
+// several paths, e.g., through links to the same directory, are only walked
+// through once, instead of once per path.
+type walker struct {
+	// root is the root path with all links resolved
+	root string
+
+	followLinks bool
+	fn          walkFunc
+
+	// visited contains the directories, with all links resolved, which were
+	// already walked through during the whole walk
+	visited map[string]bool
+}
+
+// walk calls fn for each file and directory within root, except root itself.
+func walk(root string, followLinks bool, fn walkFunc) error {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+
+	w := &walker{
+		root:        realRoot,
+		followLinks: followLinks,
+		fn:          fn,
+		visited:     map[string]bool{},
+	}
+
+	return w.walkDir(root, ".")
+}
+
+// walkDir walks through the directory with the given path, which is reached by
+// the path rel relative to the root path.
+func (w *walker) walkDir(dir string, rel string) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	if w.visited[real] {
+		// we reached a directory through a link, which we already walked
+		// through, or in which we still are
+		return nil
+	}
+
+	w.visited[real] = true
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range entries {
+		path := filepath.Join(dir, d.Name())
+		r := filepath.Join(rel, d.Name())
+
+		if d.Type()&fs.ModeSymlink != 0 && w.followLinks {
+			err = w.walkLink(path, r)
+		} else {
+			err = w.visit(path, r, d)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// visit calls fn for the entry with the given path and walks through it, if it
+// is a directory, which is not skipped.
+func (w *walker) visit(path string, rel string, d fs.DirEntry) error {
+	err := w.fn(path, rel, d)
+	if err == filepath.SkipDir {
+		return nil
+	} else if err != nil || !d.IsDir() {
+		return err
+	}
+
+	return w.walkDir(path, rel)
+}
+
+// walkLink follows the symbolic link with the given path, unless it is broken
+// or points outside of the root path.
+func (w *walker) walkLink(path string, rel string) error {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil
+	}
+
+	if _, ok := frontend.RelativePath(w.root, target); !ok {
+		return nil
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil
+	}
+
+	return w.visit(path, rel, fs.FileInfoToDirEntry(info))
+}
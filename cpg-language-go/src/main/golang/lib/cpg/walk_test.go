@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2021, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// links creates a chain of depth+1 directories in root, each of which contains
+// two links to the next one, so that the last directory is reachable by
+// 2^depth paths.
+func links(t *testing.T, root string, depth int) {
+	t.Helper()
+
+	for i := 0; i <= depth; i++ {
+		dir := filepath.Join(root, "d"+string(rune('a'+i)))
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == depth {
+			if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			continue
+		}
+
+		next := filepath.Join(root, "d"+string(rune('a'+i+1)))
+		for _, name := range []string{"left", "right"} {
+			if err := os.Symlink(next, filepath.Join(dir, name)); err != nil {
+				t.Skipf("symbolic links are not supported: %v", err)
+			}
+		}
+	}
+}
+
+func TestWalkVisitsDirectoriesOnce(t *testing.T) {
+	root := t.TempDir()
+	links(t, root, 12)
+
+	files := 0
+	err := walk(root, true, func(path string, rel string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			files++
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if files != 1 {
+		t.Errorf("walk visited %d files, want 1", files)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		name        string
+		followLinks bool
+		want        []string
+	}{
+		{
+			name: "without links",
+			want: []string{"a", "a/a.go", "a/b", "b", "b/b.go", "b/cycle"},
+		},
+		{
+			name:        "with links",
+			followLinks: true,
+			// b was already walked through by the link in a
+			want: []string{"a", "a/a.go", "a/b", "a/b/b.go", "a/b/cycle", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+
+			for _, dir := range []string{"a", "b"} {
+				if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(filepath.Join(root, dir, dir+".go"), []byte("package "+dir+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// a links to b, which links back to a
+			if err := os.Symlink(filepath.Join(root, "b"), filepath.Join(root, "a", "b")); err != nil {
+				t.Skipf("symbolic links are not supported: %v", err)
+			}
+
+			if err := os.Symlink(filepath.Join(root, "a"), filepath.Join(root, "b", "cycle")); err != nil {
+				t.Skipf("symbolic links are not supported: %v", err)
+			}
+
+			var got []string
+			err := walk(root, tt.followLinks, func(path string, rel string, d fs.DirEntry) error {
+				got = append(got, filepath.ToSlash(rel))
+
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("walk visited %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("walk visited %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
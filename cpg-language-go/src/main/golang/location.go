@@ -26,10 +26,6 @@
 package cpg
 
 import (
-	"go/ast"
-	"go/token"
-	"log"
-
 	"tekao.net/jnigi"
 )
 
@@ -39,23 +35,3 @@ type Region jnigi.ObjectRef
 const SarifPackage = CPGPackage + "/sarif"
 const RegionClass = SarifPackage + "/Region"
 const PhysicalLocationClass = SarifPackage + "/PhysicalLocation"
-
-func NewRegion(fset *token.FileSet, astNode ast.Node, startLine int, startColumn int, endLine int, endColumn int) *Region {
-	c, err := env.NewObject(RegionClass, startLine, startColumn, endLine, endColumn)
-	if err != nil {
-		log.Fatal(err)
-
-	}
-
-	return (*Region)(c)
-}
-
-func NewPhysicalLocation(fset *token.FileSet, astNode ast.Node, uri *jnigi.ObjectRef, region *Region) *PhysicalLocation {
-	c, err := env.NewObject(PhysicalLocationClass, (*jnigi.ObjectRef)(uri), (*jnigi.ObjectRef)(region))
-	if err != nil {
-		log.Fatal(err)
-
-	}
-
-	return (*PhysicalLocation)(c)
-}
@@ -26,8 +26,6 @@
 package cpg
 
 import (
-	"log"
-
 	"tekao.net/jnigi"
 )
 
@@ -36,31 +34,79 @@ type Node jnigi.ObjectRef
 const CPGPackage = "de/fraunhofer/aisec/cpg"
 const GraphPackage = CPGPackage + "/graph"
 const NodeClass = GraphPackage + "/Node"
+const AnnotationClass = GraphPackage + "/Annotation"
+const AnnotationMemberClass = GraphPackage + "/AnnotationMember"
+const NameClass = GraphPackage + "/Name"
+
+type Annotation Node
+type AnnotationMember Node
 
 func (n *Node) Cast(className string) *jnigi.ObjectRef {
 	return (*jnigi.ObjectRef)(n).Cast(className)
 }
 
 func (n *Node) SetName(s string) error {
-	return (*jnigi.ObjectRef)(n).CallMethod(env, "setName", nil, NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(n).CallMethod(env, "setName", nil, str)
 }
 
 func (n *Node) SetLanguge(l *Language) error {
 	return (*jnigi.ObjectRef)(n).CallMethod(env, "setLanguage", nil, l)
 }
 
+func (n *Node) SetImplicit(b bool) error {
+	return (*jnigi.ObjectRef)(n).CallMethod(env, "setImplicit", nil, b)
+}
+
 func (n *Node) SetCode(s string) error {
-	return (*jnigi.ObjectRef)(n).SetField(env, "code", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(n).SetField(env, "code", str)
 }
 
 func (n *Node) SetComment(s string) error {
-	return (*jnigi.ObjectRef)(n).SetField(env, "comment", NewString(s))
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	return (*jnigi.ObjectRef)(n).SetField(env, "comment", str)
 }
 
 func (n *Node) SetLocation(location *PhysicalLocation) error {
 	return (*jnigi.ObjectRef)(n).SetField(env, "location", (*jnigi.ObjectRef)(location))
 }
 
+// SetNameParent sets the parent of the full name of the node, so that its full
+// name is qualified by parent, while its (local) name stays the same.
+func (n *Node) SetNameParent(parent string) {
+	var fullName = jnigi.NewObjectRef(NameClass)
+	err := (*jnigi.ObjectRef)(n).CallMethod(env, "getFullName", fullName)
+	if err != nil {
+		panic(err)
+	}
+
+	defer env.DeleteLocalRef(fullName)
+
+	str := NewString(parent)
+	defer env.DeleteLocalRef(str)
+
+	delimiter := NewString(".")
+	defer env.DeleteLocalRef(delimiter)
+
+	p, err := env.NewObject(NameClass, str, jnigi.NewObjectRef(NameClass), delimiter)
+	if err != nil {
+		panic(err)
+	}
+	defer env.DeleteLocalRef(p)
+
+	err = fullName.CallMethod(env, "setParent", nil, p)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (n *Node) GetName() string {
 	var o = jnigi.NewObjectRef("java/lang/String")
 	_ = (*jnigi.ObjectRef)(n).CallMethod(env, "getName", o)
@@ -72,8 +118,47 @@ func (n *Node) GetName() string {
 	var b []byte
 	err := o.CallMethod(env, "getBytes", &b)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return string(b)
 }
+
+func (n *Node) AddAnnotations(annotations []*Annotation) {
+	list, err := ListOf(annotations)
+	if err != nil {
+		panic(err)
+	}
+
+	err = (*jnigi.ObjectRef)(n).CallMethod(env, "addAnnotations", nil, list.Cast("java/util/Collection"))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (n *Node) AddPrevDFG(prev *Node) {
+	err := (*jnigi.ObjectRef)(n).CallMethod(env, "addPrevDFG", nil, (*jnigi.ObjectRef)(prev).Cast(NodeClass))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (a *Annotation) Cast(className string) *jnigi.ObjectRef {
+	return (*jnigi.ObjectRef)(a).Cast(className)
+}
+
+func (a *Annotation) SetMembers(members []*AnnotationMember) {
+	list, err := ListOf(members)
+	if err != nil {
+		panic(err)
+	}
+
+	err = (*jnigi.ObjectRef)(a).CallMethod(env, "setMembers", nil, list.Cast("java/util/List"))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (m *AnnotationMember) Cast(className string) *jnigi.ObjectRef {
+	return (*jnigi.ObjectRef)(m).Cast(className)
+}
@@ -25,7 +25,11 @@
  */
 package cpg
 
-import "tekao.net/jnigi"
+import (
+	"errors"
+
+	"tekao.net/jnigi"
+)
 
 type ScopeManager jnigi.ObjectRef
 type Scope jnigi.ObjectRef
@@ -35,6 +39,7 @@ const ScopesPackage = PassesPackage + "/scopes"
 const ScopeManagerClass = ScopesPackage + "/ScopeManager"
 const ScopeClass = ScopesPackage + "/Scope"
 const NameScopeClass = ScopesPackage + "/NameScope"
+const ValueDeclarationScopeClass = ScopesPackage + "/ValueDeclarationScope"
 
 func (s *ScopeManager) EnterScope(n *Node) {
 	(*jnigi.ObjectRef)(s).CallMethod(env, "enterScope", nil, (*jnigi.ObjectRef)(n).Cast(NodeClass))
@@ -60,7 +65,17 @@ func (s *ScopeManager) GetCurrentScope() *Scope {
 
 func (s *ScopeManager) LookupScope(fqn string) *Scope {
 	var o = jnigi.NewObjectRef(NameScopeClass)
-	(*jnigi.ObjectRef)(s).CallMethod(env, "lookupScope", o, NewString(fqn))
+	str := NewString(fqn)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(s).CallMethod(env, "lookupScope", o, str)
+
+	return (*Scope)(o)
+}
+
+func (s *ScopeManager) LookupScopeOf(n *Node) *Scope {
+	var o = jnigi.NewObjectRef(ScopeClass)
+	(*jnigi.ObjectRef)(s).CallMethod(env, "lookupScope", o, (*jnigi.ObjectRef)(n).Cast(NodeClass))
 
 	return (*Scope)(o)
 }
@@ -81,12 +96,14 @@ func (s *ScopeManager) GetCurrentBlock() *CompoundStatement {
 
 func (s *ScopeManager) GetRecordForName(scope *Scope, recordName string) (record *RecordDeclaration, err error) {
 	var o = jnigi.NewObjectRef(RecordDeclarationClass)
+	str := NewString(recordName)
+	defer env.DeleteLocalRef(str)
 
 	err = (*jnigi.ObjectRef)(s).CallMethod(env,
 		"getRecordForName",
 		o,
 		(*jnigi.ObjectRef)(scope).Cast(ScopeClass),
-		NewString(recordName))
+		str)
 
 	record = (*RecordDeclaration)(o)
 
@@ -98,3 +115,27 @@ func (s *ScopeManager) AddDeclaration(d *Declaration) (err error) {
 
 	return
 }
+
+func (s *ScopeManager) AddTypedef(t *TypedefDeclaration) {
+	(*jnigi.ObjectRef)(s).CallMethod(env, "addTypedef", nil, (*jnigi.ObjectRef)(t).Cast(TypedefDeclarationClass))
+}
+
+func (s *Scope) IsNil() bool {
+	return (*jnigi.ObjectRef)(s).IsNil()
+}
+
+// AddDeclaration adds d to this scope and to the AST of its node, independent
+// of the current scope of the scope manager. Only a ValueDeclarationScope (or
+// one of its subclasses) can hold declarations.
+func (s *Scope) AddDeclaration(d *Declaration) error {
+	ok, err := (*jnigi.ObjectRef)(s).IsInstanceOf(env, ValueDeclarationScopeClass)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("scope cannot hold declarations")
+	}
+
+	return (*jnigi.ObjectRef)(s).Cast(ValueDeclarationScopeClass).CallMethod(env, "addDeclaration", nil, (*jnigi.ObjectRef)(d).Cast(DeclarationClass), true)
+}
@@ -39,10 +39,17 @@ type CaseStatement Statement
 type DefaultStatement Statement
 type ForStatement Statement
 type ForEachStatement Statement
+type LabelStatement Statement
+type GotoStatement Statement
+type BreakStatement Statement
+type ContinueStatement Statement
+type EmptyStatement Statement
+type TryStatement Statement
 
 const StatementsPackage = GraphPackage + "/statements"
 const StatementClass = StatementsPackage + "/Statement"
 const CompoundStatementClass = StatementsPackage + "/CompoundStatement"
+const LabelStatementClass = StatementsPackage + "/LabelStatement"
 
 func (f *CompoundStatement) AddStatement(s *Statement) {
 	(*jnigi.ObjectRef)(f).CallMethod(env, "addStatement", nil, (*jnigi.ObjectRef)(s).Cast(StatementClass))
@@ -119,3 +126,47 @@ func (f *ForEachStatement) SetIterable(s *Statement) {
 func (f *ForEachStatement) SetStatement(s *Statement) {
 	(*jnigi.ObjectRef)(f).CallMethod(env, "setStatement", nil, (*jnigi.ObjectRef)(s).Cast(StatementClass))
 }
+
+func (l *LabelStatement) SetLabel(s string) {
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(l).CallMethod(env, "setLabel", nil, str)
+}
+
+func (l *LabelStatement) SetSubStatement(s *Statement) {
+	(*jnigi.ObjectRef)(l).CallMethod(env, "setSubStatement", nil, (*jnigi.ObjectRef)(s).Cast(StatementClass))
+}
+
+func (g *GotoStatement) SetLabelName(s string) {
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(g).CallMethod(env, "setLabelName", nil, str)
+}
+
+func (g *GotoStatement) SetTargetLabel(l *LabelStatement) {
+	(*jnigi.ObjectRef)(g).CallMethod(env, "setTargetLabel", nil, (*jnigi.ObjectRef)(l).Cast(LabelStatementClass))
+}
+
+func (b *BreakStatement) SetLabel(s string) {
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(b).CallMethod(env, "setLabel", nil, str)
+}
+
+func (c *ContinueStatement) SetLabel(s string) {
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	(*jnigi.ObjectRef)(c).CallMethod(env, "setLabel", nil, str)
+}
+
+func (t *TryStatement) SetTryBlock(s *CompoundStatement) {
+	(*jnigi.ObjectRef)(t).CallMethod(env, "setTryBlock", nil, (*jnigi.ObjectRef)(s).Cast(CompoundStatementClass))
+}
+
+func (t *TryStatement) SetFinallyBlock(s *CompoundStatement) {
+	(*jnigi.ObjectRef)(t).CallMethod(env, "setFinallyBlock", nil, (*jnigi.ObjectRef)(s).Cast(CompoundStatementClass))
+}
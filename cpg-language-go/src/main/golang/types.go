@@ -30,9 +30,6 @@ import (
 
 	"tekao.net/jnigi"
 )
-import (
-	"log"
-)
 
 var env *jnigi.Env
 
@@ -45,7 +42,9 @@ const UnknownTypeClass = TypesPackage + "/UnknownType"
 const TypeParserClass = TypesPackage + "/TypeParser"
 const PointerTypeClass = TypesPackage + "/PointerType"
 const FunctionTypeClass = TypesPackage + "/FunctionType"
+const GoTupleTypeClass = "de/fraunhofer/aisec/cpg/frontends/golang/GoTupleType"
 const PointerOriginClass = PointerTypeClass + "$PointerOrigin"
+const QualifierClass = TypeClass + "$Qualifier"
 
 func (*Type) GetClassName() string {
 	return TypeClass
@@ -84,9 +83,12 @@ func InitEnv(e *jnigi.Env) {
 
 func TypeParser_createFrom(s string, l *Language) *Type {
 	var t = jnigi.NewObjectRef(TypeClass)
-	err := env.CallStaticMethod(TypeParserClass, "createFrom", t, NewString(s), l)
+	str := NewString(s)
+	defer env.DeleteLocalRef(str)
+
+	err := env.CallStaticMethod(TypeParserClass, "createFrom", t, str, l)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 
 	}
 
@@ -97,7 +99,7 @@ func UnknownType_getUnknown(l *Language) *UnknownType {
 	var t = jnigi.NewObjectRef(UnknownTypeClass)
 	err := env.CallStaticMethod(UnknownTypeClass, "getUnknownType", t, l)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 
 	}
 
@@ -108,7 +110,7 @@ func (t *Type) GetRoot() *Type {
 	var root = jnigi.NewObjectRef(TypeClass)
 	err := (*jnigi.ObjectRef)(t).CallMethod(env, "getRoot", root)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return (*Type)(root)
@@ -119,12 +121,35 @@ func (t *Type) Reference(o *jnigi.ObjectRef) *Type {
 	err := (*jnigi.ObjectRef)(t).CallMethod(env, "reference", refType, (*jnigi.ObjectRef)(o).Cast(PointerOriginClass))
 
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return (*Type)(refType)
 }
 
+func (t *Type) Duplicate() *Type {
+	var dup = jnigi.NewObjectRef(TypeClass)
+	err := (*jnigi.ObjectRef)(t).CallMethod(env, "duplicate", dup)
+	if err != nil {
+		panic(err)
+	}
+
+	return (*Type)(dup)
+}
+
+func (t *Type) SetConst(b bool) {
+	var q = jnigi.NewObjectRef(QualifierClass)
+	err := (*jnigi.ObjectRef)(t).CallMethod(env, "getQualifier", q)
+	if err != nil {
+		panic(err)
+	}
+
+	err = q.CallMethod(env, "setConst", nil, b)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (h *HasType) SetType(t *Type) {
 	if t != nil {
 		(*jnigi.ObjectRef)(h).CallMethod(env, "setType", nil, (*Node)(t).Cast(TypeClass))
@@ -135,7 +160,7 @@ func (h *HasType) GetType() *Type {
 	var t = jnigi.NewObjectRef(TypeClass)
 	err := (*jnigi.ObjectRef)(h).CallMethod(env, "getType", t)
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
 	}
 
 	return (*Type)(t)
@@ -147,8 +172,50 @@ func (t *ObjectType) AddGeneric(g *Type) {
 	var objType = jnigi.WrapJObject(uintptr((*jnigi.ObjectRef)(t).JObject()), ObjectTypeClass, false)
 	err := objType.CallMethod(env, "addGeneric", nil, (*Node)(g).Cast(TypeClass))
 	if err != nil {
-		log.Fatal(err)
+		panic(err)
+	}
+}
+
+func (t *ObjectType) SetRecordDeclaration(r *RecordDeclaration) {
+	// See AddGeneric
+	var objType = jnigi.WrapJObject(uintptr((*jnigi.ObjectRef)(t).JObject()), ObjectTypeClass, false)
+	err := objType.CallMethod(env, "setRecordDeclaration", nil, (*jnigi.ObjectRef)(r).Cast(RecordDeclarationClass))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SetArrayLength sets the length of an array type. Only a pointer type has a
+// length, whereas referencing an unknown type, e.g., of an unresolved element,
+// still results in the unknown type, which is then left as it is.
+func (t *Type) SetArrayLength(l int64) {
+	ok, err := (*jnigi.ObjectRef)(t).IsInstanceOf(env, PointerTypeClass)
+	if err != nil {
+		panic(err)
+	}
+
+	if !ok {
+		return
+	}
+
+	// See AddGeneric
+	var pointerType = jnigi.WrapJObject(uintptr((*jnigi.ObjectRef)(t).JObject()), PointerTypeClass, false)
+	long := NewLong(l)
+	defer env.DeleteLocalRef(long)
+
+	err = pointerType.CallMethod(env, "setArrayLength", nil, long)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (t *Type) IsObjectType() bool {
+	ok, err := (*jnigi.ObjectRef)(t).IsInstanceOf(env, ObjectTypeClass)
+	if err != nil {
+		panic(err)
 	}
+
+	return ok
 }
 
 func FunctionType_ComputeType(decl *FunctionDeclaration) (t *Type, err error) {
@@ -161,3 +228,17 @@ func FunctionType_ComputeType(decl *FunctionDeclaration) (t *Type, err error) {
 
 	return (*Type)(funcType), nil
 }
+
+func NewTupleType(elementTypes []*Type) *Type {
+	list, err := ListOf(elementTypes)
+	if err != nil {
+		panic(err)
+	}
+
+	t, err := env.NewObject(GoTupleTypeClass, list.Cast("java/util/List"))
+	if err != nil {
+		panic(err)
+	}
+
+	return (*Type)(t)
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2022, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.frontends.golang
+
+import com.fasterxml.jackson.databind.node.ObjectNode
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import java.io.File
+import org.apache.commons.lang3.builder.ToStringBuilder
+import org.apache.commons.lang3.builder.ToStringStyle
+
+/**
+ * This class holds configuration options for the [GoLanguageFrontend]. It is passed to the native
+ * part of the frontend as JSON, therefore the names of the properties must match the ones of the
+ * `Config` struct of the frontend.
+ */
+class GoConfiguration
+private constructor(
+    /** Also translates test files and test packages. */
+    val includeTests: Boolean,
+
+    /** Additional build tags, which are considered when selecting the files of a package. */
+    val buildTags: List<String>,
+
+    /**
+     * The target operating system, which is considered when selecting the files of a package. If
+     * empty, the one of the host is used.
+     */
+    val goos: String,
+
+    /**
+     * The target architecture, which is considered when selecting the files of a package. If
+     * empty, the one of the host is used.
+     */
+    val goarch: String,
+
+    /** Loads dependencies from the vendor directory. */
+    val vendor: Boolean,
+
+    /**
+     * Also translates the packages in the vendor directory. Otherwise, they are only used to
+     * resolve imports.
+     */
+    val translateVendor: Boolean,
+
+    /**
+     * Type checks the packages. Without type information, the frontend falls back to heuristics,
+     * e.g. to resolve references.
+     */
+    val typeCheck: Boolean,
+
+    /**
+     * The maximum number of package-level symbols, which are declared before any file content is
+     * handled. Zero means no limit.
+     */
+    val symbolLimit: Int,
+
+    /**
+     * Import path patterns of external packages, e.g. `github.com/aws/aws-sdk-go-v2/...`, which
+     * are loaded from the module cache. Only their exported declarations are translated, so that
+     * calls into these packages can be resolved.
+     */
+    val dependencies: List<String>,
+
+    /**
+     * Glob patterns of directories, which are skipped when discovering the packages of the
+     * project. Patterns without a slash are matched against the name of a directory, others
+     * against its path relative to the top level.
+     */
+    val ignore: List<String>,
+
+    /**
+     * Links calls of methods through an interface to all implementations of the method in the
+     * loaded packages. This requires [typeCheck].
+     */
+    val interfaceDispatch: Boolean,
+
+    /**
+     * Nests the namespace of a package in one namespace per segment of its path, e.g.
+     * `github.com/org/repo` in `github.com/org` and `github.com`, similar to the packages of Java.
+     * Otherwise, there is a single namespace named after the package path.
+     */
+    val nestedNamespaces: Boolean,
+
+    /**
+     * Releases the syntax tree of a file, as well as the type information of its nodes, once the
+     * file was translated. This reduces the memory consumption for large projects. A file that is
+     * translated again afterwards is parsed on its own, without type information.
+     */
+    val releaseSyntax: Boolean,
+
+    /**
+     * Follows symbolic links to directories, when discovering the packages of the project. Links
+     * pointing outside of the top level, as well as cycles of links, are never followed.
+     */
+    val followSymlinks: Boolean,
+
+    /**
+     * The minimum level of the messages of the native part of the frontend, which are logged, i.e.
+     * `debug`, `info`, `warn`, `error` or `off`. Other messages are dropped before they reach the
+     * JVM, which spares a lot of calls into the JVM for large projects.
+     */
+    val logLevel: String,
+
+    /**
+     * Skips generated files, i.e., the ones marked by a `// Code generated ... DO NOT EDIT.`
+     * comment, such as the output of protoc, mockgen or stringer. They are still type checked,
+     * but only result in an empty translation unit. Otherwise, they are translated and marked as
+     * generated.
+     */
+    val skipGenerated: Boolean,
+
+    /**
+     * The sources of files, keyed by their absolute path, which differ from the files on disk,
+     * e.g. the unsaved buffers of an editor. These files are translated with these sources, and all
+     * packages are loaded with them, so that the type information reflects the actual content.
+     */
+    val overlay: Map<String, String>
+) {
+    class Builder(
+        var includeTests: Boolean = false,
+        var buildTags: MutableList<String> = mutableListOf(),
+        var goos: String = "",
+        var goarch: String = "",
+        var vendor: Boolean = false,
+        var translateVendor: Boolean = false,
+        var typeCheck: Boolean = true,
+        var symbolLimit: Int = 0,
+        var dependencies: MutableList<String> = mutableListOf(),
+        var ignore: MutableList<String> = DEFAULT_IGNORE.toMutableList(),
+        var interfaceDispatch: Boolean = false,
+        var nestedNamespaces: Boolean = false,
+        var releaseSyntax: Boolean = false,
+        var followSymlinks: Boolean = false,
+        var logLevel: String = "info",
+        var skipGenerated: Boolean = false,
+        var overlay: MutableMap<String, String> = mutableMapOf()
+    ) {
+        fun includeTests(include: Boolean) = apply { this.includeTests = include }
+        fun buildTag(tag: String) = apply { this.buildTags.add(tag) }
+        fun goos(goos: String) = apply { this.goos = goos }
+        fun goarch(goarch: String) = apply { this.goarch = goarch }
+        fun vendor(vendor: Boolean) = apply { this.vendor = vendor }
+        fun translateVendor(translate: Boolean) = apply { this.translateVendor = translate }
+        fun typeCheck(typeCheck: Boolean) = apply { this.typeCheck = typeCheck }
+        fun symbolLimit(limit: Int) = apply { this.symbolLimit = limit }
+        fun dependency(pattern: String) = apply { this.dependencies.add(pattern) }
+        fun ignore(pattern: String) = apply { this.ignore.add(pattern) }
+        fun interfaceDispatch(dispatch: Boolean) = apply { this.interfaceDispatch = dispatch }
+        fun nestedNamespaces(nested: Boolean) = apply { this.nestedNamespaces = nested }
+        fun releaseSyntax(release: Boolean) = apply { this.releaseSyntax = release }
+        fun followSymlinks(follow: Boolean) = apply { this.followSymlinks = follow }
+        fun logLevel(level: String) = apply { this.logLevel = level }
+        fun skipGenerated(skip: Boolean) = apply { this.skipGenerated = skip }
+        fun overlay(file: File, source: String) = apply { this.overlay[file.absolutePath] = source }
+        fun build() =
+            GoConfiguration(
+                includeTests,
+                buildTags.toList(),
+                goos,
+                goarch,
+                vendor,
+                translateVendor,
+                typeCheck,
+                symbolLimit,
+                dependencies.toList(),
+                ignore.toList(),
+                interfaceDispatch,
+                nestedNamespaces,
+                releaseSyntax,
+                followSymlinks,
+                logLevel,
+                skipGenerated,
+                overlay.toMap()
+            )
+    }
+
+    /**
+     * Returns the JSON representation, which is passed to the native part of the frontend. If
+     * specified, it includes the [files] selected for translation, so that the native part does not
+     * need to discover the packages of the project by itself.
+     */
+    @JvmOverloads
+    fun toJson(files: List<String> = listOf()): String {
+        val node = mapper.valueToTree<ObjectNode>(this)
+
+        if (files.isNotEmpty()) {
+            node.putPOJO("files", files)
+        }
+
+        return mapper.writeValueAsString(node)
+    }
+
+    companion object {
+        private val mapper = jacksonObjectMapper()
+
+        /**
+         * The directories, which are ignored by default: testdata, hidden directories and the ones
+         * starting with an underscore (like the go command does), as well as node_modules.
+         */
+        @JvmField val DEFAULT_IGNORE = listOf("testdata", ".*", "_*", "node_modules")
+
+        @JvmStatic
+        fun builder(): Builder {
+            return Builder()
+        }
+    }
+
+    override fun toString(): String {
+        return ToStringBuilder(this, ToStringStyle.JSON_STYLE)
+            .append("includeTests", includeTests)
+            .append("buildTags", buildTags)
+            .append("goos", goos)
+            .append("goarch", goarch)
+            .append("vendor", vendor)
+            .append("translateVendor", translateVendor)
+            .append("typeCheck", typeCheck)
+            .append("symbolLimit", symbolLimit)
+            .append("dependencies", dependencies)
+            .append("ignore", ignore)
+            .append("interfaceDispatch", interfaceDispatch)
+            .append("nestedNamespaces", nestedNamespaces)
+            .append("releaseSyntax", releaseSyntax)
+            .append("followSymlinks", followSymlinks)
+            .append("logLevel", logLevel)
+            .append("skipGenerated", skipGenerated)
+            .append("overlay", overlay.keys)
+            .toString()
+    }
+}
@@ -27,14 +27,18 @@ package de.fraunhofer.aisec.cpg.frontends.golang
 
 import de.fraunhofer.aisec.cpg.TranslationConfiguration
 import de.fraunhofer.aisec.cpg.frontends.*
+import de.fraunhofer.aisec.cpg.frontends.HasComputedCallees
 import de.fraunhofer.aisec.cpg.frontends.HasFunctionPointers
 import de.fraunhofer.aisec.cpg.frontends.HasImplicitInterfaces
 import de.fraunhofer.aisec.cpg.frontends.HasNoClassScope
 import de.fraunhofer.aisec.cpg.frontends.HasNoMultipleFunctionNames
+import de.fraunhofer.aisec.cpg.frontends.HasQualifiedNamespaces
 import de.fraunhofer.aisec.cpg.frontends.HasShortCircuitOperators
+import de.fraunhofer.aisec.cpg.frontends.HasSliceExpressions
 import de.fraunhofer.aisec.cpg.graph.declarations.*
 import de.fraunhofer.aisec.cpg.graph.newVariableDeclaration
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.LambdaExpression
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.MemberCallExpression
 import de.fraunhofer.aisec.cpg.graph.types.PointerType
 import de.fraunhofer.aisec.cpg.graph.types.Type
@@ -56,7 +60,10 @@ open class GoLanguage :
     HasImplicitInterfaces,
     HasNoClassScope,
     HasFunctionPointers,
-    HasNoMultipleFunctionNames {
+    HasNoMultipleFunctionNames,
+    HasComputedCallees,
+    HasSliceExpressions,
+    HasQualifiedNamespaces {
     override val fileExtensions = listOf("go")
     override val namespaceDelimiter = "."
     override val frontend: KClass<out GoLanguageFrontend> = GoLanguageFrontend::class
@@ -64,6 +71,12 @@ open class GoLanguage :
     override val disjunctiveOperators = listOf("||")
     val log = LoggerFactory.getLogger(GoLanguage::class.java)
 
+    /** The configuration of the [GoLanguageFrontend]. */
+    var configuration: GoConfiguration = GoConfiguration.builder().build()
+
+    /** Receives the progress of the [GoLanguageFrontend], if set. */
+    var progressListener: GoProgressListener? = null
+
     override fun newFrontend(
         config: TranslationConfiguration,
         scopeManager: ScopeManager
@@ -223,6 +236,13 @@ open class GoLanguage :
         scopeManager: ScopeManager,
         currentTU: TranslationUnitDeclaration
     ) {
+        // an immediately invoked function literal calls exactly this function
+        val callee = call.callee
+        if (callee is LambdaExpression) {
+            call.invokes = listOfNotNull(callee.function)
+            return
+        }
+
         val invocationCandidates = scopeManager.resolveFunction(call)
 
         call.invokes = invocationCandidates
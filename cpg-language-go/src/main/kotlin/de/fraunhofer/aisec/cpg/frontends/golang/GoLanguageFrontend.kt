@@ -25,25 +25,48 @@
  */
 package de.fraunhofer.aisec.cpg.frontends.golang
 
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
 import de.fraunhofer.aisec.cpg.TranslationConfiguration
 import de.fraunhofer.aisec.cpg.frontends.Language
 import de.fraunhofer.aisec.cpg.frontends.LanguageFrontend
 import de.fraunhofer.aisec.cpg.frontends.SupportsParallelParsing
 import de.fraunhofer.aisec.cpg.frontends.TranslationException
+import de.fraunhofer.aisec.cpg.graph.Node
 import de.fraunhofer.aisec.cpg.graph.declarations.TranslationUnitDeclaration
 import de.fraunhofer.aisec.cpg.passes.FunctionPointerCallResolver
+import de.fraunhofer.aisec.cpg.passes.ResolveGoChannelFlows
+import de.fraunhofer.aisec.cpg.passes.ResolveGoDeferredCalls
 import de.fraunhofer.aisec.cpg.passes.ResolveGoEmbeddedMembers
+import de.fraunhofer.aisec.cpg.passes.ResolveGoInterfaceDispatch
 import de.fraunhofer.aisec.cpg.passes.ResolveGoInterfaceImplementations
+import de.fraunhofer.aisec.cpg.passes.ResolveGoNonReturningCalls
+import de.fraunhofer.aisec.cpg.passes.ResolveGoORMModels
+import de.fraunhofer.aisec.cpg.passes.ResolveGoPackageInitialization
+import de.fraunhofer.aisec.cpg.passes.ResolveGoTupleResults
 import de.fraunhofer.aisec.cpg.passes.order.RegisterExtraPass
 import de.fraunhofer.aisec.cpg.passes.scopes.ScopeManager
 import de.fraunhofer.aisec.cpg.sarif.PhysicalLocation
+import de.fraunhofer.aisec.cpg.sarif.Region
 import java.io.File
 import java.io.FileOutputStream
+import java.net.URI
+import java.nio.ByteBuffer
+import java.nio.file.Path
+import java.util.Collections
+import java.util.WeakHashMap
+import kotlin.math.max
 
 @SupportsParallelParsing(false)
 @RegisterExtraPass(ResolveGoEmbeddedMembers::class)
 @RegisterExtraPass(ResolveGoInterfaceImplementations::class)
 @RegisterExtraPass(FunctionPointerCallResolver::class)
+@RegisterExtraPass(ResolveGoInterfaceDispatch::class)
+@RegisterExtraPass(ResolveGoNonReturningCalls::class)
+@RegisterExtraPass(ResolveGoDeferredCalls::class)
+@RegisterExtraPass(ResolveGoTupleResults::class)
+@RegisterExtraPass(ResolveGoChannelFlows::class)
+@RegisterExtraPass(ResolveGoPackageInitialization::class)
+@RegisterExtraPass(ResolveGoORMModels::class)
 class GoLanguageFrontend(
     language: Language<GoLanguageFrontend>,
     config: TranslationConfiguration,
@@ -51,8 +74,20 @@ class GoLanguageFrontend(
 ) : LanguageFrontend(language, config, scopeManager) {
     companion object {
         @JvmField var GOLANG_EXTENSIONS: List<String> = listOf(".go")
-        var activeTranslationUnits = mutableMapOf<String, TranslationUnitDeclaration>()
-        var currentScopeManager: ScopeManager? = null
+
+        /**
+         * The translation units of the files, which were loaded by the native part of the
+         * frontend, keyed by the scope manager of their translation, so that several translations
+         * can run concurrently. The one of the predeclared functions is among them as well, so that
+         * they are only declared once per translation. Accesses to it are synchronized on the map
+         * itself, but no lock is held while calling into the native part, which calls back into the
+         * frontend.
+         */
+        private val translations =
+            WeakHashMap<ScopeManager, MutableMap<String, TranslationUnitDeclaration>>()
+
+        /** The initial capacity of the buffer, which holds the metadata of a node. */
+        private const val METADATA_CAPACITY = 4096
 
         init {
             try {
@@ -88,16 +123,85 @@ class GoLanguageFrontend(
                 )
             }
         }
+
+        /**
+         * Cancels the translations, which are currently running in the native part of the
+         * frontend. They are aborted cooperatively, i.e., after the current step, with a
+         * [TranslationException]. All remaining files of a translation fail as well, until the
+         * state of its project is reset by the next translation. If no translation has started
+         * since then, this has no effect.
+         */
+        @JvmStatic external fun cancel()
+
+        /**
+         * Returns the statistics of the translation of the project with the given [topLevel]
+         * path, if any of its files was translated since the state of the project was last reset.
+         */
+        @JvmStatic
+        fun metrics(topLevel: File): GoMetrics? {
+            return getMetricsInternal(topLevel.absolutePath)?.let { GoMetrics.fromJson(it) }
+        }
+
+        @JvmStatic private external fun getMetricsInternal(topLevel: String): String?
     }
 
+    /** The translation units of the files of the current translation, see [translations]. */
+    private val activeTranslationUnits: MutableMap<String, TranslationUnitDeclaration>
+
     init {
-        if (scopeManager != currentScopeManager) {
-            activeTranslationUnits = mutableMapOf<String, TranslationUnitDeclaration>()
+        var started = false
+
+        activeTranslationUnits =
+            synchronized(translations) {
+                translations.getOrPut(scopeManager) {
+                    started = true
+                    Collections.synchronizedMap(mutableMapOf())
+                }
+            }
+
+        if (started) {
             resetState()
-            currentScopeManager = scopeManager
         }
     }
 
+    /**
+     * Resets the state of the projects of a new translation in the native part of the frontend,
+     * but not the one of other projects, which might be translated concurrently. The files are
+     * only passed once for the whole translation, instead of with every file.
+     */
+    private fun resetState() {
+        val files = files()
+        val json = jacksonObjectMapper().writeValueAsString(files)
+
+        // without a top level path, each file is translated as part of its directory, see parse
+        val topLevels =
+            config.topLevel?.let { listOf(it.absolutePath) }
+                ?: files.map { File(it).parent }.distinct()
+
+        for (topLevel in topLevels) {
+            resetState(topLevel, json)
+        }
+    }
+
+    /**
+     * Returns the Go files of all software components, which are passed to the native part of the
+     * frontend, so that it does not need to walk through the whole project to discover its
+     * packages.
+     */
+    private fun files(): List<String> {
+        return config.softwareComponents.values
+            .flatten()
+            .flatMap { file ->
+                if (file.isDirectory) {
+                    file.walkTopDown().filter { it.isFile && it.extension == "go" }.toList()
+                } else {
+                    listOf(file).filter { it.extension == "go" }
+                }
+            }
+            .map { it.absolutePath }
+            .distinct()
+    }
+
     fun addActiveTranslationUnit(fname: String, tu: TranslationUnitDeclaration) {
         activeTranslationUnits.set(fname, tu)
     }
@@ -107,12 +211,109 @@ class GoLanguageFrontend(
         return u
     }
 
+    /**
+     * Adds the [tu] of a dependency, which is not among the source locations, to the result, so
+     * that its declarations are processed by the passes like the ones of the project.
+     */
+    fun addDependencyTranslationUnit(tu: TranslationUnitDeclaration) {
+        additionalTranslationUnits += tu
+    }
+
+    /**
+     * The code and location of the next node, which is created by the native part of the
+     * frontend, see [metadataBuffer].
+     */
+    private var metadata: ByteBuffer? = null
+
+    /** The URIs of the files, in which the nodes are located. */
+    private val uris = mutableMapOf<String, URI>()
+
+    /**
+     * Returns the direct buffer, which holds the code and, optionally, the file and region
+     * (including byte offsets) of the next node, which is created by the native part of the
+     * frontend. It is shared with the native part, which writes them without any JNI call, and
+     * only requests the buffer once per file, or again, if it needs at least [size] bytes. The data
+     * is preceded by its length, which is reset, once the data is applied. If the location was
+     * remapped by a `//line` directive, it is followed by the location in the file, from which the
+     * node was actually parsed.
+     */
+    fun metadataBuffer(size: Int): ByteBuffer {
+        val buffer = metadata
+        if (buffer != null && buffer.capacity() >= size) {
+            return buffer
+        }
+
+        // the buffer at least doubles, so that it rarely needs to grow
+        val capacity = max(size, 2 * (buffer?.capacity() ?: METADATA_CAPACITY))
+
+        return ByteBuffer.allocateDirect(capacity).also { metadata = it }
+    }
+
+    /**
+     * Applies the [metadata] of the native part of the frontend to [cpgNode] while it is created
+     * by one of the node builders. This way, the location is already set before the node is added
+     * to any scope or collection, which relies on its [Node.hashCode].
+     */
+    override fun <N, S> setCodeAndLocation(cpgNode: N, astNode: S?) {
+        val buffer = metadata
+        if (cpgNode !is Node || buffer == null || buffer.getInt(0) == 0) {
+            return
+        }
+
+        buffer.putInt(0, 0)
+        buffer.position(Int.SIZE_BYTES)
+
+        cpgNode.code = buffer.getMetadataString()
+
+        if (buffer.getBoolean()) {
+            cpgNode.location = buffer.getLocation()
+
+            if (buffer.getBoolean()) {
+                cpgNode.physicalLocation = buffer.getLocation()
+            }
+        }
+    }
+
+    /**
+     * Reports the progress of the native part of the frontend to the
+     * [GoLanguage.progressListener]. The [phase] is the name of one of the
+     * [GoProgressListener.Phase]s.
+     */
+    fun reportProgress(phase: String, done: Int, total: Int) {
+        (language as GoLanguage)
+            .progressListener
+            ?.onProgress(GoProgressListener.Phase.valueOf(phase), done, total)
+    }
+
+    private fun ByteBuffer.getLocation(): PhysicalLocation {
+        val file = getMetadataString()
+        // the file is a native path, e.g. with a drive letter on Windows
+        val uri = uris.getOrPut(file) { Path.of(file).toUri() }
+        val region = Region(getInt(), getInt(), getInt(), getInt(), getInt(), getInt())
+
+        return PhysicalLocation(uri, region)
+    }
+
+    private fun ByteBuffer.getMetadataString(): String {
+        val bytes = ByteArray(getInt())
+        get(bytes)
+
+        return String(bytes, Charsets.UTF_8)
+    }
+
+    private fun ByteBuffer.getBoolean(): Boolean {
+        return get() != 0.toByte()
+    }
+
     @Throws(TranslationException::class)
     override fun parse(file: File): TranslationUnitDeclaration {
+        val configuration = (language as GoLanguage).configuration
+
         return parseInternal(
-            file.readText(Charsets.UTF_8),
+            configuration.overlay[file.absolutePath] ?: file.readText(Charsets.UTF_8),
             file.path,
-            config.topLevel?.absolutePath ?: file.parent
+            config.topLevel?.absolutePath ?: file.parent,
+            configuration.toJson()
         )
     }
 
@@ -131,8 +332,9 @@ class GoLanguageFrontend(
     private external fun parseInternal(
         s: String?,
         path: String,
-        topLevel: String
+        topLevel: String,
+        configuration: String
     ): TranslationUnitDeclaration
 
-    private external fun resetState()
+    private external fun resetState(topLevel: String, files: String)
 }
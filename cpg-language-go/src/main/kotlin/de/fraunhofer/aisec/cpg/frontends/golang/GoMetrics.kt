@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.frontends.golang
+
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
+import com.fasterxml.jackson.module.kotlin.readValue
+
+/**
+ * The statistics of the translation of a project by the [GoLanguageFrontend]. They show which
+ * kinds of nodes are not supported yet and where they occur, as well as where the time was spent.
+ * The names of the kinds are the ones of the `go/ast` package, e.g. `CallExpr`.
+ */
+data class GoMetrics(
+    /** How often each kind of node was translated. */
+    val handled: Map<String, Int> = mapOf(),
+
+    /** How often each kind of node was encountered, which is not supported. */
+    val unhandled: Map<String, Int> = mapOf(),
+
+    /** The positions of some of the unsupported nodes of each kind. */
+    val samples: Map<String, List<String>> = mapOf(),
+
+    /** The time spent in each phase of the translation, e.g. `load`, in milliseconds. */
+    val phases: Map<String, Long> = mapOf()
+) {
+    companion object {
+        private val mapper = jacksonObjectMapper()
+
+        /** Parses the JSON representation, which is created by the native part of the frontend. */
+        @JvmStatic
+        fun fromJson(json: String): GoMetrics {
+            return mapper.readValue(json)
+        }
+    }
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.frontends.golang
+
+/**
+ * Receives the progress of the native part of the [GoLanguageFrontend], which loads all packages
+ * of a project at once, before any file is translated. This may take a while for large projects.
+ */
+fun interface GoProgressListener {
+    /** The phases of a translation, in which files are counted. */
+    enum class Phase {
+        /** Files, which were discovered in the project. */
+        DISCOVERED,
+        /** Files, whose package was loaded and whose records were declared. */
+        LOADED,
+        /** Files, which were translated completely. */
+        TRANSLATED
+    }
+
+    /**
+     * Called once [done] out of [total] files were handled in [phase]. A [total] of zero means
+     * that it is not known yet.
+     */
+    fun onProgress(phase: Phase, done: Int, total: Int)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.frontends.golang
+
+import de.fraunhofer.aisec.cpg.graph.types.TupleType
+import de.fraunhofer.aisec.cpg.graph.types.Type
+
+/**
+ * The type of the multiple results of a call in Go, which can be destructured. It is named like the
+ * result list of a function signature, e.g. `(int, error)`, which is kept when it is duplicated.
+ */
+class GoTupleType(types: List<Type>) : TupleType(types) {
+    override fun refreshNames() {
+        name = elementTypes.joinToString(", ", "(", ")") { it.name }
+    }
+
+    override fun duplicate(): Type {
+        return GoTupleType(ArrayList(elementTypes))
+    }
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.ValueDeclaration
+import de.fraunhofer.aisec.cpg.graph.declarations.VariableDeclaration
+import de.fraunhofer.aisec.cpg.graph.statements.ForEachStatement
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.BinaryOperator
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.DeclaredReferenceExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.UnaryOperator
+import de.fraunhofer.aisec.cpg.graph.types.ObjectType
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.ExecuteBefore
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Connects the values sent to a channel, e.g. `ch <- x`, to the receives from the same channel,
+ * i.e. `<-ch` and `for v := range ch`. Otherwise, the data passed between goroutines would not be
+ * part of the DFG at all.
+ *
+ * Channels are identified by the declaration of the variable, parameter or field holding them.
+ * Since a channel is usually created in one function and passed to others, e.g. in `go
+ * worker(ch)`, the declarations are merged if a channel is passed as argument to a resolved call
+ * or assigned to another variable. Channels, which are returned by functions or stored in other
+ * data structures, are not tracked.
+ *
+ * This is an over-approximation: every value sent to a channel flows into every receive from it,
+ * regardless of the direction of the channel types involved, the order of the operations and
+ * whether the goroutine of the receive can be reached from the one of the send at all. For
+ * example, two independent calls of `worker(ch)` with different channels share the parameter of
+ * `worker`, so their sends and receives are connected with each other as well. Therefore, the
+ * resulting flows may not be feasible, but no flow through a tracked channel is missed.
+ */
+@DependsOn(DFGPass::class)
+@DependsOn(ResolveGoInterfaceDispatch::class)
+@ExecuteBefore(ControlFlowSensitiveDFGPass::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoChannelFlows : Pass() {
+    /** The representative of the merged declarations of each channel. */
+    private val channels = mutableMapOf<ValueDeclaration, ValueDeclaration>()
+
+    override fun accept(t: TranslationResult) {
+        for (tu in t.translationUnits) {
+            for (call in tu.allChildren<CallExpression>()) {
+                for (function in call.invokes) {
+                    for ((arg, param) in call.arguments.zip(function.parameters)) {
+                        merge(channelOf(arg), param)
+                    }
+                }
+            }
+
+            for (variable in tu.allChildren<VariableDeclaration>()) {
+                merge(channelOf(variable.initializer), variable)
+            }
+
+            for (assign in tu.allChildren<BinaryOperator> { it.operatorCode == "=" }) {
+                merge(channelOf(assign.rhs), channelOf(assign.lhs))
+            }
+        }
+
+        val sends = mutableMapOf<ValueDeclaration, MutableList<Node>>()
+        val receives = mutableMapOf<ValueDeclaration, MutableList<Node>>()
+
+        for (tu in t.translationUnits) {
+            for (send in tu.allChildren<BinaryOperator> { it.operatorCode == "<-" }) {
+                val channel = channelOf(send.lhs) ?: continue
+                sends.getOrPut(find(channel)) { mutableListOf() } += send.rhs
+            }
+
+            for (receive in tu.allChildren<UnaryOperator> { it.operatorCode == "<-" }) {
+                val channel = channelOf(receive.input) ?: continue
+                receives.getOrPut(find(channel)) { mutableListOf() } += receive
+            }
+
+            // The iterable flows into the variables of the loop
+            for (loop in tu.allChildren<ForEachStatement>()) {
+                val iterable = loop.iterable ?: continue
+                val channel = channelOf(iterable) ?: continue
+                receives.getOrPut(find(channel)) { mutableListOf() } += iterable
+            }
+        }
+
+        for ((channel, values) in sends) {
+            for (receive in receives[channel].orEmpty()) {
+                values.forEach { receive.addPrevDFG(it) }
+            }
+        }
+    }
+
+    /** Returns the declaration of the channel [node] refers to, if it is a channel at all. */
+    private fun channelOf(node: Node?): ValueDeclaration? {
+        val declaration = (node as? DeclaredReferenceExpression)?.refersTo as? ValueDeclaration
+        return declaration?.takeIf { isChannel(it) }
+    }
+
+    private fun isChannel(declaration: ValueDeclaration): Boolean {
+        return (declaration.type as? ObjectType)?.name == "chan"
+    }
+
+    private fun find(declaration: ValueDeclaration): ValueDeclaration {
+        var root = declaration
+        while (true) {
+            root = channels[root]?.takeIf { it !== root } ?: return root
+        }
+    }
+
+    private fun merge(a: ValueDeclaration?, b: ValueDeclaration?) {
+        if (a == null || b == null || !isChannel(b)) {
+            return
+        }
+
+        val rootA = find(a)
+        val rootB = find(b)
+        if (rootA !== rootB) {
+            channels[rootB] = rootA
+        }
+    }
+
+    override fun cleanup() {
+        channels.clear()
+    }
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
+import de.fraunhofer.aisec.cpg.graph.edge.Properties
+import de.fraunhofer.aisec.cpg.graph.edge.PropertyEdge
+import de.fraunhofer.aisec.cpg.graph.statements.ReturnStatement
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.UnaryOperator
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.ExecuteBefore
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Moves the deferred calls of a function to its exits in the EOG. Go evaluates the function value
+ * and the arguments of a deferred call at the `defer` statement, but the call itself is executed
+ * once the function returns, in the reverse order in which the calls were deferred.
+ *
+ * The [EvaluationOrderGraphPass] treats the `defer` operator like any other unary operator, so the
+ * call is first removed from the EOG at the `defer` statement. Afterwards, every return statement
+ * and the end of the function body are connected to the deferred calls in LIFO order. Since we do
+ * not know whether a conditional `defer` statement was executed, all deferred calls of a function
+ * are assumed to be executed at every exit, including the calls unwinding the stack, see
+ * [ResolveGoNonReturningCalls].
+ */
+@DependsOn(EvaluationOrderGraphPass::class)
+@ExecuteBefore(ControlFlowSensitiveDFGPass::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoDeferredCalls : Pass() {
+    override fun accept(t: TranslationResult) {
+        for (tu in t.translationUnits) {
+            for (function in tu.allChildren<FunctionDeclaration>()) {
+                handleFunction(function)
+            }
+        }
+    }
+
+    private fun handleFunction(function: FunctionDeclaration) {
+        val body = function.body ?: return
+
+        // Deferred calls and return statements of function literals belong to the literal
+        val nested =
+            body.allChildren<FunctionDeclaration>().flatMap { it.allChildren<Node>() }.toSet()
+        val calls =
+            body
+                .allChildren<UnaryOperator> { it.operatorCode == "defer" && it !in nested }
+                .mapNotNull { removeFromEOG(it) }
+        if (calls.isEmpty()) {
+            return
+        }
+
+        // Calls that unwind the stack, e.g. of panic, run the deferred calls as well
+        val spawned = ResolveGoNonReturningCalls.spawnedCalls(body.allChildren<UnaryOperator>())
+        val unwinding =
+            body.allChildren<CallExpression> {
+                it !in nested && it !in spawned && ResolveGoNonReturningCalls.unwinds(it)
+            }
+
+        // The body itself is the last node of the EOG, if the function does not end with a
+        // return statement
+        val exits =
+            (body.allChildren<ReturnStatement> { it !in nested } + unwinding + body).filter {
+                it.prevEOG.isNotEmpty()
+            }
+        val ordered = calls.reversed()
+
+        for (exit in exits) {
+            addEOGEdge(exit, ordered.first())
+        }
+
+        ordered.zipWithNext().forEach { (prev, next) -> addEOGEdge(prev, next) }
+    }
+
+    /**
+     * Removes the deferred call of [defer] from the EOG, so that the nodes evaluated before the
+     * call are directly followed by [defer]. Returns the call, if there is one.
+     */
+    private fun removeFromEOG(defer: UnaryOperator): CallExpression? {
+        val call = defer.input as? CallExpression ?: return null
+        val prevs = call.prevEOG
+
+        for (edge in call.nextEOGEdges) {
+            edge.end.prevEOGEdges.removeIf { it.start === call }
+        }
+        for (edge in call.prevEOGEdges) {
+            edge.start.nextEOGEdges.removeIf { it.end === call }
+        }
+        call.nextEOGEdges.clear()
+        call.prevEOGEdges.clear()
+
+        prevs.forEach { addEOGEdge(it, defer) }
+
+        return call
+    }
+
+    private fun addEOGEdge(prev: Node, next: Node) {
+        val propertyEdge = PropertyEdge(prev, next)
+        propertyEdge.addProperty(Properties.INDEX, prev.nextEOG.size)
+        propertyEdge.addProperty(Properties.UNREACHABLE, false)
+        prev.addNextEOG(propertyEdge)
+        next.addPrevEOG(propertyEdge)
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.DeclaredReferenceExpression
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Adds the implementations of a method to the invoked functions of a call of the method through an
+ * interface. Since the [CallResolver] only resolves such a call to the method of the interface (if
+ * at all), a call would otherwise never reach the concrete implementations.
+ *
+ * The candidates are determined by the native part of the [GoLanguageFrontend] using the type
+ * checker, if the `interfaceDispatch` option of the
+ * [de.fraunhofer.aisec.cpg.frontends.golang.GoConfiguration] is enabled. They are stored in the
+ * `dispatch` annotation of the call, whose `target` members refer to the implementations.
+ */
+@DependsOn(CallResolver::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoInterfaceDispatch : Pass() {
+    override fun accept(t: TranslationResult) {
+        for (tu in t.translationUnits) {
+            for (call in tu.allChildren<CallExpression>()) {
+                val dispatch = call.annotations.firstOrNull { it.name == "dispatch" } ?: continue
+
+                val targets =
+                    dispatch.members
+                        .filter { it.name == "target" }
+                        .mapNotNull {
+                            (it.value as? DeclaredReferenceExpression)?.refersTo
+                                as? FunctionDeclaration
+                        }
+
+                call.invokes = (call.invokes + targets).distinct()
+            }
+        }
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.Literal
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.UnaryOperator
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.ExecuteBefore
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Removes the fall-through of calls, which never return to their caller, from the EOG, so that the
+ * nodes following such a call are no longer reachable through it. These calls, e.g. of `panic`,
+ * `os.Exit` or `t.Fatal`, are marked by the `noreturn` annotation of the native part of the
+ * [GoLanguageFrontend].
+ *
+ * A call that unwinds the stack, i.e. of the kind `panic` or `goexit`, still runs the deferred
+ * calls of its function. Therefore, [ResolveGoDeferredCalls] treats it as an exit of the function.
+ */
+@DependsOn(EvaluationOrderGraphPass::class)
+@ExecuteBefore(ResolveGoDeferredCalls::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoNonReturningCalls : Pass() {
+    override fun accept(t: TranslationResult) {
+        for (tu in t.translationUnits) {
+            // Deferred calls and goroutines are not executed in place
+            val spawned = spawnedCalls(tu.allChildren<UnaryOperator>())
+            val calls = tu.allChildren<CallExpression> { exitKind(it) != null && it !in spawned }
+
+            for (call in calls) {
+                for (edge in call.nextEOGEdges) {
+                    edge.end.prevEOGEdges.removeIf { it.start === call }
+                }
+                call.nextEOGEdges.clear()
+            }
+        }
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+
+    companion object {
+        /** Returns the kind of the exit of [call], if it never returns. */
+        fun exitKind(call: CallExpression): String? {
+            val noreturn = call.annotations.firstOrNull { it.name == "noreturn" } ?: return null
+            return (noreturn.members.firstOrNull { it.name == "kind" }?.value as? Literal<*>)
+                ?.value as? String
+        }
+
+        /** Returns, whether [call] unwinds the stack, running the deferred calls. */
+        fun unwinds(call: CallExpression): Boolean {
+            return exitKind(call) == "panic" || exitKind(call) == "goexit"
+        }
+
+        /** Returns the calls of the `defer` and `go` statements among [operators]. */
+        fun spawnedCalls(operators: List<UnaryOperator>): Set<CallExpression> {
+            return operators
+                .filter { it.operatorCode == "defer" || it.operatorCode == "go" }
+                .mapNotNull { it.input as? CallExpression }
+                .toSet()
+        }
+    }
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.Annotation
+import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.FieldDeclaration
+import de.fraunhofer.aisec.cpg.graph.declarations.RecordDeclaration
+import de.fraunhofer.aisec.cpg.graph.newAnnotation
+import de.fraunhofer.aisec.cpg.graph.newAnnotationMember
+import de.fraunhofer.aisec.cpg.graph.newLiteral
+import de.fraunhofer.aisec.cpg.graph.parseType
+import de.fraunhofer.aisec.cpg.graph.statements.ReturnStatement
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.Literal
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.TypeExpression
+import de.fraunhofer.aisec.cpg.graph.types.ObjectType
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Links the models of object-relational mappers (ORMs) to the tables and columns of the database.
+ * The frontend marks the calls of an ORM, which refer to models, such as `db.AutoMigrate(&User{})`
+ * of GORM, with an `orm` annotation, which contains the framework and the types of the models.
+ *
+ * The record of each model receives a `table` annotation, whose members contain the `framework`
+ * and the `name` of the table, if it is known. Each field, which is stored in a column, receives a
+ * `column` annotation, whose members contain the `framework`, the `table` and the `name` of the
+ * column. The names follow the struct tags of the fields, e.g. `gorm:"column:email"`, or the naming
+ * conventions of the framework otherwise. The fields of embedded structs are stored in the table of
+ * the embedding model.
+ */
+@DependsOn(TypeResolver::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoORMModels : Pass() {
+    override fun accept(t: TranslationResult) {
+        val models = mutableSetOf<Pair<RecordDeclaration, String>>()
+
+        for (call in t.translationUnits.flatMap { it.allChildren<CallExpression>() }) {
+            val orm = call.annotations.firstOrNull { it.name == "orm" } ?: continue
+            val framework = orm.stringMember("framework") ?: continue
+            val table = orm.stringMember("table")
+
+            for (member in orm.members.filter { it.name == "model" }) {
+                val type = (member.value as? TypeExpression)?.type?.root as? ObjectType
+                val record = type?.recordDeclaration ?: continue
+
+                // each model is only linked once per framework
+                if (models.add(record to framework)) {
+                    linkModel(record, framework, table)
+                }
+            }
+        }
+    }
+
+    /** Adds the `table` and `column` annotations to the model [record] of the [framework]. */
+    private fun linkModel(record: RecordDeclaration, framework: String, declaredTable: String?) {
+        val table = declaredTable ?: tableName(record, framework)
+
+        record.addAnnotations(
+            listOf(record.newStringAnnotation("table", "framework" to framework, "name" to table))
+        )
+
+        for ((field, column) in columns(record, framework, mutableSetOf())) {
+            field.addAnnotations(
+                listOf(
+                    field.newStringAnnotation(
+                        "column",
+                        "framework" to framework,
+                        "table" to table,
+                        "name" to column
+                    )
+                )
+            )
+        }
+    }
+
+    /**
+     * Returns the table of the model [record]. GORM and bun allow to override the table, with a
+     * `TableName` method and a `bun:"table:..."` tag respectively, and otherwise derive it from the
+     * name of the model, e.g. `user_profiles` for `UserProfile`. sqlx does not map models to tables
+     * at all.
+     */
+    private fun tableName(record: RecordDeclaration, framework: String): String? {
+        when (framework) {
+            "sqlx" -> return null
+            "gorm" -> {
+                val tableName = record.methods.firstOrNull { it.name == "TableName" }
+                val value =
+                    tableName?.allChildren<ReturnStatement>()?.firstNotNullOfOrNull {
+                        (it.returnValue as? Literal<*>)?.value as? String
+                    }
+
+                if (value != null) {
+                    return value
+                }
+            }
+            "bun" -> {
+                val options = record.fields.firstNotNullOfOrNull { tagOptions(it, "bun") }
+                val value = options?.firstOrNull { it.startsWith("table:") }
+
+                if (value != null) {
+                    return value.removePrefix("table:")
+                }
+            }
+        }
+
+        return pluralize(snakeCase(record.name.substringAfterLast('.')))
+    }
+
+    /**
+     * Returns the fields of the model [record], which are stored in a column, together with the
+     * name of the column. The fields of embedded structs, which are known, are included.
+     */
+    private fun columns(
+        record: RecordDeclaration,
+        framework: String,
+        seen: MutableSet<RecordDeclaration>
+    ): List<Pair<FieldDeclaration, String>> {
+        if (!seen.add(record)) {
+            return listOf()
+        }
+
+        val columns = mutableListOf<Pair<FieldDeclaration, String>>()
+
+        for (field in record.fields) {
+            val options = tagOptions(field, tagKey(framework))
+
+            // e.g. gorm:"-", which excludes the field
+            if (options?.firstOrNull() == "-") {
+                continue
+            }
+
+            if (field.isEmbeddedField) {
+                val embedded = (field.type.root as? ObjectType)?.recordDeclaration
+                if (embedded != null) {
+                    columns += columns(embedded, framework, seen)
+                }
+
+                continue
+            }
+
+            // unexported fields cannot be set by the ORM, nor does ent store its edges
+            if (field.name.firstOrNull()?.isUpperCase() != true) {
+                continue
+            }
+
+            if (framework == "ent" && field.name == "Edges") {
+                continue
+            }
+
+            columns += field to columnName(field.name, framework, options)
+        }
+
+        return columns
+    }
+
+    /** Returns the column of the field with the given [name] and [options] of its struct tag. */
+    private fun columnName(name: String, framework: String, options: List<String>?): String {
+        val tagged =
+            when (framework) {
+                // e.g. gorm:"column:email;not null"
+                "gorm" -> options?.firstOrNull { it.startsWith("column:") }?.removePrefix("column:")
+                // e.g. bun:"email,notnull" or bun:",pk", whose column is derived from the name
+                "bun" -> options?.firstOrNull()?.takeIf { ':' !in it }
+                else -> options?.firstOrNull()
+            }
+
+        if (!tagged.isNullOrEmpty()) {
+            return tagged
+        }
+
+        // sqlx maps the fields by their lower-cased name by default
+        return if (framework == "sqlx") name.lowercase() else snakeCase(name)
+    }
+
+    /** Returns the key of the struct tag, which contains the column of a field. */
+    private fun tagKey(framework: String): String =
+        when (framework) {
+            "sqlx" -> "db"
+            "ent" -> "json"
+            else -> framework
+        }
+
+    /**
+     * Returns the options of the struct tag [key] of [field], which are separated by a semicolon
+     * for GORM and by a comma otherwise.
+     */
+    private fun tagOptions(field: FieldDeclaration, key: String): List<String>? {
+        val tag = field.annotations.firstOrNull { it.name == key } ?: return null
+        val value = tag.stringMember("value") ?: return null
+
+        return value.split(if (key == "gorm") ';' else ',').map { it.trim() }
+    }
+
+    private fun Annotation.stringMember(name: String): String? =
+        (getValueForName(name) as? Literal<*>)?.value as? String
+
+    private fun Node.newStringAnnotation(
+        name: String,
+        vararg values: Pair<String, String?>
+    ): Annotation {
+        val annotation = newAnnotation(name)
+        annotation.members =
+            values
+                .filter { it.second != null }
+                .map { (key, value) ->
+                    newAnnotationMember(key, newLiteral(value, parseType("string")))
+                }
+
+        return annotation
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+
+    companion object {
+        /**
+         * Converts the name of a Go identifier to snake case, like the ORMs do, e.g. `user_id` for
+         * `UserID` and `html_body` for `HTMLBody`.
+         */
+        fun snakeCase(name: String): String {
+            val builder = StringBuilder()
+
+            for ((i, c) in name.withIndex()) {
+                if (c.isUpperCase() && i > 0) {
+                    val prev = name[i - 1]
+                    val next = name.getOrNull(i + 1)
+
+                    if (prev != '_' && (!prev.isUpperCase() || next?.isLowerCase() == true)) {
+                        builder.append('_')
+                    }
+                }
+
+                builder.append(c.lowercaseChar())
+            }
+
+            return builder.toString()
+        }
+
+        /** Returns the (regular) English plural of [noun], e.g. `categories` for `category`. */
+        fun pluralize(noun: String): String =
+            when {
+                noun.endsWith("y") && noun.length > 1 && noun[noun.length - 2] !in "aeiou" ->
+                    noun.dropLast(1) + "ies"
+                noun.endsWith("s") ||
+                    noun.endsWith("x") ||
+                    noun.endsWith("z") ||
+                    noun.endsWith("ch") ||
+                    noun.endsWith("sh") -> noun + "es"
+                else -> noun + "s"
+            }
+    }
+}
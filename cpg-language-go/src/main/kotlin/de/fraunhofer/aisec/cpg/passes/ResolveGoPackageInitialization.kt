@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.Name
+import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.Declaration
+import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
+import de.fraunhofer.aisec.cpg.graph.declarations.MethodDeclaration
+import de.fraunhofer.aisec.cpg.graph.declarations.VariableDeclaration
+import de.fraunhofer.aisec.cpg.graph.edge.Properties
+import de.fraunhofer.aisec.cpg.graph.edge.PropertyEdge
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.Literal
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.ExecuteBefore
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Connects the initialization of a Go package in the EOG. Before `main` is called, the
+ * package-level variables are initialized in the order of their dependencies, followed by the init
+ * functions in the order of the files and their declarations. Since this spans all translation
+ * units of a package, the frontend marks each part with an `initialization` annotation, which
+ * contains the package, the position of the part within its initialization and whether the package
+ * is a command, i.e. named `main`.
+ *
+ * The [EvaluationOrderGraphPass] connects the package-level variables of a file in the order of
+ * their declaration, so these edges are removed first. Afterwards, the exits of each part lead to
+ * the start of the next one and the last part of a command leads to its `main` function, whose
+ * full name is qualified by the path of the package, just like the package of the annotation. This
+ * way, init functions are no longer disconnected from the rest of the program.
+ */
+@DependsOn(EvaluationOrderGraphPass::class)
+@DependsOn(ResolveGoDeferredCalls::class)
+@ExecuteBefore(ControlFlowSensitiveDFGPass::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoPackageInitialization : Pass() {
+    override fun accept(t: TranslationResult) {
+        val declarations = t.translationUnits.flatMap { it.allChildren<Declaration>() }
+        val parts = declarations.filter { it.initializationPackage != null }
+
+        for ((pkg, unordered) in parts.groupBy { it.initializationPackage }) {
+            val ordered = unordered.sortedBy { it.initializationOrder }
+
+            // the entries and exits of all parts need to be known before any of them is connected
+            val entries = ordered.map { entry(it) }
+            val exits = entries.map { exits(it) }
+
+            for (i in 1 until ordered.size) {
+                exits[i - 1].forEach { addEOGEdge(it, entries[i]) }
+            }
+
+            if (ordered.none { it.initializationCommand }) {
+                continue
+            }
+
+            val main =
+                declarations.firstOrNull {
+                    it is FunctionDeclaration &&
+                        it !is MethodDeclaration &&
+                        it.name == "main" &&
+                        it.fullName.parent == Name(pkg ?: "")
+                }
+
+            if (main != null) {
+                exits.last().forEach { addEOGEdge(it, main) }
+            }
+        }
+    }
+
+    /**
+     * Returns the first node of [part] in the EOG. For a variable, this is the start of its
+     * initializer, which is disconnected from the variables declared before it.
+     */
+    private fun entry(part: Declaration): Node {
+        if (part !is VariableDeclaration) {
+            return part
+        }
+
+        // the variable is followed by the next variable of the file
+        part.nextEOGEdges.toList().forEach { removeEOGEdge(it) }
+
+        val initializer = part.initializer ?: return part
+
+        // Function literals have an EOG of their own
+        val nested =
+            initializer
+                .allChildren<FunctionDeclaration>()
+                .flatMap { it.allChildren<Node>() }
+                .toSet()
+        val nodes = (initializer.allChildren<Node>() + initializer).toSet() - nested
+
+        val entry =
+            nodes.firstOrNull { node ->
+                node.nextEOG.isNotEmpty() && node.prevEOG.none { it in nodes }
+            } ?: return part
+
+        entry.prevEOGEdges.toList().forEach { removeEOGEdge(it) }
+
+        return entry
+    }
+
+    /** Returns all nodes reachable from [entry] in the EOG, which are not followed by any node. */
+    private fun exits(entry: Node): List<Node> {
+        val exits = mutableListOf<Node>()
+        val seen = mutableSetOf(entry)
+        val worklist = mutableListOf(entry)
+
+        while (worklist.isNotEmpty()) {
+            val node = worklist.removeLast()
+            if (node.nextEOG.isEmpty()) {
+                exits += node
+            }
+
+            node.nextEOG.filter { seen.add(it) }.forEach { worklist += it }
+        }
+
+        return exits
+    }
+
+    private fun addEOGEdge(prev: Node, next: Node) {
+        val propertyEdge = PropertyEdge(prev, next)
+        propertyEdge.addProperty(Properties.INDEX, prev.nextEOG.size)
+        propertyEdge.addProperty(Properties.UNREACHABLE, false)
+        prev.addNextEOG(propertyEdge)
+        next.addPrevEOG(propertyEdge)
+    }
+
+    private fun removeEOGEdge(edge: PropertyEdge<Node>) {
+        edge.start.nextEOGEdges.remove(edge)
+        edge.end.prevEOGEdges.remove(edge)
+    }
+
+    /** The package, whose initialization this declaration is part of. */
+    private val Declaration.initializationPackage: String?
+        get() = initializationMember("package") as? String
+
+    /** The position of this declaration within the initialization of its package. */
+    private val Declaration.initializationOrder: Int
+        get() = (initializationMember("order") as? Number)?.toInt() ?: 0
+
+    /** Whether the package, whose initialization this declaration is part of, is a command. */
+    private val Declaration.initializationCommand: Boolean
+        get() = initializationMember("command") == true
+
+    private fun Declaration.initializationMember(name: String): Any? {
+        val annotation = annotations.firstOrNull { it.name == "initialization" }
+
+        return (annotation?.getValueForName(name) as? Literal<*>)?.value
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+}
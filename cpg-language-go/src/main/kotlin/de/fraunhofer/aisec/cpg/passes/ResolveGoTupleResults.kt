@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2023, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.passes
+
+import de.fraunhofer.aisec.cpg.TranslationResult
+import de.fraunhofer.aisec.cpg.frontends.golang.GoLanguageFrontend
+import de.fraunhofer.aisec.cpg.graph.Node
+import de.fraunhofer.aisec.cpg.graph.allChildren
+import de.fraunhofer.aisec.cpg.graph.declarations.FunctionDeclaration
+import de.fraunhofer.aisec.cpg.graph.statements.ReturnStatement
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.CallExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.DestructureTupleExpression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.Expression
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.TupleExpression
+import de.fraunhofer.aisec.cpg.passes.order.DependsOn
+import de.fraunhofer.aisec.cpg.passes.order.ExecuteBefore
+import de.fraunhofer.aisec.cpg.passes.order.RequiredFrontend
+
+/**
+ * Connects the values of a destructured call, e.g. `a, b := f()`, to the corresponding results of
+ * the return statements of the invoked functions. This way, `a` only receives the data flowing
+ * into the first result of `f` and `b` only the data of the second one.
+ *
+ * The [DFGPass] only follows the return statements directly contained in the body of a function,
+ * so that the results of returns in nested blocks, e.g. `if err != nil { return nil, err }`, would
+ * be lost. If the destructured value cannot be attributed to a single result, e.g. because of a
+ * bare return of named results, the data flows from the whole call, as before.
+ */
+@DependsOn(DFGPass::class)
+@DependsOn(ResolveGoInterfaceDispatch::class)
+@ExecuteBefore(ControlFlowSensitiveDFGPass::class)
+@RequiredFrontend(GoLanguageFrontend::class)
+class ResolveGoTupleResults : Pass() {
+    override fun accept(t: TranslationResult) {
+        for (tu in t.translationUnits) {
+            for (destructure in tu.allChildren<DestructureTupleExpression>()) {
+                val call = destructure.refersTo as? CallExpression ?: continue
+                if (call.invokes.isEmpty()) {
+                    continue
+                }
+
+                handleDestructure(destructure, call)
+            }
+        }
+    }
+
+    private fun handleDestructure(destructure: DestructureTupleExpression, call: CallExpression) {
+        var complete = true
+
+        for (function in call.invokes) {
+            val results = results(function)
+            if (results == null) {
+                complete = false
+                continue
+            }
+
+            for (result in results) {
+                val value = result.getOrNull(destructure.tupleIndex)
+                if (value == null) {
+                    complete = false
+                    continue
+                }
+
+                destructure.addPrevDFG(value)
+            }
+        }
+
+        // Every value of the tuple is known, so the remaining results of the call do not flow
+        // into the destructured value
+        if (complete) {
+            destructure.removePrevDFG(call)
+        } else {
+            destructure.addPrevDFG(call)
+        }
+    }
+
+    /**
+     * Returns the members of the returned tuples of all return statements of [function], or null,
+     * if its results are not known, e.g. because it has no body or contains a bare return.
+     */
+    private fun results(function: FunctionDeclaration): List<List<Expression>>? {
+        val body = function.body ?: return null
+
+        // Return statements of function literals belong to the literal
+        val nested =
+            body.allChildren<FunctionDeclaration>().flatMap { it.allChildren<Node>() }.toSet()
+
+        return body
+            .allChildren<ReturnStatement> { it !in nested }
+            .map { (it.returnValue as? TupleExpression)?.members ?: return null }
+    }
+
+    override fun cleanup() {
+        // Nothing to do
+    }
+}
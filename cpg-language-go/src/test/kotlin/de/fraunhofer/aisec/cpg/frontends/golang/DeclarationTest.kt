@@ -27,12 +27,24 @@ package de.fraunhofer.aisec.cpg.frontends.golang
 
 import de.fraunhofer.aisec.cpg.TestUtils
 import de.fraunhofer.aisec.cpg.graph.*
-import de.fraunhofer.aisec.cpg.graph.byNameOrNull
 import de.fraunhofer.aisec.cpg.graph.declarations.*
 import de.fraunhofer.aisec.cpg.graph.statements.*
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.*
+import de.fraunhofer.aisec.cpg.graph.types.FunctionType
+import de.fraunhofer.aisec.cpg.graph.types.ObjectType
+import de.fraunhofer.aisec.cpg.graph.types.PointerType
+import de.fraunhofer.aisec.cpg.graph.types.TupleType
 import java.nio.file.Path
-import kotlin.test.*
+import kotlin.test.assertContains
+import kotlin.test.assertEquals
+import kotlin.test.assertFalse
+import kotlin.test.assertIs
+import kotlin.test.assertNotEquals
+import kotlin.test.assertNotNull
+import kotlin.test.assertNull
+import kotlin.test.assertSame
+import kotlin.test.assertTrue
+import org.junit.jupiter.api.Test
 
 class DeclarationTest {
     @Test
@@ -106,4 +118,1223 @@ class DeclarationTest {
         assertContains(myInterface.superTypeDeclarations, myOtherInterface)
         assertTrue(myInterface.superClasses.any { it.name == myOtherInterface.name })
     }
+
+    @Test
+    fun testGroupedDeclarations() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("grouped.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        // grouped package-level variables and constants
+        for (name in listOf("a", "b", "c", "d")) {
+            assertNotNull((p.flatMap { it.variables })[name], "declaration of $name is missing")
+        }
+
+        val b = (p.flatMap { it.variables })["b"]
+        assertNotNull(b)
+        assertEquals("b", (b.initializer as? Literal<*>)?.value)
+
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        // grouped local variables end up in a single declaration statement
+        val declStmt = main.bodyOrNull<DeclarationStatement>(0)
+        assertNotNull(declStmt)
+        assertEquals(listOf("e", "f"), declStmt.declarations.map { it.name })
+    }
+
+    @Test
+    fun testMultipleNamesAndValues() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("multi_value.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val variables = p.flatMap { it.variables }
+
+        // var a, b int = 1, 2
+        val a = variables["a"]
+        assertNotNull(a)
+        assertEquals("int", a.type.name)
+        assertEquals(1, (a.initializer as? Literal<*>)?.value)
+
+        val b = variables["b"]
+        assertNotNull(b)
+        assertEquals("int", b.type.name)
+        assertEquals(2, (b.initializer as? Literal<*>)?.value)
+
+        // var c, d = pair()
+        val c = variables["c"]
+        assertNotNull(c)
+        assertEquals("int", c.type.name)
+
+        var destructure = c.initializer as? DestructureTupleExpression
+        assertNotNull(destructure)
+        assertEquals(0, destructure.tupleIndex)
+
+        val call = destructure.refersTo as? CallExpression
+        assertNotNull(call)
+        assertEquals("pair", call.name)
+
+        // the call returns a tuple of the result types, which is destructured
+        val tuple = call.type
+        assertIs<TupleType>(tuple)
+        assertEquals("(int, string)", tuple.name)
+        assertEquals("int", destructure.type.name)
+
+        val d = variables["d"]
+        assertNotNull(d)
+        assertEquals("string", d.type.name)
+
+        destructure = d.initializer as? DestructureTupleExpression
+        assertNotNull(destructure)
+        assertEquals(1, destructure.tupleIndex)
+        assertSame(call, destructure.refersTo)
+        assertEquals("string", destructure.type.name)
+
+        // var e, f string
+        for (name in listOf("e", "f")) {
+            val v = variables[name]
+            assertNotNull(v)
+            assertEquals("string", v.type.name)
+            assertNull(v.initializer)
+        }
+    }
+
+    @Test
+    fun testIota() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("iota.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val variables = p.flatMap { it.variables }
+
+        val sunday = variables["Sunday"]
+        assertNotNull(sunday)
+        assertTrue(sunday.type.qualifier.isConst)
+
+        val iota = sunday.initializer as? Literal<*>
+        assertNotNull(iota)
+        assertEquals("iota", iota.name)
+        assertEquals(0, iota.value)
+
+        // the implicitly repeated expression is evaluated
+        val tuesday = variables["Tuesday"]
+        assertNotNull(tuesday)
+        assertTrue(tuesday.type.qualifier.isConst)
+        assertEquals(2, (tuesday.initializer as? Literal<*>)?.value)
+
+        // iota within an expression
+        val kb = variables["KB"]
+        assertNotNull(kb)
+
+        val shift = kb.initializer as? BinaryOperator
+        assertNotNull(shift)
+        assertEquals("<<", shift.operatorCode)
+
+        val mb = variables["MB"]
+        assertNotNull(mb)
+        assertEquals(1 shl 20, (mb.initializer as? Literal<*>)?.value)
+
+        val name = variables["name"]
+        assertNotNull(name)
+        assertEquals("string", name.type.name)
+        assertTrue(name.type.qualifier.isConst)
+    }
+
+    @Test
+    fun testStructTags() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("tags.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val user = (p.flatMap { it.records })["p.User"]
+        assertNotNull(user)
+
+        val name = user.fields["Name"]
+        assertNotNull(name)
+        assertEquals(listOf("json"), name.annotations.map { it.name })
+
+        val email = user.fields["Email"]
+        assertNotNull(email)
+        assertEquals(listOf("json", "gorm"), email.annotations.map { it.name })
+
+        val json = email.annotations.first()
+        assertEquals("email,omitempty", (json.getValueForName("value") as? Literal<*>)?.value)
+
+        val gorm = email.annotations.last()
+        assertEquals("column:email", (gorm.getValueForName("value") as? Literal<*>)?.value)
+
+        val age = user.fields["age"]
+        assertNotNull(age)
+        assertTrue(age.annotations.isEmpty())
+    }
+
+    @Test
+    fun testAnonymousStruct() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(listOf(topLevel.resolve("anonymous.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+
+        val tu = result.translationUnits.firstOrNull()
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val records = p.flatMap { it.records }
+
+        val config = records["p.Config"]
+        assertNotNull(config)
+
+        // the field is typed with an implicit record of the anonymous struct
+        val server = config.fields["Server"]
+        assertNotNull(server)
+        assertEquals("p.struct_anonymous_4_9", server.type.name)
+
+        val serverRecord = records["p.struct_anonymous_4_9"]
+        assertNotNull(serverRecord)
+        assertTrue(serverRecord.isImplicit)
+        assertEquals(listOf("Host", "Port"), serverRecord.fields.map { it.name })
+
+        // the implicit record is declared in the package, not within the record of its field
+        assertTrue(config.records.isEmpty())
+
+        val x = p.flatMap { it.variables }["x"]
+        assertNotNull(x)
+
+        val xRecord = records[x.type.name]
+        assertNotNull(xRecord)
+        assertNotNull(xRecord.fields["A"])
+
+        // the implicit records are declared in the scope of the namespace, so they are resolvable
+        val scope = result.scopeManager.lookupScope("p")
+        assertNotNull(scope)
+        assertContains(scope.structureDeclarations, serverRecord)
+        assertContains(scope.structureDeclarations, xRecord)
+        assertSame(xRecord, (x.type as? ObjectType)?.recordDeclaration)
+    }
+
+    @Test
+    fun testImplements() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("implements.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val records = p.flatMap { it.records }
+
+        val shape = records["p.Shape"]
+        assertNotNull(shape)
+
+        val named = records["p.Named"]
+        assertNotNull(named)
+
+        val square = records["p.Square"]
+        assertNotNull(square)
+        assertEquals(listOf("p.Shape"), square.superClasses.map { it.name })
+        assertTrue(square.superTypeDeclarations.contains(shape))
+
+        // methods with pointer receivers also count
+        val circle = records["p.Circle"]
+        assertNotNull(circle)
+        assertEquals(listOf("p.Named", "p.Shape"), circle.superClasses.map { it.name })
+
+        // the kind of receiver is kept on the method
+        val squareArea = square.methods["Area"]
+        assertNotNull(squareArea)
+        assertFalse(squareArea.hasPointerReceiver)
+
+        val circleArea = circle.methods["Area"]
+        assertNotNull(circleArea)
+        assertTrue(circleArea.hasPointerReceiver)
+
+        val point = records["p.Point"]
+        assertNotNull(point)
+        assertTrue(point.superClasses.isEmpty())
+    }
+
+    @Test
+    fun testInterfaceDispatch() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().interfaceDispatch(true).build()
+
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("implements.go").toFile(),
+                    topLevel.resolve("dispatch.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        val tu = result.translationUnits.firstOrNull { it.name.endsWith("dispatch.go") }
+        assertNotNull(tu)
+
+        val totalArea = (tu.namespaces.flatMap { it.functions })["totalArea"]
+        assertNotNull(totalArea)
+
+        val call = totalArea.allChildren<MemberCallExpression>().firstOrNull { it.name == "Area" }
+        assertNotNull(call)
+
+        // the call through the interface reaches all implementations
+        val records =
+            call.invokes.mapNotNull { (it as? MethodDeclaration)?.recordDeclaration?.name }
+        assertTrue(records.containsAll(listOf("p.Square", "p.Circle")))
+    }
+
+    @Test
+    fun testSymbolsAcrossFiles() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("symbols_use.go").toFile(),
+                    topLevel.resolve("symbols_decl.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        val tus = result.translationUnits
+        val useTU = tus.firstOrNull { it.name.endsWith("symbols_use.go") }
+        assertNotNull(useTU)
+        val declTU = tus.firstOrNull { it.name.endsWith("symbols_decl.go") }
+        assertNotNull(declTU)
+
+        val decl = declTU.namespaces.filter { it.name == "p" }
+
+        val helper = (decl.flatMap { it.functions })["helper"]
+        assertNotNull(helper)
+
+        val counter = (decl.flatMap { it.variables })["counter"]
+        assertNotNull(counter)
+        assertEquals("int", counter.type.name)
+        assertNotNull(counter.initializer)
+
+        val open = (decl.flatMap { it.records })["p.Box"]?.methods?.get("Open")
+        assertNotNull(open)
+
+        // the symbols of the other file are known, although it is handled afterwards
+        val useSymbols = (useTU.namespaces.flatMap { it.functions })["useSymbols"]
+        assertNotNull(useSymbols)
+
+        val calls = useSymbols.allChildren<CallExpression>()
+        assertEquals(listOf(helper), calls.firstOrNull { it.name == "helper" }?.invokes)
+        assertEquals(listOf(open), calls.firstOrNull { it.name == "Open" }?.invokes)
+
+        val ref =
+            useSymbols.allChildren<DeclaredReferenceExpression>().firstOrNull {
+                it.name == "counter"
+            }
+        assertNotNull(ref)
+        assertSame(counter, ref.refersTo)
+    }
+
+    @Test
+    fun testBlankAndDotImports() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("imports.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // both imports are named after their package and marked with their kind
+        val kinds =
+            tu.includes.associate {
+                it.name to
+                    (it.annotations.firstOrNull { a -> a.name == "import" }?.getValueForName("kind")
+                            as? Literal<*>)
+                        ?.value
+            }
+        assertEquals(mapOf("embed" to "blank", "strings" to "dot"), kinds)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val upper = (p.flatMap { it.functions })["upper"]
+        assertNotNull(upper)
+
+        // the symbol of the dot import is qualified with its package
+        val call = upper.allChildren<CallExpression>().firstOrNull()
+        assertNotNull(call)
+        assertEquals("ToUpper", call.name)
+        assertEquals("strings.ToUpper", call.fqn)
+    }
+
+    @Test
+    fun testUnresolvedImports() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("unresolved").resolve("unresolved.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the name of the missing package is derived from its path, without the major version
+        assertEquals(setOf("strings", "missing"), tu.includes.map { it.name }.toSet())
+
+        val problems = tu.declarations.filterIsInstance<ProblemDeclaration>()
+        assertEquals(
+            listOf("could not resolve import example.com/missing/v2"),
+            problems.map { it.problem }
+        )
+    }
+
+    @Test
+    fun testMethodsInOtherFile() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("methods").resolve("handlers.go").toFile(),
+                    topLevel.resolve("methods").resolve("server.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        val records = result.translationUnits.flatMap { it.records }
+
+        // the methods are declared in another file than their types
+        val server = records.firstOrNull { it.name.endsWith("Server") }
+        assertNotNull(server)
+        assertEquals(listOf("Addr"), server.methods.map { it.name })
+
+        val list = records.firstOrNull { it.name.endsWith("List") }
+        assertNotNull(list)
+        assertEquals(listOf("Push"), list.methods.map { it.name })
+    }
+
+    @Test
+    fun testQualifiedNames() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("methods").resolve("handlers.go").toFile(),
+                    topLevel.resolve("methods").resolve("run.go").toFile(),
+                    topLevel.resolve("methods").resolve("server.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        val functions = result.translationUnits.flatMap { it.functions }
+
+        val addr = functions.firstOrNull { it is MethodDeclaration && it.name == "Addr" }
+        assertNotNull(addr)
+        assertEquals("p/methods.(*Server).Addr", addr.fullName.toString())
+
+        val run = functions["Run"]
+        assertNotNull(run)
+        assertEquals("p/methods.Run", run.fullName.toString())
+
+        val defaultServer = result.translationUnits.flatMap { it.variables }["DefaultServer"]
+        assertNotNull(defaultServer)
+        assertEquals("p/methods.DefaultServer", defaultServer.fullName.toString())
+
+        // the call shares the name of the method, but is resolved within the record
+        val call = run.calls["Addr"]
+        assertNotNull(call)
+        assertEquals(addr.fullName.toString(), call.fullName.toString())
+        assertEquals("p/methods.Server.Addr", call.fqn)
+    }
+
+    @Test
+    fun testNestedNamespaces() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().nestedNamespaces(true).build()
+
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("methods").resolve("server.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+        assertNotNull(tu)
+
+        // the package p/methods is nested in the namespace p
+        val p = tu.namespaces.filterIsInstance<NamespaceDeclaration>()["p"]
+        assertNotNull(p)
+
+        val methods = p.namespaces["p/methods"]
+        assertNotNull(methods)
+        assertNotNull(methods.records["p/methods.Server"])
+    }
+
+    @Test
+    fun testTypeDefinitions() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("types.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // a defined type is a record of its own, which is based on its underlying type
+        val names = tu.records["p.Names"]
+        assertNotNull(names)
+        assertEquals("type", names.kind)
+        assertNotNull(names.methods["Len"])
+
+        val underlying = names.underlyingType
+        assertIs<PointerType>(underlying)
+        assertEquals("string", underlying.elementType.name)
+
+        // an alias is just another name for the same type
+        val alias = tu.namespaces.flatMap { it.typedefs }.firstOrNull { it.alias.name == "p.Alias" }
+        assertNotNull(alias)
+        assertEquals("p.Names", alias.type.name)
+        assertNull(tu.records["p.Alias"])
+
+        // there is no function for the conversion, but a cast
+        assertNull(tu.functions["Names"])
+
+        val convert = tu.functions["convert"]
+        assertNotNull(convert)
+
+        val cast = convert.allChildren<CastExpression>().firstOrNull()
+        assertNotNull(cast)
+        assertEquals("p.Names", cast.castType.name)
+    }
+
+    @Test
+    fun testNamedTypes() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("types.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val fahrenheit = tu.records["p.Fahrenheit"]
+        assertNotNull(fahrenheit)
+        assertEquals("float64", fahrenheit.underlyingType?.name)
+
+        // the underlying type of a chain of defined types is the one at its end
+        val celsius = tu.records["p.Celsius"]
+        assertNotNull(celsius)
+        assertEquals("float64", celsius.underlyingType?.name)
+
+        val toCelsius = tu.functions["toCelsius"]
+        assertNotNull(toCelsius)
+
+        // the type of a reference is linked to the record of its named type
+        val f = toCelsius.refs["f"]
+        assertNotNull(f)
+
+        val type = f.type
+        assertIs<ObjectType>(type)
+        assertSame(fahrenheit, type.recordDeclaration)
+    }
+
+    @Test
+    fun testGroupedNames() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("groups.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // each name of a group of fields is a field of its own
+        val vector = tu.records["p.Vector"]
+        assertNotNull(vector)
+        assertEquals(listOf("X", "Y"), vector.fields.map { it.name })
+
+        for (field in vector.fields) {
+            assertEquals("float64", field.type.name)
+            assertEquals("X and Y are the coordinates", field.comment)
+        }
+
+        // the same goes for parameters and named results
+        val add = tu.functions["add"]
+        assertNotNull(add)
+        assertEquals(listOf("a", "b"), add.parameters.map { it.name })
+        assertTrue(add.parameters.all { it.type.name == "int" })
+        assertEquals(2, add.returnTypes.size)
+        assertNotNull(add.variables["sum"])
+        assertNotNull(add.variables["carry"])
+    }
+
+    @Test
+    fun testPackageInitialization() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("initialization").resolve("a.go").toFile(),
+                    topLevel.resolve("initialization").resolve("b.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        fun Node.initializationOrder() =
+            (annotations
+                    .firstOrNull { it.name == "initialization" }
+                    ?.getValueForName("order") as? Literal<*>)
+                ?.value
+
+        val functions = result.translationUnits.flatMap { it.functions }
+        val variables = result.translationUnits.flatMap { it.variables }
+
+        // variables are initialized in the order of their dependencies, across files
+        val count = variables["count"]
+        assertNotNull(count)
+        assertEquals(0, count.initializationOrder())
+
+        val total = variables["total"]
+        assertNotNull(total)
+        assertEquals(1, total.initializationOrder())
+
+        // followed by the init functions in the order of the files
+        val inits = functions.filter { it.name == "init" }.sortedBy { it.location?.toString() }
+        assertEquals(listOf(2, 3), inits.map { it.initializationOrder() })
+
+        val compute = functions["compute"]
+        assertNotNull(compute)
+        assertNull(compute.initializationOrder())
+
+        // the package is a command, whose initialization leads to main
+        val initialization = count.annotations.firstOrNull { it.name == "initialization" }
+        assertEquals(true, (initialization?.getValueForName("command") as? Literal<*>)?.value)
+
+        // the parts of the initialization are connected in the EOG and lead to main
+        assertTrue(count.nextEOG.isNotEmpty())
+        assertTrue(count.nextEOG.all { it in total.initializer.allChildren<Node>() })
+        assertEquals(listOf(inits[0]), total.nextEOG)
+        assertTrue(inits[1].prevEOG.isNotEmpty())
+
+        val main = functions["main"]
+        assertNotNull(main)
+        assertTrue(main.prevEOG.isNotEmpty())
+    }
+
+    @Test
+    fun testReleaseSyntax() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().releaseSyntax(true).build()
+
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("initialization").resolve("a.go").toFile(),
+                    topLevel.resolve("initialization").resolve("b.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        // the syntax tree of the first file is released, which must not affect the second one
+        val functions = result.translationUnits.flatMap { it.functions }
+        val inits = functions.filter { it.name == "init" }.sortedBy { it.location?.toString() }
+        assertEquals(
+            listOf(2, 3),
+            inits.map {
+                (it.annotations
+                        .firstOrNull { a -> a.name == "initialization" }
+                        ?.getValueForName("order") as? Literal<*>)
+                    ?.value
+            }
+        )
+
+        val variables = result.translationUnits.flatMap { it.variables }
+        val count = variables["count"]
+        assertNotNull(count)
+        assertEquals("int", count.type.name)
+
+        val total = variables["total"]
+        assertNotNull(total)
+        assertEquals("int", total.type.name)
+    }
+
+    @Test
+    fun testBlankIdentifier() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("blank.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the blank identifier never declares a variable
+        assertTrue(tu.variables.none { it.name == "_" })
+
+        // but the value of a package-level one is still evaluated
+        val cast = tu.statements.firstOrNull() as? CastExpression
+        assertNotNull(cast)
+        assertEquals("p.Sink*", cast.castType.name)
+
+        // and records, that the type implements the interface
+        val sink = tu.records["p.Sink"]
+        assertNotNull(sink)
+        assertTrue(sink.superClasses.any { it.name == "io.Writer" })
+
+        // neither blank receivers nor blank results are declared
+        val close = sink.methods["Close"]
+        assertNotNull(close)
+        assertNull(close.receiver)
+        assertEquals(2, close.returnTypes.size)
+        assertEquals(listOf("err"), close.variables.map { it.name })
+
+        // the values of local ones are evaluated in place
+        val blank = tu.functions["blank"]
+        assertNotNull(blank)
+
+        val body = blank.body as? CompoundStatement
+        assertNotNull(body)
+        assertTrue(body.statements.none { it is DeclarationStatement })
+        assertEquals(
+            listOf("open", "len"),
+            body.statements.filterIsInstance<CallExpression>().map { it.name }
+        )
+
+        // as is the range expression, if the iteration values are discarded
+        val loop = body.statements.filterIsInstance<ForEachStatement>().singleOrNull()
+        assertNotNull(loop)
+        assertTrue(loop.variables.none { it is DeclaredReferenceExpression })
+    }
+
+    @Test
+    fun testArrayTypes() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("arrays.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // nested arrays keep the length of each dimension
+        val grid = tu.variables["grid"]?.type
+        assertIs<PointerType>(grid)
+        assertEquals("int[][]", grid.name)
+        assertEquals(3L, grid.arrayLength)
+
+        var element = grid.elementType
+        assertIs<PointerType>(element)
+        assertEquals(4L, element.arrayLength)
+
+        // slices have no length at all
+        val lines = tu.variables["lines"]?.type
+        assertIs<PointerType>(lines)
+        assertEquals("byte[][]", lines.name)
+        assertNull(lines.arrayLength)
+
+        element = lines.elementType
+        assertIs<PointerType>(element)
+        assertNull(element.arrayLength)
+
+        // lengths are evaluated, if they are given by constant expressions or by the literal
+        val arrays = tu.functions["arrays"]
+        assertNotNull(arrays)
+
+        val doubled = arrays.variables["doubled"]?.type
+        assertIs<PointerType>(doubled)
+        assertEquals(4L, doubled.arrayLength)
+
+        val primes = arrays.variables["primes"]?.type
+        assertIs<PointerType>(primes)
+        assertEquals(3L, primes.arrayLength)
+
+        // an array of a type parameter is translated, even if its element type is unknown
+        val pair = tu.functions["pair"]
+        assertNotNull(pair)
+        assertNotNull(pair.variables["both"])
+    }
+
+    @Test
+    fun testMethodTypes() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("method_types.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the receiver is the first parameter of the type of a method
+        val tile = tu.records["p.Tile"]?.methods?.get("Area")
+        assertNotNull(tile)
+
+        val tileType = tile.type
+        assertIs<FunctionType>(tileType)
+        assertEquals("func(p.Tile) int", tileType.name)
+        assertEquals(listOf("p.Tile"), tileType.parameters.map { it.name })
+
+        // including a pointer receiver
+        val frame = tu.records["p.Frame"]?.methods?.get("Area")
+        assertNotNull(frame)
+
+        val frameType = frame.type
+        assertIs<FunctionType>(frameType)
+        assertEquals("func(p.Frame*) int", frameType.name)
+
+        // so that methods with the same signature on different types do not share their type
+        assertNotEquals(tileType, frameType)
+
+        // functions have no receiver
+        val area = tu.functions["area"]
+        assertNotNull(area)
+        assertEquals("func(int) int", area.type.name)
+    }
+
+    @Test
+    fun testBodylessFunctions() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("bodyless.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // functions implemented in assembly are only declared
+        val addAsm = tu.functions["addAsm"]
+        assertNotNull(addAsm)
+        assertFalse(addAsm.isDefinition)
+        assertNull(addAsm.body)
+
+        // as well as the ones linked to another function
+        val nanotime = tu.functions["nanotime"]
+        assertNotNull(nanotime)
+        assertFalse(nanotime.isDefinition)
+
+        val linkname = nanotime.annotations.firstOrNull { it.name == "linkname" }
+        assertNotNull(linkname)
+        assertEquals(
+            "runtime.nanotime",
+            (linkname.members.firstOrNull { it.name == "target" }?.value as? Literal<*>)?.value
+        )
+
+        val callsBodyless = tu.functions["callsBodyless"]
+        assertNotNull(callsBodyless)
+        assertTrue(callsBodyless.isDefinition)
+        assertTrue(callsBodyless.annotations.none { it.name == "linkname" })
+
+        // calls are still resolved to the declarations
+        val calls = callsBodyless.calls
+        assertEquals(listOf(addAsm), calls.firstOrNull { it.name == "addAsm" }?.invokes)
+        assertEquals(listOf(nanotime), calls.firstOrNull { it.name == "nanotime" }?.invokes)
+    }
+
+    @Test
+    fun testPackageDocumentation() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("documented").resolve("service.go").toFile(),
+                    topLevel.resolve("documented").resolve("doc.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        val (service, doc) = result.translationUnits
+
+        // the documentation of the package is taken from doc.go for all files
+        val expected =
+            "Package documented provides a documented service.\n\n" +
+                "It is used to test package documentation."
+        for (tu in listOf(service, doc)) {
+            val namespace = tu.namespaces.firstOrNull { it.name == "p/documented" }
+            assertNotNull(namespace)
+            assertEquals(expected, namespace.comment)
+        }
+
+        // the comments before the package clause belong to the file itself
+        assertEquals("This file contains the service.", service.comment)
+        assertEquals("Copyright (c) 2022, Example Authors.\n\n$expected", doc.comment)
+    }
+
+    @Test
+    fun testDeprecation() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("deprecated.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.deprecation(): String? {
+            val deprecated = annotations.firstOrNull { it.name == "Deprecated" } ?: return null
+            return (deprecated.members.firstOrNull { it.name == "message" }?.value as? Literal<*>)
+                ?.value as? String
+        }
+
+        // the message of the paragraph is joined into a single line
+        assertEquals(
+            "MD5 is cryptographically broken, use strongDigest instead.",
+            tu.functions["weakDigest"]?.deprecation()
+        )
+        assertNull(tu.functions["strongDigest"]?.deprecation())
+
+        // the documentation of a group applies to all of its variables
+        for (name in listOf("shortKeySize", "shortKeyName")) {
+            val v = tu.variables[name]
+            assertNotNull(v)
+            assertEquals("the key size is too small.", v.deprecation())
+        }
+        assertNull(tu.variables["longKeySize"]?.deprecation())
+
+        val cipher = tu.records["p.LegacyCipher"]
+        assertNotNull(cipher)
+        assertNull(cipher.deprecation())
+        assertEquals(
+            "ECB leaks patterns of the plaintext.",
+            cipher.fields["Mode"]?.deprecation()
+        )
+        assertNull(cipher.fields["Key"]?.deprecation())
+
+        val encrypter = tu.records["p.Encrypter"]
+        assertNotNull(encrypter)
+        assertEquals("use Encrypt.", encrypter.methods["EncryptECB"]?.deprecation())
+        assertNull(encrypter.methods["Encrypt"]?.deprecation())
+
+        // calls to deprecated functions can be found by following invokes
+        val usesDeprecated = tu.functions["usesDeprecated"]
+        assertNotNull(usesDeprecated)
+        assertTrue(
+            usesDeprecated.calls.any { call -> call.invokes.any { it.deprecation() != null } }
+        )
+    }
+
+    @Test
+    fun testFieldAndImportComments() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("field_comments.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the documentation of an ungrouped import belongs to its declaration
+        val includes = tu.includes.associateBy { it.name }
+        assertEquals("fmt prints the greeting.", includes["fmt"]?.comment)
+        assertEquals("strconv formats the count.", includes["strconv"]?.comment)
+        assertEquals("strings repeats the text.", includes["strings"]?.comment)
+
+        val greeting = tu.records["p.greeting"]
+        assertNotNull(greeting)
+
+        // doc and line comments of a field are joined
+        assertEquals("Text is the message.\nmust not be empty", greeting.fields["Text"]?.comment)
+        assertEquals("how often to repeat the text", greeting.fields["Count"]?.comment)
+    }
+
+    @Test
+    fun testDestructuredResults() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("destructure.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val user = tu.variables["user"]?.initializer as? DestructureTupleExpression
+        assertNotNull(user)
+        assertEquals("string", user.type.name)
+
+        val err = tu.variables["err"]?.initializer as? DestructureTupleExpression
+        assertNotNull(err)
+        assertEquals("error", err.type.name)
+
+        val call = user.refersTo as? CallExpression
+        assertNotNull(call)
+        assertEquals(listOf(tu.functions["lookupUser"]), call.invokes)
+
+        // each value only receives the corresponding results of all return statements,
+        // including the nested one
+        assertEquals(setOf("", "admin"), user.prevDFG.map { (it as? Literal<*>)?.value }.toSet())
+        assertTrue(err.prevDFG.any { it.name == "errUnknownUser" })
+        assertTrue(err.prevDFG.none { (it as? Literal<*>)?.value is String })
+        assertFalse(call in user.prevDFG)
+        assertFalse(call in err.prevDFG)
+    }
+
+    @Test
+    fun testRPCMethods() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("rpc").resolve("greeter.go").toFile(),
+                    topLevel.resolve("rpc").resolve("greeter_grpc.pb.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        val records = result.translationUnits.flatMap { it.records }
+
+        fun FunctionDeclaration.rpc(): Map<String, Any?>? {
+            val rpc = annotations.firstOrNull { it.name == "rpc" } ?: return null
+            return rpc.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        val greeter = records.firstOrNull { it.name.endsWith("greeter") }
+        assertNotNull(greeter)
+        assertEquals(
+            mapOf(
+                "service" to "helloworld.Greeter",
+                "method" to "SayHello",
+                "name" to "/helloworld.Greeter/SayHello"
+            ),
+            greeter.methods["SayHello"]?.rpc()
+        )
+
+        // neither helpers nor the generated stubs are entry points
+        assertNull(greeter.methods["format"]?.rpc())
+
+        val unimplemented = records.firstOrNull { it.name.endsWith("UnimplementedGreeterServer") }
+        assertNotNull(unimplemented)
+        assertTrue(unimplemented.methods.isNotEmpty())
+        assertTrue(unimplemented.methods.all { it.rpc() == null })
+    }
+
+    @Test
+    fun testRPCMethodsWithReleasedSyntax() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().releaseSyntax(true).build()
+
+        // the file with the service descriptor is translated (and released) first
+        val result =
+            TestUtils.analyze(
+                listOf(
+                    topLevel.resolve("services").resolve("desc.go").toFile(),
+                    topLevel.resolve("services").resolve("server.go").toFile()
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        val echo = result.translationUnits.flatMap { it.records }["services.echo"]
+        assertNotNull(echo)
+
+        val rpc = echo.methods["Echo"]?.annotations?.firstOrNull { it.name == "rpc" }
+        assertNotNull(rpc)
+        assertEquals("echo.v1.Echo", (rpc.getValueForName("service") as? Literal<*>)?.value)
+    }
+
+    @Test
+    fun testORMModels() {
+        val topLevel = Path.of("src", "test", "resources", "golang-orm")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("models").resolve("models.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.annotated(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the calls referring to models are marked with the operation and their models
+        val migrate = tu.calls["AutoMigrate"]
+        assertNotNull(migrate)
+        assertEquals("migrate", migrate.annotated("orm")?.get("operation"))
+        assertEquals(
+            listOf("UserProfile", "Order"),
+            migrate.annotations
+                .first { it.name == "orm" }
+                .members
+                .filter { it.name == "model" }
+                .map { it.value?.name }
+        )
+        assertEquals("query", tu.calls["Find"]?.annotated("orm")?.get("operation"))
+        assertNull(tu.calls["Where"]?.annotated("orm"))
+
+        val profile = tu.records["example.io/shop/models.UserProfile"]
+        assertNotNull(profile)
+        assertEquals(
+            mapOf("framework" to "gorm", "name" to "user_profiles"),
+            profile.annotated("table")
+        )
+
+        val audited = tu.records["example.io/shop/models.Audited"]
+        assertNotNull(audited)
+
+        // the columns follow the tags or the naming convention, including embedded fields
+        val columns =
+            (profile.fields + audited.fields).associate {
+                it.name to it.annotated("column")?.get("name")
+            }
+        assertEquals(
+            mapOf(
+                "Audited" to null,
+                "CreatedBy" to "created_by",
+                "ID" to "id",
+                "FullName" to "full_name",
+                "Email" to "email_address",
+                "Password" to null,
+                "lastLogin" to null,
+            ),
+            columns
+        )
+
+        // the table can be overridden by a TableName method
+        val order = tu.records["example.io/shop/models.Order"]
+        assertNotNull(order)
+        assertEquals("purchases", order.annotated("table")?.get("name"))
+        assertEquals("purchases", order.fields["UserID"]?.annotated("column")?.get("table"))
+        assertEquals("user_id", order.fields["UserID"]?.annotated("column")?.get("name"))
+    }
+
+    @Test
+    fun testSqlxModels() {
+        val topLevel = Path.of("src", "test", "resources", "golang-orm")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("accounts").resolve("accounts.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.annotated(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members
+                .filter { it.name != "model" }
+                .associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the model follows the context, if there is one
+        for (name in listOf("GetContext", "Select")) {
+            val call = tu.calls[name]
+            assertNotNull(call)
+            assertEquals(
+                mapOf("framework" to "sqlx", "operation" to "query"),
+                call.annotated("orm")
+            )
+        }
+
+        // sqlx does not map the model to a table
+        val account = tu.records["example.io/shop/accounts.Account"]
+        assertNotNull(account)
+        assertEquals(mapOf("framework" to "sqlx"), account.annotated("table"))
+
+        // the columns follow the db tags or the lower-cased names
+        assertEquals(
+            mapOf("ID" to "account_id", "Email" to "email", "Password" to null),
+            account.fields.associate { it.name to it.annotated("column")?.get("name") }
+        )
+    }
+
+    @Test
+    fun testProtobufMessages() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("userpb").resolve("user.pb.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.annotated(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        val user = tu.records.firstOrNull { it.name.endsWith("User") }
+        assertNotNull(user)
+        assertNotNull(user.annotated("protoMessage"))
+
+        assertEquals(
+            mapOf(
+                "name" to "email_address",
+                "number" to 2,
+                "wire" to "bytes",
+                "cardinality" to "opt",
+                "json" to "emailAddress"
+            ),
+            user.fields["EmailAddress"]?.annotated("protoField")
+        )
+        assertEquals(
+            mapOf("name" to "id", "number" to 1, "wire" to "varint", "cardinality" to "opt"),
+            user.fields["Id"]?.annotated("protoField")
+        )
+        assertEquals("rep", user.fields["Tags"]?.annotated("protoField")?.get("cardinality"))
+        assertEquals(mapOf("name" to "contact"), user.fields["Contact"]?.annotated("protoOneof"))
+
+        // the internal state of the message is not part of the proto schema
+        assertNull(user.fields["sizeCache"]?.annotated("protoField"))
+
+        // the wrapper of a oneof field
+        val phone = tu.records.firstOrNull { it.name.endsWith("User_Phone") }
+        assertNotNull(phone)
+        assertEquals(4, phone.fields["Phone"]?.annotated("protoField")?.get("number"))
+    }
 }
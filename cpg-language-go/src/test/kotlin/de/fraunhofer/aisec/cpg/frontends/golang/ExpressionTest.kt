@@ -30,9 +30,13 @@ import de.fraunhofer.aisec.cpg.graph.*
 import de.fraunhofer.aisec.cpg.graph.declarations.*
 import de.fraunhofer.aisec.cpg.graph.statements.*
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.*
+import de.fraunhofer.aisec.cpg.graph.types.FunctionType
 import java.nio.file.Path
 import kotlin.test.assertEquals
+import kotlin.test.assertFalse
+import kotlin.test.assertIs
 import kotlin.test.assertNotNull
+import kotlin.test.assertNull
 import kotlin.test.assertSame
 import kotlin.test.assertTrue
 import org.junit.jupiter.api.Test
@@ -70,4 +74,961 @@ class ExpressionTest {
         assertEquals("p.MyStructTA", cast.castType.name)
         assertSame(f, (cast.expression as? DeclaredReferenceExpression)?.refersTo)
     }
+
+    @Test
+    fun testSliceExpression() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("slices.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val main = tu.namespaces.filter { it.name == "p" }
+        assertTrue(main.size > 0)
+
+        val mainFunc = (main.flatMap { it.functions })["main"]
+        assertNotNull(mainFunc)
+
+        val a =
+            (mainFunc.bodyOrNull<DeclarationStatement>(0))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(a)
+
+        // a[1:]
+        val b =
+            (mainFunc.bodyOrNull<DeclarationStatement>(1))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(b)
+        assertEquals("int[]", b.type.name)
+
+        var slice = b.initializer as? ArraySubscriptionExpression
+        assertNotNull(slice)
+        assertSame(a, (slice.arrayExpression as? DeclaredReferenceExpression)?.refersTo)
+
+        var range = slice.subscriptExpression as? ArrayRangeExpression
+        assertNotNull(range)
+        assertEquals(1, (range.floor as? Literal<*>)?.value)
+        assertNull(range.ceiling)
+        assertNull(range.max)
+
+        // a[1:2]
+        val c =
+            (mainFunc.bodyOrNull<DeclarationStatement>(2))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(c)
+
+        slice = c.initializer as? ArraySubscriptionExpression
+        assertNotNull(slice)
+
+        range = slice.subscriptExpression as? ArrayRangeExpression
+        assertNotNull(range)
+        assertEquals(1, (range.floor as? Literal<*>)?.value)
+        assertEquals(2, (range.ceiling as? Literal<*>)?.value)
+        assertNull(range.max)
+
+        // a[1:2:3]
+        val d =
+            (mainFunc.bodyOrNull<DeclarationStatement>(3))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(d)
+        assertEquals("int[]", d.type.name)
+
+        slice = d.initializer as? ArraySubscriptionExpression
+        assertNotNull(slice)
+
+        range = slice.subscriptExpression as? ArrayRangeExpression
+        assertNotNull(range)
+        assertEquals(1, (range.floor as? Literal<*>)?.value)
+        assertEquals(2, (range.ceiling as? Literal<*>)?.value)
+        assertEquals(3, (range.max as? Literal<*>)?.value)
+    }
+
+    @Test
+    fun testInstantiation() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("generics.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val main = tu.namespaces.filter { it.name == "p" }
+        assertTrue(main.size > 0)
+
+        val mainFunc = (main.flatMap { it.functions })["main"]
+        assertNotNull(mainFunc)
+
+        // Map[int, string](xs, toString)
+        var call = mainFunc.bodyOrNull<CallExpression>(0)
+        assertNotNull(call)
+        assertEquals("Map", call.name)
+        assertEquals(2, call.arguments.size)
+        assertEquals(listOf("int", "string"), call.templateParameters.map { it.name })
+
+        // Identity[int](1)
+        call = mainFunc.bodyOrNull(1)
+        assertNotNull(call)
+        assertEquals("Identity", call.name)
+        assertEquals(1, call.arguments.size)
+        assertEquals(listOf("int"), call.templateParameters.map { it.name })
+    }
+
+    @Test
+    fun testSelector() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("selector.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val main = tu.namespaces.filter { it.name == "p" }
+        assertTrue(main.size > 0)
+
+        val mainFunc = (main.flatMap { it.functions })["main"]
+        assertNotNull(mainFunc)
+
+        // fmt.Println refers to the package
+        val call = mainFunc.bodyOrNull<CallExpression>(0)
+        assertNotNull(call)
+        assertEquals("Println", call.name)
+        assertEquals("fmt.Println", call.fqn)
+
+        // fmt.fmt refers to the field of the local variable that shadows the import
+        val member = mainFunc.allChildren<MemberExpression>().firstOrNull { it.name == "fmt" }
+        assertNotNull(member)
+
+        val base = member.base as? DeclaredReferenceExpression
+        assertNotNull(base)
+        assertEquals("fmt", base.name)
+        assertEquals("p.printer", base.type.name)
+    }
+
+    @Test
+    fun testShadowing() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("shadow.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val main = tu.namespaces.filter { it.name == "p" }
+        assertTrue(main.size > 0)
+
+        val mainFunc = (main.flatMap { it.functions })["main"]
+        assertNotNull(mainFunc)
+
+        val outer = (mainFunc.bodyOrNull<DeclarationStatement>(0))?.singleDeclaration
+        assertNotNull(outer)
+        assertEquals("int", (outer as? VariableDeclaration)?.type?.name)
+
+        val refs = mainFunc.allChildren<DeclaredReferenceExpression>().filter { it.name == "x" }
+
+        // the reference within the if block refers to the shadowing variable
+        val inner = refs.firstOrNull { it.refersTo != outer }
+        assertNotNull(inner)
+        assertEquals("string", (inner.refersTo as? VariableDeclaration)?.type?.name)
+
+        // the closure captures the outer variable
+        val returnStmt = mainFunc.allChildren<ReturnStatement>().firstOrNull()
+        assertNotNull(returnStmt)
+        assertSame(outer, (returnStmt.returnValue as? DeclaredReferenceExpression)?.refersTo)
+    }
+
+    @Test
+    fun testConversion() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("conversion.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val conversion = (p.flatMap { it.functions })["conversion"]
+        assertNotNull(conversion)
+
+        val b = conversion.parameters.firstOrNull()
+        assertNotNull(b)
+
+        // string(b)
+        val s =
+            (conversion.bodyOrNull<DeclarationStatement>(0))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(s)
+
+        var cast = s.initializer as? CastExpression
+        assertNotNull(cast)
+        assertEquals("string", cast.castType.name)
+        assertSame(b, (cast.expression as? DeclaredReferenceExpression)?.refersTo)
+
+        // MyInt(len(b))
+        val i =
+            (conversion.bodyOrNull<DeclarationStatement>(1))?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(i)
+
+        cast = i.initializer as? CastExpression
+        assertNotNull(cast)
+        assertEquals("p.MyInt", cast.castType.name)
+
+        // (*MyInt)(&i)
+        cast =
+            conversion
+                .allChildren<ReturnStatement>()
+                .firstOrNull()
+                ?.allChildren<CastExpression>()
+                ?.firstOrNull()
+        assertNotNull(cast)
+        assertEquals("p.MyInt*", cast.castType.name)
+
+        // conversions do not call anything
+        val calls = conversion.allChildren<CallExpression>()
+        assertEquals(listOf("len"), calls.map { it.name })
+    }
+
+    @Test
+    fun testBuiltins() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("builtin.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val builtins = (p.flatMap { it.functions })["builtins"]
+        assertNotNull(builtins)
+
+        val calls = builtins.allChildren<CallExpression>().associateBy { it.name }
+
+        // the predeclared functions are resolved without the package prefix
+        for (name in listOf("append", "copy", "len")) {
+            val call = calls[name]
+            assertNotNull(call)
+            assertNull(call.fqn)
+
+            val builtin = call.invokes.singleOrNull()
+            assertNotNull(builtin)
+            assertEquals(name, builtin.name)
+            assertTrue(builtin.isImplicit)
+        }
+
+        val len = calls["len"]
+        assertNotNull(len)
+        assertEquals("int", len.type.name)
+
+        // the data flows through append and copy
+        val append = calls["append"]
+        assertNotNull(append)
+        assertTrue(append.prevDFG.containsAll(append.arguments))
+
+        val copy = calls["copy"]
+        assertNotNull(copy)
+        assertTrue(copy.arguments[0].prevDFG.contains(copy.arguments[1]))
+    }
+
+    @Test
+    fun testBuiltinsWithoutTopLevel() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val files =
+            listOf(topLevel.resolve("builtin.go"), topLevel.resolve("builtins/lengths.go")).map {
+                it.toFile()
+            }
+
+        // without a top level, each directory is loaded on its own
+        val result =
+            TestUtils.analyze(files, topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+                it.topLevel(null)
+            }
+
+        val lens = result.translationUnits.flatMap { tu -> tu.calls.filter { it.name == "len" } }
+        assertEquals(2, lens.size)
+
+        // the predeclared functions are still declared only once per translation
+        val len = lens.first().invokes.singleOrNull()
+        assertNotNull(len)
+        assertSame(len, lens.last().invokes.singleOrNull())
+    }
+
+    @Test
+    fun testClosure() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("closure.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val counter = (tu.namespaces.flatMap { it.functions })["counter"]
+        assertNotNull(counter)
+
+        val lambda = counter.allChildren<LambdaExpression>().firstOrNull()
+        assertNotNull(lambda)
+
+        // neither the parameter, nor the package-level variable is captured
+        assertEquals(listOf("count", "step"), lambda.capturedVariables.map { it.name })
+        assertTrue(lambda.capturesByReference)
+
+        val count = counter.variables["count"]
+        assertNotNull(count)
+        assertSame(count, lambda.capturedVariables.first())
+        assertTrue(lambda.prevDFG.contains(count))
+    }
+
+    @Test
+    fun testPromotion() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("promotion.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val use = (tu.namespaces.flatMap { it.functions })["use"]
+        assertNotNull(use)
+
+        // o.ID is accessed as o.Middle.Base.ID
+        val id = use.allChildren<MemberExpression> { it.name == "ID" }.firstOrNull()
+        assertNotNull(id)
+
+        val base = id.base as? MemberExpression
+        assertNotNull(base)
+        assertEquals("Base", base.name)
+        assertTrue(base.isImplicit)
+
+        val middle = base.base as? MemberExpression
+        assertNotNull(middle)
+        assertEquals("Middle", middle.name)
+        assertEquals("o", middle.base.name)
+
+        // o.Name is not promoted
+        val name = use.allChildren<MemberExpression> { it.name == "Name" }.firstOrNull()
+        assertNotNull(name)
+        assertEquals("o", name.base.name)
+
+        // the method is called on o.Middle.Base
+        val describe = use.calls["Describe"] as? MemberCallExpression
+        assertNotNull(describe)
+        assertEquals("Base", describe.base?.name)
+    }
+
+    @Test
+    fun testMethodValues() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("method_values.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val counter = tu.records["p.Counter"]
+        assertNotNull(counter)
+
+        val inc = counter.methods["Inc"]
+        assertNotNull(inc)
+
+        // a method value binds the receiver c as the base of the member
+        val value = tu.variables["inc"]?.initializer
+        assertIs<MemberExpression>(value)
+        assertEquals("c", value.base.name)
+        assertSame(inc, value.refersTo)
+        assertIs<FunctionType>(value.type)
+
+        // a method expression binds no receiver at all
+        val expr = tu.variables["incExpr"]?.initializer
+        assertNotNull(expr)
+        assertFalse(expr is MemberExpression)
+        assertIs<DeclaredReferenceExpression>(expr)
+        assertSame(inc, expr.refersTo)
+    }
+
+    @Test
+    fun testComputedCallees() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("callees.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val handler = tu.functions["handler"]
+        assertNotNull(handler)
+
+        val callees = tu.functions["callees"]
+        assertNotNull(callees)
+
+        val calls = callees.allChildren<CallExpression>()
+
+        // an immediately invoked function literal calls the function of the lambda
+        val immediate = calls.firstOrNull { it.callee is LambdaExpression }
+        assertNotNull(immediate)
+        assertEquals(
+            listOfNotNull((immediate.callee as LambdaExpression).function),
+            immediate.invokes
+        )
+
+        // the result of another call is called, not the other function itself
+        val higherOrder = calls.firstOrNull { it.callee is CallExpression }
+        assertNotNull(higherOrder)
+        assertEquals("", higherOrder.name)
+        assertFalse(handler in higherOrder.invokes)
+
+        val inner = higherOrder.callee as CallExpression
+        assertEquals(listOf(handler), inner.invokes)
+
+        // the callee is evaluated before the arguments
+        assertTrue(inner in higherOrder.arguments[0].prevEOG)
+
+        // as is the element of a slice
+        val element = calls.firstOrNull { it.callee is ArraySubscriptionExpression }
+        assertNotNull(element)
+        assertEquals("", element.name)
+    }
+
+    @Test
+    fun testCompositeLiterals() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("composite.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val composite = tu.functions["composite"]
+        assertNotNull(composite)
+
+        val last = tu.variables["last"]
+        assertNotNull(last)
+
+        // index keys of arrays are expressions on their own, not field names
+        val primes = composite.variables["primes"]?.initializer as? ConstructExpression
+        assertNotNull(primes)
+
+        var keys =
+            (primes.arguments[0] as InitializerListExpression).initializers.map {
+                (it as KeyValueExpression).key
+            }
+        assertEquals(2, (keys[0] as? Literal<*>)?.value)
+        assertSame(last, (keys[1] as? DeclaredReferenceExpression)?.refersTo)
+
+        // so are the keys of maps
+        val names = composite.variables["names"]?.initializer as? ConstructExpression
+        assertNotNull(names)
+
+        keys =
+            (names.arguments[0] as InitializerListExpression).initializers.map {
+                (it as KeyValueExpression).key
+            }
+        assertSame(last, (keys[0] as? DeclaredReferenceExpression)?.refersTo)
+
+        // nested literals take the element type of their enclosing literal
+        val points = composite.variables["points"]?.initializer as? ConstructExpression
+        assertNotNull(points)
+
+        val elements = (points.arguments[0] as InitializerListExpression).initializers
+        assertEquals(2, elements.size)
+
+        for (element in elements) {
+            assertIs<ConstructExpression>(element)
+            assertEquals("p.Point", element.type.name)
+        }
+
+        // whereas keys of structs still name their fields
+        val fields =
+            ((elements[1] as ConstructExpression).arguments[0] as InitializerListExpression)
+                .initializers
+                .map { ((it as KeyValueExpression).key as Literal<*>).value }
+        assertEquals(listOf("X", "Y"), fields)
+    }
+
+    @Test
+    fun testExpressionTypes() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("expression_types.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val typed = tu.functions["typed"]
+        assertNotNull(typed)
+
+        // every expression has the type determined by the type checker
+        val sum = typed.variables["sum"]?.initializer
+        assertIs<BinaryOperator>(sum)
+        assertEquals("int", sum.type.name)
+
+        val ratio = typed.variables["ratio"]?.initializer
+        assertIs<BinaryOperator>(ratio)
+        assertEquals("float64", ratio.type.name)
+        assertEquals("float64", ratio.lhs.type.name)
+
+        // including untyped constants, which take the type of their context
+        assertEquals("float64", ratio.rhs.type.name)
+
+        val count = typed.variables["count"]?.initializer
+        assertNotNull(count)
+        assertEquals("int", count.type.name)
+
+        val returnStmt = typed.bodyOrNull<ReturnStatement>()
+        assertNotNull(returnStmt)
+        assertEquals("bool", returnStmt.returnValue?.type?.name)
+    }
+
+    @Test
+    fun testSyncOperations() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("sync.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the primitive and the kind of the operation of each annotated call
+        val operations =
+            tu.calls.mapNotNull { call ->
+                val sync =
+                    call.annotations.firstOrNull { it.name == "sync" } ?: return@mapNotNull null
+                val members = sync.members.associate { it.name to (it.value as? Literal<*>)?.value }
+                assertEquals(call.name, members["method"])
+
+                call.name to Pair(members["primitive"], members["kind"])
+            }
+
+        assertEquals(
+            mapOf(
+                "Add" to Pair("WaitGroup", "add"),
+                "Done" to Pair("WaitGroup", "release"),
+                "Wait" to Pair("WaitGroup", "acquire"),
+                // promoted from the embedded mutex
+                "Lock" to Pair("Mutex", "acquire"),
+                "Unlock" to Pair("Mutex", "release"),
+                "Do" to Pair("Once", "once"),
+            ),
+            operations.toMap()
+        )
+        assertEquals(6, operations.size)
+    }
+
+    @Test
+    fun testHttpEndpoints() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("endpoints.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.endpoint(): Map<String, Any?>? {
+            val endpoint = annotations.firstOrNull { it.name == "endpoint" } ?: return null
+            return endpoint.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the method and path of each registration
+        val routes =
+            tu.calls.mapNotNull { call ->
+                val endpoint = call.endpoint() ?: return@mapNotNull null
+                assertEquals("net/http", endpoint["framework"])
+
+                endpoint["path"] to Pair(endpoint["method"], endpoint["handler"])
+            }
+
+        assertEquals(
+            mapOf(
+                "/users" to Pair("GET", "p.listUsers"),
+                "/health" to Pair("ANY", null),
+            ),
+            routes.filter { it.second.first != "POST" }.toMap()
+        )
+        assertEquals(3, routes.size)
+
+        // the declared handlers are marked as well
+        val listUsers = tu.functions["listUsers"]
+        assertNotNull(listUsers)
+        assertEquals("GET", listUsers.endpoint()?.get("method"))
+
+        val userAPI = tu.records["userAPI"]
+        assertNotNull(userAPI)
+
+        val createUser = userAPI.methods["createUser"]
+        assertNotNull(createUser)
+        assertEquals("POST", createUser.endpoint()?.get("method"))
+        assertEquals("p.(*userAPI).createUser", createUser.endpoint()?.get("handler"))
+
+        // as well as the function literal
+        val lambda = tu.allChildren<LambdaExpression>().singleOrNull()
+        assertNotNull(lambda)
+        assertEquals("/health", lambda.endpoint()?.get("path"))
+    }
+
+    @Test
+    fun testHttpFrameworks() {
+        val topLevel = Path.of("src", "test", "resources", "golang-libraries")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("web").resolve("web.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.endpoint(): Map<String, Any?>? {
+            val endpoint = annotations.firstOrNull { it.name == "endpoint" } ?: return null
+            return endpoint.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the framework, method and handler of each registration
+        val routes =
+            tu.calls.mapNotNull { call ->
+                val endpoint = call.endpoint() ?: return@mapNotNull null
+
+                endpoint["path"].toString() + " " + endpoint["method"] to
+                    Pair(endpoint["framework"], endpoint["handler"])
+            }
+
+        assertEquals(
+            mapOf(
+                // the last handler of gin follows the middlewares
+                "/users GET" to Pair("gin", "example.io/libraries/web.listUsers"),
+                "/users OPTIONS" to Pair("gin", "example.io/libraries/web.preflight"),
+                "/orders/:id GET" to Pair("echo", "example.io/libraries/web.getOrder"),
+                "/tunnel CONNECT" to Pair("echo", "example.io/libraries/web.tunnel"),
+                "/items GET" to Pair("chi", "example.io/libraries/web.listItems"),
+                "/items OPTIONS" to Pair("chi", "example.io/libraries/web.allowItems"),
+            ),
+            routes.toMap()
+        )
+        assertEquals(6, routes.size)
+
+        // the declared handlers are marked as well, but not the middlewares
+        for (name in listOf("listUsers", "preflight", "getOrder", "tunnel", "listItems")) {
+            val handler = tu.functions[name]
+            assertNotNull(handler)
+            assertNotNull(handler.endpoint(), name)
+        }
+
+        val authenticate = tu.functions["authenticate"]
+        assertNotNull(authenticate)
+        assertNull(authenticate.endpoint())
+    }
+
+    @Test
+    fun testSQLQueries() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("queries.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun CallExpression.sql(name: String): List<Any?> {
+            val sql = annotations.firstOrNull { it.name == "sql" } ?: return listOf()
+            return sql.members.filter { it.name == name }.map { (it.value as? Literal<*>)?.value }
+        }
+
+        // the constant parts of the query are folded
+        val queryRow = tu.calls["QueryRow"]
+        assertNotNull(queryRow)
+        assertEquals(
+            listOf("SELECT id, name, email FROM users WHERE email = ?"),
+            queryRow.sql("query")
+        )
+        assertEquals(listOf("SELECT"), queryRow.sql("kind"))
+        assertEquals(listOf("users"), queryRow.sql("table"))
+        assertEquals(listOf("id", "name", "email"), queryRow.sql("column"))
+        assertEquals(listOf(), queryRow.sql("dynamic"))
+
+        val exec = tu.calls["ExecContext"]
+        assertNotNull(exec)
+        assertEquals(listOf("UPDATE"), exec.sql("kind"))
+        assertEquals(listOf("users"), exec.sql("table"))
+        assertEquals(listOf("name", "id"), exec.sql("column"))
+
+        // the table is not known, if it is concatenated at runtime
+        val query = tu.calls["Query"]
+        assertNotNull(query)
+        assertEquals(listOf("SELECT COUNT(*) FROM ?"), query.sql("query"))
+        assertEquals(listOf(true), query.sql("dynamic"))
+        assertEquals(listOf(), query.sql("table"))
+    }
+
+    @Test
+    fun testPgxQueries() {
+        val topLevel = Path.of("src", "test", "resources", "golang-libraries")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("db").resolve("db.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun CallExpression.sql(name: String): List<Any?> {
+            val sql = annotations.firstOrNull { it.name == "sql" } ?: return listOf()
+            return sql.members.filter { it.name == name }.map { (it.value as? Literal<*>)?.value }
+        }
+
+        // the statement of Prepare follows its name
+        val prepare = tu.calls["Prepare"]
+        assertNotNull(prepare)
+        assertEquals(listOf("SELECT name FROM users WHERE id = $1"), prepare.sql("query"))
+        assertEquals(listOf("users"), prepare.sql("table"))
+
+        // while the other methods take it right after the context
+        val exec = tu.calls["Exec"]
+        assertNotNull(exec)
+        assertEquals(listOf("DELETE"), exec.sql("kind"))
+        assertEquals(listOf("users"), exec.sql("table"))
+    }
+
+    @Test
+    fun testSerializationAndEgress() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("egress.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun CallExpression.annotated(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the calls in the order of the file
+        assertEquals(
+            listOf(
+                mapOf("format" to "json", "type" to "*p.profileUpdate"),
+                mapOf("format" to "xml", "type" to "p.profileUpdate"),
+            ),
+            tu.calls.mapNotNull { it.annotated("serialization") }
+        )
+        assertEquals(
+            listOf(
+                mapOf("kind" to "http", "type" to "*net/http.Request"),
+                mapOf("kind" to "smtp", "type" to "[]byte"),
+            ),
+            tu.calls.mapNotNull { it.annotated("egress") }
+        )
+
+        // building a request does not send it yet
+        assertNull(tu.calls["NewRequest"]?.annotated("egress"))
+    }
+
+    @Test
+    fun testKafkaEgress() {
+        val topLevel = Path.of("src", "test", "resources", "golang-libraries")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("events").resolve("events.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun CallExpression.annotated(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        assertEquals(
+            mapOf("format" to "json", "type" to "example.io/libraries/events.signup"),
+            tu.calls["Marshal"]?.annotated("serialization")
+        )
+
+        // the messages follow the context
+        assertEquals(
+            mapOf("kind" to "kafka", "type" to "github.com/segmentio/kafka-go.Message"),
+            tu.calls["WriteMessages"]?.annotated("egress")
+        )
+
+        // closing the writer sends nothing
+        assertNull(tu.calls["Close"]?.annotated("egress"))
+    }
+
+    @Test
+    fun testConfigurationSources() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("settings.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the source and key of each setting, which is read
+        val settings =
+            tu.calls.mapNotNull { call ->
+                val config =
+                    call.annotations.firstOrNull { it.name == "config" } ?: return@mapNotNull null
+                val members =
+                    config.members.associate { it.name to (it.value as? Literal<*>)?.value }
+
+                call.name to Pair(members["source"], members["key"])
+            }
+
+        assertEquals(
+            listOf(
+                "String" to Pair("flag", "listen"),
+                "BoolVar" to Pair("flag", "verbose"),
+                "Getenv" to Pair("env", "APP_DATABASE_URL"),
+                // the key is only known at runtime
+                "LookupEnv" to Pair("env", null),
+            ),
+            settings
+        )
+    }
+
+    @Test
+    fun testPflagSources() {
+        val topLevel = Path.of("src", "test", "resources", "golang-libraries")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("settings").resolve("settings.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the key of each flag, which is declared
+        val flags =
+            tu.calls.mapNotNull { call ->
+                val config =
+                    call.annotations.firstOrNull { it.name == "config" } ?: return@mapNotNull null
+                val members =
+                    config.members.associate { it.name to (it.value as? Literal<*>)?.value }
+                assertEquals("flag", members["source"])
+
+                call.name.localName to members["key"]
+            }
+
+        // IP is a flag type of its own, while IPP is its variant with a shorthand
+        assertEquals(
+            listOf(
+                "IP" to "listen",
+                "IPP" to "advertise",
+                "IPVarP" to "bind",
+                "StringP" to "name",
+            ),
+            flags
+        )
+    }
+
+    @Test
+    fun testLoggingSinks() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("logging.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        // the method, severity and logged arguments of each logging call
+        val sinks =
+            tu.calls.mapNotNull { call ->
+                val logging =
+                    call.annotations.firstOrNull { it.name == "logging" } ?: return@mapNotNull null
+                val values = { name: String ->
+                    logging.members
+                        .filter { it.name == name }
+                        .map { (it.value as? Literal<*>)?.value }
+                }
+                assertEquals(listOf("log"), values("library"))
+
+                Triple(values("method").single(), values("severity"), values("argument"))
+            }
+
+        assertEquals(
+            listOf(
+                // the call depth is not logged
+                Triple("Output", listOf(), listOf(1)),
+                Triple("Printf", listOf(), listOf(0, 1)),
+                Triple("Panicln", listOf("panic"), listOf(0, 1)),
+            ),
+            sinks
+        )
+
+        // a method, which is only named like a logger, is no sink by itself
+        val printf = tu.calls.filter { it.name == "Printf" }
+        assertEquals(2, printf.size)
+        assertEquals(1, printf.count { call -> call.annotations.any { it.name == "logging" } })
+    }
+
+    @Test
+    fun testSlogSinks() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("slog.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val sinks =
+            tu.calls.mapNotNull { call ->
+                val logging =
+                    call.annotations.firstOrNull { it.name == "logging" } ?: return@mapNotNull null
+                val values = { name: String ->
+                    logging.members
+                        .filter { it.name == name }
+                        .map { (it.value as? Literal<*>)?.value }
+                }
+                assertEquals(listOf("slog"), values("library"))
+
+                Triple(values("method").single(), values("severity"), values("argument"))
+            }
+
+        assertEquals(
+            listOf(
+                // the attributes are logged along with each message
+                Triple("With", listOf(), listOf(0, 1)),
+                // the context is not logged
+                Triple("InfoContext", listOf("info"), listOf(1)),
+                Triple("Error", listOf("error"), listOf(0, 1, 2)),
+            ),
+            sinks
+        )
+    }
 }
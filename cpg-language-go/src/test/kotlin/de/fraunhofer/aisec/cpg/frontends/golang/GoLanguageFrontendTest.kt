@@ -25,20 +25,28 @@
  */
 package de.fraunhofer.aisec.cpg.frontends.golang
 
+import com.fasterxml.jackson.module.kotlin.jacksonObjectMapper
 import de.fraunhofer.aisec.cpg.BaseTest
 import de.fraunhofer.aisec.cpg.TestUtils.analyze
 import de.fraunhofer.aisec.cpg.TestUtils.analyzeAndGetFirstTU
 import de.fraunhofer.aisec.cpg.graph.*
 import de.fraunhofer.aisec.cpg.graph.declarations.*
+import de.fraunhofer.aisec.cpg.graph.edge.Properties
 import de.fraunhofer.aisec.cpg.graph.statements.*
 import de.fraunhofer.aisec.cpg.graph.statements.expressions.*
 import de.fraunhofer.aisec.cpg.graph.types.FunctionType
 import de.fraunhofer.aisec.cpg.graph.types.TypeParser
+import java.math.BigInteger
 import java.nio.file.Path
+import java.util.concurrent.Callable
+import java.util.concurrent.Executors
 import kotlin.test.Ignore
 import kotlin.test.Test
+import kotlin.test.assertContains
 import kotlin.test.assertEquals
+import kotlin.test.assertFalse
 import kotlin.test.assertNotNull
+import kotlin.test.assertNull
 import kotlin.test.assertTrue
 
 class GoLanguageFrontendTest : BaseTest() {
@@ -173,6 +181,9 @@ class GoLanguageFrontendTest : BaseTest() {
         assertTrue(make is ConstructExpression)
         assertEquals(TypeParser.createFrom("map<string,string>", GoLanguage()), make.type)
 
+        // the size of the map is only a hint
+        assertEquals("size", make.argumentsEdges.first().getProperty(Properties.NAME))
+
         // make channel
 
         stmt = main.body(3)
@@ -185,6 +196,27 @@ class GoLanguageFrontendTest : BaseTest() {
         assertNotNull(make)
         assertTrue(make is ConstructExpression)
         assertEquals(TypeParser.createFrom("chan<int>", GoLanguage()), make.type)
+        assertTrue(make.arguments.isEmpty())
+
+        var buffered = make.annotations.firstOrNull { it.name == "channel" }?.members?.firstOrNull()
+        assertEquals("buffered", buffered?.name)
+        assertEquals(false, (buffered?.value as? Literal<*>)?.value)
+
+        // make buffered channel
+
+        stmt = main.body(4)
+        assertNotNull(stmt)
+
+        decl = stmt.singleDeclaration as? VariableDeclaration
+        assertNotNull(decl)
+
+        make = decl.initializer
+        assertNotNull(make)
+        assertTrue(make is ConstructExpression)
+        assertEquals("capacity", make.argumentsEdges.first().getProperty(Properties.NAME))
+
+        buffered = make.annotations.firstOrNull { it.name == "channel" }?.members?.firstOrNull()
+        assertEquals(true, (buffered?.value as? Literal<*>)?.value)
     }
 
     @Test
@@ -203,6 +235,15 @@ class GoLanguageFrontendTest : BaseTest() {
         val a = (p.flatMap { it.variables })["a"]
         assertNotNull(a)
         assertNotNull(a.location)
+        assertEquals(3, a.location?.region?.startLine)
+        assertEquals(7, a.location?.region?.startColumn)
+        assertEquals(3, a.location?.region?.endLine)
+        assertEquals(12, a.location?.region?.endColumn)
+        assertEquals(17, a.location?.region?.startOffset)
+        assertEquals(22, a.location?.region?.endOffset)
+        assertEquals("a = 1", a.code)
+        // the location is set when a is created, so it is still found in hash-based collections
+        assertTrue(a.initializer?.typeListeners?.contains(a) == true)
 
         assertEquals("a", a.name)
         assertEquals(TypeParser.createFrom("int", GoLanguage()), a.type)
@@ -230,6 +271,34 @@ class GoLanguageFrontendTest : BaseTest() {
         assertNotNull(nil)
         assertEquals("nil", nil.name)
         assertEquals(null, nil.value)
+
+        // string literals have their runtime value
+        val esc = (p.flatMap { it.variables })["esc"]
+        assertNotNull(esc)
+        assertEquals("line\n\"quoted\" \u00e4", (esc.initializer as? Literal<*>)?.value)
+
+        val raw = (p.flatMap { it.variables })["raw"]
+        assertNotNull(raw)
+        assertEquals("C:\\path\\n", (raw.initializer as? Literal<*>)?.value)
+
+        // all forms of numeric literals are parsed
+        val values =
+            listOf("hex", "bin", "oct", "sep", "big", "max", "hf", "im").associateWith {
+                ((p.flatMap { v -> v.variables })[it]?.initializer as? Literal<*>)?.value
+            }
+        assertEquals(255, values["hex"])
+        assertEquals(10, values["bin"])
+        assertEquals(15, values["oct"])
+        assertEquals(1000000, values["sep"])
+        assertEquals(5000000000L, values["big"])
+        assertEquals(BigInteger("18446744073709551615"), values["max"])
+        assertEquals(0.25, values["hf"])
+        assertEquals(Complex(0.0, 2.5), values["im"])
+
+        // the code is taken verbatim from the source
+        val sum = (p.flatMap { it.variables })["sum"]
+        assertNotNull(sum)
+        assertEquals("1 +   2", sum.initializer?.code)
     }
 
     @Test
@@ -701,6 +770,118 @@ class GoLanguageFrontendTest : BaseTest() {
         assertTrue(call.invokes.contains(newAwesome))
     }
 
+    @Test
+    fun testNestedModules() {
+        val topLevel = Path.of("src", "test", "resources", "golang-modules")
+        val result =
+            analyze(
+                listOf(
+                    topLevel.resolve("awesome.go").toFile(),
+                    topLevel.resolve("plugin/plugin.go").toFile(),
+                    topLevel.resolve("plugin/version/version.go").toFile(),
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        assertNotNull(result)
+        val namespaces = result.translationUnits.flatMap { it.namespaces }
+
+        // the packages of the nested module are named after its own module path
+        val plugin = namespaces.firstOrNull { it.name == "example.io/plugin" }
+        assertNotNull(plugin)
+
+        val version = namespaces.firstOrNull { it.name == "example.io/plugin/version" }
+        assertNotNull(version)
+        assertTrue(namespaces.none { it.name.startsWith("example.io/awesome/plugin") })
+
+        val describe = plugin.functions["Describe"]
+        assertNotNull(describe)
+
+        val versionFunc = version.functions["Version"]
+        assertNotNull(versionFunc)
+
+        val call = describe.calls.singleOrNull()
+        assertNotNull(call)
+        assertTrue(call.invokes.contains(versionFunc))
+    }
+
+    @Test
+    fun testReplaceDirective() {
+        val topLevel = Path.of("src", "test", "resources", "golang-modules")
+        val result =
+            analyze(
+                listOf(
+                    topLevel.resolve("cmd/fork/main.go").toFile(),
+                    topLevel.resolve("forked/forked.go").toFile(),
+                ),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        assertNotNull(result)
+        val (main, forked) = result.translationUnits
+
+        fun TranslationUnitDeclaration.annotated(name: String) =
+            annotations
+                .filter { it.name == name }
+                .map { a -> a.members.associate { it.name to (it.value as? Literal<*>)?.value } }
+
+        // the replaced module is imported under the path of the replace directive
+        val namespace = forked.namespaces.firstOrNull { it.name == "example.io/forked" }
+        assertNotNull(namespace)
+
+        val fork = namespace.functions["Fork"]
+        assertNotNull(fork)
+
+        val call = main.calls.firstOrNull { it.name == "Fork" }
+        assertNotNull(call)
+        assertTrue(call.invokes.contains(fork))
+
+        assertEquals(
+            listOf(
+                mapOf(
+                    "path" to "example.io/forked",
+                    "dir" to "forked",
+                    "go" to "1.16",
+                    "declared" to "github.com/someone/forked"
+                )
+            ),
+            forked.annotated("module")
+        )
+        assertEquals(
+            listOf(mapOf("old" to "example.io/forked", "new" to "./forked")),
+            main.annotated("replace")
+        )
+    }
+
+    @Test
+    fun testModuleMetadata() {
+        val topLevel = Path.of("src", "test", "resources", "golang-modules")
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("awesome.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+        assertNotNull(tu)
+
+        fun annotated(name: String) =
+            tu.annotations
+                .filter { it.name == name }
+                .map { a -> a.members.associate { it.name to (it.value as? Literal<*>)?.value } }
+
+        // the content of the go.mod file is available as a dependency inventory
+        assertEquals(
+            listOf(mapOf("path" to "example.io/awesome", "dir" to "", "go" to "1.16")),
+            annotated("module")
+        )
+        assertEquals(
+            listOf(
+                mapOf("path" to "example.io/forked", "version" to "v0.0.0", "indirect" to false)
+            ),
+            annotated("require")
+        )
+    }
+
     @Test
     @Ignore
     fun testComments() {
@@ -763,4 +944,733 @@ class GoLanguageFrontendTest : BaseTest() {
 
         assertNotNull(tu)
     }
+
+    @Test
+    fun testVariadic() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("variadic.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+
+        val mainPackage = tu.namespaces.filter { it.name == "p" }
+        assertNotNull(mainPackage)
+
+        val sum = (mainPackage.flatMap { it.functions })["sum"]
+        assertNotNull(sum)
+        assertEquals(2, sum.parameters.size)
+        assertFalse(sum.parameters[0].isVariadic)
+
+        val numbers = sum.parameters[1]
+        assertTrue(numbers.isVariadic)
+        assertEquals("int", numbers.type.name)
+
+        val main = (mainPackage.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        var call = main.bodyOrNull<CallExpression>(0)
+        assertNotNull(call)
+        assertEquals(3, call.arguments.size)
+        assertTrue(call.invokes.contains(sum))
+
+        call = main.bodyOrNull(1)
+        assertNotNull(call)
+        assertEquals(2, call.arguments.size)
+
+        val spread = call.arguments[1] as? UnaryOperator
+        assertNotNull(spread)
+        assertEquals("...", spread.operatorCode)
+        assertTrue(spread.isPostfix)
+        assertEquals("numbers", spread.input.name)
+    }
+
+    @Test
+    fun testWithoutTypeCheck() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().typeCheck(false).build()
+
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("function.go").toFile()), topLevel, true) {
+                it.registerLanguage(language)
+            }
+
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.size > 0)
+
+        val myTest = (p.flatMap { it.functions })["myTest"]
+        assertNotNull(myTest)
+        assertEquals(1, myTest.parameters.size)
+
+        // references are still resolved, although there is no type information
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val call = main.allChildren<CallExpression>().firstOrNull { it.name == "myTest" }
+        assertNotNull(call)
+        assertEquals(listOf(myTest), call.invokes)
+    }
+
+    @Test
+    fun testConfiguration() {
+        val configuration =
+            GoConfiguration.builder()
+                .includeTests(true)
+                .buildTag("integration")
+                .dependency("github.com/aws/aws-sdk-go-v2/...")
+                .logLevel("warn")
+                .build()
+
+        // the names must match the ones of the Config struct of the native frontend
+        val json = jacksonObjectMapper().readTree(configuration.toJson())
+        assertTrue(json["includeTests"].asBoolean())
+        assertEquals(listOf("integration"), json["buildTags"].map { it.asText() })
+        assertFalse(json["vendor"].asBoolean())
+        assertTrue(json["typeCheck"].asBoolean())
+        assertEquals(0, json["symbolLimit"].asInt())
+        assertEquals(
+            listOf("github.com/aws/aws-sdk-go-v2/..."),
+            json["dependencies"].map { it.asText() }
+        )
+        assertEquals("warn", json["logLevel"].asText())
+        assertNull(json["files"])
+
+        // the selected files are only included, if specified
+        val withFiles = jacksonObjectMapper().readTree(configuration.toJson(listOf("/p/main.go")))
+        assertEquals(listOf("/p/main.go"), withFiles["files"].map { it.asText() })
+
+        assertEquals(
+            GoConfiguration.DEFAULT_IGNORE + "generated",
+            GoConfiguration.builder().ignore("generated").build().ignore
+        )
+    }
+
+    @Test
+    fun testIgnore() {
+        val topLevel = Path.of("src", "test", "resources", "golang-ignore")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().ignore("generated").build()
+
+        val result =
+            analyze(listOf(topLevel.toFile()), topLevel, true) { it.registerLanguage(language) }
+
+        val main = result.translationUnits.firstOrNull { it.name.endsWith("main.go") }
+        assertNotNull(main)
+        assertNotNull(main.functions["main"])
+
+        // both the configured and the default directories are skipped
+        for (name in listOf("generated.go", "old.go")) {
+            val tu = result.translationUnits.firstOrNull { it.name.endsWith(name) }
+            assertNotNull(tu)
+            assertTrue(tu.declarations.isEmpty(), "$name is not skipped")
+        }
+    }
+
+    @Test
+    fun testDependencies() {
+        val topLevel = Path.of("src", "test", "resources", "golang-libraries")
+        val language = GoLanguage()
+        language.configuration =
+            GoConfiguration.builder().dependency("example.io/greetings/...").build()
+
+        val result =
+            analyze(listOf(topLevel.resolve("app").resolve("app.go").toFile()), topLevel, true) {
+                it.registerLanguage(language)
+            }
+
+        // the stub of the dependency is part of the result, although it is no source location
+        assertEquals(2, result.translationUnits.size)
+
+        val greetings =
+            result.translationUnits.firstOrNull { tu ->
+                tu.annotations.any { it.name == "dependency" }
+            }
+        assertNotNull(greetings)
+
+        // only its exported declarations are translated
+        val hello = greetings.functions["Hello"]
+        assertNotNull(hello)
+        assertNull(greetings.functions["greeting"])
+
+        // so that calls into the dependency are resolved
+        val call = result.translationUnits.first().calls["Hello"]
+        assertNotNull(call)
+        assertContains(call.invokes, hello)
+    }
+
+    @Test
+    fun testBuildConstraints() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration =
+            GoConfiguration.builder().goos("linux").goarch("arm64").buildTag("integration").build()
+
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("build.go").toFile()), topLevel, true) {
+                it.registerLanguage(language)
+            }
+
+        assertNotNull(tu)
+
+        val build = tu.annotations.firstOrNull { it.name == "build" }
+        assertNotNull(build)
+
+        val members = build.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        assertEquals("linux", members["goos"])
+        assertEquals("arm64", members["goarch"])
+        assertEquals("integration", members["tags"])
+        assertEquals("!windows", members["constraint"])
+    }
+
+    @Test
+    fun testExcludedByBuildConstraints() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("ignored.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+        assertNotNull(tu)
+
+        // the file is not part of any package, so it is not translated at all
+        assertTrue(tu.functions.isEmpty())
+        assertNull(tu.annotations.firstOrNull { it.name == "build" })
+    }
+
+    @Test
+    fun testOverlay() {
+        val topLevel = Path.of("src", "test", "resources", "golang-overlay")
+        val a = topLevel.resolve("a.go").toFile()
+        val b = topLevel.resolve("b.go").toFile()
+
+        // the unsaved buffers of both files differ from the files on disk
+        val language = GoLanguage()
+        language.configuration =
+            GoConfiguration.builder()
+                .overlay(
+                    a,
+                    """
+                    package overlay
+
+                    const key = "APP_TOKEN"
+
+                    func a() {}
+                    """
+                        .trimIndent()
+                )
+                .overlay(
+                    b,
+                    """
+                    package overlay
+
+                    import "os"
+
+                    func b() string {
+                        return os.Getenv(key)
+                    }
+                    """
+                        .trimIndent()
+                )
+                .build()
+
+        val result = analyze(listOf(a, b), topLevel, true) { it.registerLanguage(language) }
+
+        val tu = result.translationUnits.firstOrNull { it.name.endsWith("b.go") }
+        assertNotNull(tu)
+
+        // the call is only known, if the package was loaded with both buffers, since the constant
+        // is only declared in the one of the other file
+        val getenv = tu.calls["Getenv"]
+        assertNotNull(getenv)
+
+        val config = getenv.annotations.firstOrNull { it.name == "config" }
+        assertNotNull(config)
+        assertEquals(
+            "APP_TOKEN",
+            (config.members.firstOrNull { it.name == "key" }?.value as? Literal<*>)?.value
+        )
+    }
+
+    @Test
+    fun testVendor() {
+        val topLevel = Path.of("src", "test", "resources", "golang-vendor")
+        val result = analyze(listOf(topLevel.toFile()), topLevel, true) {
+            it.registerLanguage<GoLanguage>()
+        }
+
+        val main = result.translationUnits.firstOrNull { it.name.endsWith("main.go") }
+        assertNotNull(main)
+        assertNotNull(main.functions["main"])
+
+        // the vendored file is among the files, but it is only used to resolve imports
+        val quotes = result.translationUnits.firstOrNull { it.name.endsWith("quotes.go") }
+        assertNotNull(quotes)
+        assertTrue(quotes.functions.isEmpty())
+        assertTrue(quotes.namespaces.isEmpty())
+    }
+
+    @Test
+    fun testBrokenFile() {
+        val topLevel = Path.of("src", "test", "resources", "golang-broken")
+        val result =
+            analyze(listOf(topLevel.toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+                it.failOnError(false)
+            }
+
+        // the file without a package clause fails on its own
+        assertNull(result.translationUnits.firstOrNull { it.name.endsWith("bad.go") })
+
+        // while the other files are still translated
+        val good = result.translationUnits.firstOrNull { it.name.endsWith("good.go") }
+        assertNotNull(good)
+        assertNotNull(good.functions["good"])
+
+        val other = result.translationUnits.firstOrNull { it.name.endsWith("other.go") }
+        assertNotNull(other)
+        assertNotNull(other.functions["other"])
+    }
+
+    @Test
+    fun testDirectives() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("directive.go").toFile()),
+                topLevel,
+                true
+            ) {
+                it.registerLanguage<GoLanguage>()
+            }
+
+        assertNotNull(tu)
+
+        fun Annotation.arguments() =
+            (members.firstOrNull { it.name == "arguments" }?.value as? InitializerListExpression)
+                ?.initializers
+                ?.map { (it as? Literal<*>)?.value }
+
+        // the build constraint is only part of the build annotation, not a directive of its own
+        assertNull(tu.annotations.firstOrNull { it.name == "go:build" })
+
+        val build = tu.annotations.firstOrNull { it.name == "build" }
+        assertNotNull(build)
+        assertEquals(
+            "go1.18",
+            (build.members.firstOrNull { it.name == "constraint" }?.value as? Literal<*>)?.value
+        )
+
+        // directives that do not belong to a declaration are placed at the translation unit
+
+        val generate = tu.annotations.firstOrNull { it.name == "go:generate" }
+        assertNotNull(generate)
+        assertEquals(listOf("stringer", "-type=Color", "a b"), generate.arguments())
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val directive = (p.flatMap { it.functions })["directive"]
+        assertNotNull(directive)
+        assertEquals(listOf("go:noinline", "nolint"), directive.annotations.map { it.name })
+
+        val nolint = directive.annotations.firstOrNull { it.name == "nolint" }
+        assertNotNull(nolint)
+        assertEquals(listOf("gomnd", "revive"), nolint.arguments())
+
+        val unused = (p.flatMap { it.variables })["unused"]
+        assertNotNull(unused)
+        assertEquals(listOf("nolint"), unused.annotations.map { it.name })
+        assertEquals(listOf<Any?>(), unused.annotations.first().arguments())
+    }
+
+    @Test
+    fun testEmbed() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("assets.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+        assertNotNull(tu)
+
+        fun VariableDeclaration.patterns() =
+            (annotations
+                    .firstOrNull { it.name == "embed" }
+                    ?.members
+                    ?.firstOrNull { it.name == "patterns" }
+                    ?.value as? InitializerListExpression)
+                ?.initializers
+                ?.map { (it as? Literal<*>)?.value }
+
+        val files = tu.variables["embeddedFiles"]
+        assertNotNull(files)
+        assertEquals("embed.FS", files.type.name)
+        assertEquals(listOf("embedded.txt"), files.patterns())
+
+        val text = tu.variables["embeddedText"]
+        assertNotNull(text)
+        assertEquals("string", text.type.name)
+        assertEquals(listOf("embedded.txt"), text.patterns())
+
+        // the embedded content flows from an implicit initializer at the directive
+        val init = text.initializer as? Literal<*>
+        assertNotNull(init)
+        assertTrue(init.isImplicit)
+        assertNull(init.value)
+        assertEquals("string", init.type.name)
+        assertEquals(10, init.location?.region?.startLine)
+        assertTrue(text.prevDFG.contains(init))
+    }
+
+    @Test
+    fun testTestFiles() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+
+        // test files are excluded by default
+        var tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("sample_test.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        assertNotNull(tu)
+        assertTrue(tu.declarations.isEmpty())
+
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().includeTests(true).build()
+
+        tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("sample_test.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        assertNotNull(tu)
+
+        val test = tu.annotations.firstOrNull { it.name == "test" }
+        assertNotNull(test)
+        assertEquals("p", (test.members.firstOrNull()?.value as? Literal<*>)?.value)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertNotNull((p.flatMap { it.functions })["TestSample"])
+    }
+
+    @Test
+    fun testGeneratedFiles() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+
+        // generated files are translated by default, but marked as such
+        var tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("generated.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+
+        assertNotNull(tu)
+        assertTrue(tu.isGenerated)
+        assertNotNull(tu.functions["generatedColorName"])
+
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().skipGenerated(true).build()
+
+        tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("generated.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        assertNotNull(tu)
+        assertTrue(tu.isGenerated)
+        assertTrue(tu.declarations.isEmpty())
+
+        // handwritten files are never marked
+        tu =
+            analyzeAndGetFirstTU(listOf(topLevel.resolve("call.go").toFile()), topLevel, true) {
+                it.registerLanguage<GoLanguage>()
+            }
+
+        assertNotNull(tu)
+        assertFalse(tu.isGenerated)
+    }
+
+    @Test
+    fun testTestFunctions() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val language = GoLanguage()
+        language.configuration = GoConfiguration.builder().includeTests(true).build()
+
+        val tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("sample_test.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+
+        assertNotNull(tu)
+
+        val functions = tu.namespaces.filter { it.name == "p" }.flatMap { it.functions }
+
+        for ((name, kind) in
+            listOf(
+                "TestSample" to "Test",
+                "BenchmarkSample" to "Benchmark",
+                "FuzzSample" to "Fuzz"
+            )) {
+            val f = functions[name]
+            assertNotNull(f)
+            assertEquals(listOf(kind), f.annotations.map { it.name })
+        }
+
+        for (name in listOf("ExampleSample", "Testsample", "TestHelper")) {
+            val f = functions[name]
+            assertNotNull(f)
+            assertTrue(f.annotations.isEmpty())
+        }
+    }
+
+    @Test
+    fun testSyntaxErrors() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("syntax").resolve("syntax.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(GoLanguage()) }
+
+        assertNotNull(tu)
+
+        // the parseable parts of the file are still translated
+        val functions = tu.namespaces.filter { it.name == "p/syntax" }.flatMap { it.functions }
+        assertNotNull(functions["valid"])
+
+        val broken = functions["broken"]
+        assertNotNull(broken)
+        assertTrue(broken.allChildren<ProblemExpression>().isNotEmpty())
+
+        // the broken regions are represented by problems
+        val problem = tu.declarations.filterIsInstance<ProblemDeclaration>().firstOrNull()
+        assertNotNull(problem)
+        assertEquals(12, problem.location?.region?.startLine)
+    }
+
+    @Test
+    fun testProgress() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val progress = mutableListOf<Triple<GoProgressListener.Phase, Int, Int>>()
+        val language = GoLanguage()
+        language.progressListener = GoProgressListener { phase, done, total ->
+            progress += Triple(phase, done, total)
+        }
+
+        val tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("methods").resolve("server.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(language) }
+        assertNotNull(tu)
+
+        // the phases are reported in order
+        val phases = progress.map { it.first }.distinct()
+        assertEquals(
+            listOf(
+                GoProgressListener.Phase.DISCOVERED,
+                GoProgressListener.Phase.LOADED,
+                GoProgressListener.Phase.TRANSLATED
+            ),
+            phases
+        )
+
+        // the total of the discovered files is reported, once they are all discovered
+        val discovered = progress.last { it.first == GoProgressListener.Phase.DISCOVERED }
+        assertTrue(discovered.second > 0)
+        assertEquals(discovered.third, discovered.second)
+
+        // all loaded files are counted
+        val loaded = progress.last { it.first == GoProgressListener.Phase.LOADED }
+        assertEquals(loaded.third, loaded.second)
+
+        val translated = progress.last { it.first == GoProgressListener.Phase.TRANSLATED }
+        assertEquals(1, translated.second)
+        assertTrue(translated.third >= translated.second)
+    }
+
+    @Test
+    fun testConcurrentProjects() {
+        val resources = Path.of("src", "test", "resources")
+        val projects =
+            listOf(
+                Triple(resources.resolve("golang-orm"), "models/models.go", "Migrate"),
+                Triple(resources.resolve("golang-libraries"), "db/db.go", "prepareUser")
+            )
+
+        val executor = Executors.newFixedThreadPool(projects.size)
+        try {
+            val futures =
+                projects.map { (topLevel, file, _) ->
+                    executor.submit(
+                        Callable {
+                            analyze(listOf(topLevel.resolve(file).toFile()), topLevel, true) {
+                                it.registerLanguage(GoLanguage())
+                            }
+                        }
+                    )
+                }
+
+            // each translation only resets the state of its own project, so that neither one
+            // affects the other
+            for ((future, project) in futures.zip(projects)) {
+                val tu = future.get().translationUnits.firstOrNull()
+                assertNotNull(tu)
+                assertNotNull(tu.functions[project.third])
+            }
+        } finally {
+            executor.shutdown()
+        }
+    }
+
+    @Test
+    fun testCancel() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val files =
+            listOf("server.go", "handlers.go", "run.go").map {
+                topLevel.resolve("methods").resolve(it).toFile()
+            }
+        val language = GoLanguage()
+
+        // the translation is cancelled, once its first file is translated
+        language.progressListener = GoProgressListener { phase, done, _ ->
+            if (phase == GoProgressListener.Phase.TRANSLATED && done == 1) {
+                GoLanguageFrontend.cancel()
+            }
+        }
+
+        val result =
+            analyze(files, topLevel, false) {
+                it.registerLanguage(language)
+                it.failOnError(false)
+            }
+
+        // none of the remaining files is translated
+        assertEquals(1, result.translationUnits.size)
+
+        // while the next translation is not affected
+        val tu =
+            analyzeAndGetFirstTU(files, topLevel, false) { it.registerLanguage(GoLanguage()) }
+        assertNotNull(tu)
+    }
+
+    @Test
+    fun testMetrics() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("call.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage(GoLanguage()) }
+        assertNotNull(tu)
+
+        val metrics = GoLanguageFrontend.metrics(topLevel.toFile())
+        assertNotNull(metrics)
+
+        // the nodes of the file are counted by their kind
+        assertTrue((metrics.handled["CallExpr"] ?: 0) > 0)
+        assertTrue((metrics.handled["FuncDecl"] ?: 0) > 0)
+        assertTrue(metrics.unhandled.keys.all { it in metrics.samples })
+
+        assertTrue("load" in metrics.phases)
+        assertTrue("translate" in metrics.phases)
+    }
+
+    @Test
+    fun testDeterministicOrder() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val files =
+            listOf("server.go", "handlers.go", "run.go").map {
+                topLevel.resolve("methods").resolve(it).toFile()
+            } + topLevel.resolve("implements.go").toFile()
+
+        fun translate(): List<String> {
+            val result = analyze(files, topLevel, true) { it.registerLanguage(GoLanguage()) }
+
+            return result.translationUnits.flatMap { tu ->
+                tu.allChildren<Declaration>().map { "${tu.name} ${it.name}" } +
+                    tu.allChildren<RecordDeclaration>().flatMap { r ->
+                        r.superClasses.map { "${r.name} ${it.name}" }
+                    }
+            }
+        }
+
+        // identical projects result in identical graphs
+        assertEquals(translate(), translate())
+    }
+
+    @Test
+    fun testLocationUri() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val file = topLevel.resolve("call.go").toFile()
+        val tu =
+            analyzeAndGetFirstTU(listOf(file), topLevel, true) {
+                it.registerLanguage(GoLanguage())
+            }
+        assertNotNull(tu)
+
+        // the native paths of the files are converted into proper file URIs
+        val main = tu.functions["main"]
+        assertNotNull(main)
+
+        val uri = main.location?.artifactLocation?.uri
+        assertNotNull(uri)
+        assertEquals("file", uri.scheme)
+        assertEquals(file.absoluteFile.toPath(), Path.of(uri))
+    }
+
+    @Test
+    fun testLineDirective() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val file = topLevel.resolve("line.go").toFile()
+        val tu =
+            analyzeAndGetFirstTU(listOf(file), topLevel, true) {
+                it.registerLanguage(GoLanguage())
+            }
+        assertNotNull(tu)
+
+        val func = tu.functions["lineDirective"]
+        assertNotNull(func)
+
+        // the location points to the original source of the generated code
+        val location = func.location
+        assertNotNull(location)
+        assertEquals(
+            file.absoluteFile.toPath().resolveSibling("grammar.y"),
+            Path.of(location.artifactLocation.uri)
+        )
+        assertEquals(10, location.region.startLine)
+        assertEquals(1, location.region.startColumn)
+
+        // the file, from which it was parsed, is kept as well
+        val physical = func.physicalLocation
+        assertNotNull(physical)
+        assertEquals(file.absoluteFile.toPath(), Path.of(physical.artifactLocation.uri))
+        assertEquals(6, physical.region.startLine)
+
+        // nodes spanning another directive are located in the file, from which they were parsed
+        val spanning = tu.functions["spanning"]
+        assertNotNull(spanning)
+        val spanningLocation = spanning.location
+        assertNotNull(spanningLocation)
+        assertEquals(file.absoluteFile.toPath(), Path.of(spanningLocation.artifactLocation.uri))
+        assertEquals(10, spanningLocation.region.startLine)
+        assertEquals(14, spanningLocation.region.endLine)
+        assertNull(spanning.physicalLocation)
+
+        // as is the file itself
+        assertNotNull(tu.location)
+        assertNull(tu.physicalLocation)
+    }
 }
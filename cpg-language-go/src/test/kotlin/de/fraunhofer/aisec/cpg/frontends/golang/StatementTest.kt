@@ -0,0 +1,663 @@
+/*
+ * Copyright (c) 2022, Fraunhofer AISEC. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *                    $$$$$$\  $$$$$$$\   $$$$$$\
+ *                   $$  __$$\ $$  __$$\ $$  __$$\
+ *                   $$ /  \__|$$ |  $$ |$$ /  \__|
+ *                   $$ |      $$$$$$$  |$$ |$$$$\
+ *                   $$ |      $$  ____/ $$ |\_$$ |
+ *                   $$ |  $$\ $$ |      $$ |  $$ |
+ *                   \$$$$$   |$$ |      \$$$$$   |
+ *                    \______/ \__|       \______/
+ *
+ */
+package de.fraunhofer.aisec.cpg.frontends.golang
+
+import de.fraunhofer.aisec.cpg.TestUtils
+import de.fraunhofer.aisec.cpg.graph.*
+import de.fraunhofer.aisec.cpg.graph.declarations.*
+import de.fraunhofer.aisec.cpg.graph.statements.*
+import de.fraunhofer.aisec.cpg.graph.statements.expressions.*
+import de.fraunhofer.aisec.cpg.graph.types.TypeParser
+import java.nio.file.Path
+import kotlin.test.assertEquals
+import kotlin.test.assertFalse
+import kotlin.test.assertIs
+import kotlin.test.assertNotNull
+import kotlin.test.assertNotSame
+import kotlin.test.assertNull
+import kotlin.test.assertSame
+import kotlin.test.assertTrue
+import org.junit.jupiter.api.Test
+
+class StatementTest {
+    @Test
+    fun testDefer() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("defer.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        assertTrue(p.isNotEmpty())
+
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val defer = main.bodyOrNull<UnaryOperator>(0)
+        assertNotNull(defer)
+        assertEquals("defer", defer.operatorCode)
+
+        val call = defer.input as? CallExpression
+        assertNotNull(call)
+        assertEquals("cleanup", call.name)
+    }
+
+    @Test
+    fun testDeferOrder() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("defer_order.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val tx = (p.flatMap { it.functions })["tx"]
+        assertNotNull(tx)
+
+        val open = tx.calls["open"]
+        val first = tx.calls["first"]
+        val second = tx.calls["second"]
+        assertNotNull(open)
+        assertNotNull(first)
+        assertNotNull(second)
+
+        // the deferred calls are not executed at the defer statement
+        val defer = tx.bodyOrNull<UnaryOperator>(0)
+        assertNotNull(defer)
+        assertEquals(listOf<Node>(open), defer.prevEOG)
+
+        // but in reverse order at each return
+        val returns = tx.allChildren<ReturnStatement>()
+        assertEquals(2, returns.size)
+        returns.forEach { assertTrue(second in it.nextEOG) }
+        assertEquals(listOf<Node>(first), second.nextEOG)
+    }
+
+    @Test
+    fun testRecover() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("recover.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val safe = (p.flatMap { it.functions })["safe"]
+        assertNotNull(safe)
+
+        // statements before the defer are not covered by the recover
+        val prepare = safe.bodyOrNull<CallExpression>(0)
+        assertNotNull(prepare)
+        assertEquals("prepare", prepare.name)
+
+        val tryStatement = safe.bodyOrNull<TryStatement>(0)
+        assertNotNull(tryStatement)
+
+        val panic = tryStatement.tryBlock?.statements?.firstOrNull() as? CallExpression
+        assertNotNull(panic)
+        assertEquals("panic", panic.name)
+
+        val defer = tryStatement.finallyBlock?.statements?.firstOrNull() as? UnaryOperator
+        assertNotNull(defer)
+        assertEquals("defer", defer.operatorCode)
+        assertTrue(defer.input is CallExpression)
+    }
+
+    @Test
+    fun testGo() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("goroutine.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val spawn = main.bodyOrNull<UnaryOperator>(0)
+        assertNotNull(spawn)
+        assertEquals("go", spawn.operatorCode)
+
+        val async = spawn.input as? CallExpression
+        assertNotNull(async)
+        assertEquals("work", async.name)
+
+        // the second call is an ordinary, synchronous call
+        val sync = main.bodyOrNull<CallExpression>(0)
+        assertNotNull(sync)
+        assertEquals("work", sync.name)
+        assertEquals(async.invokes, sync.invokes)
+    }
+
+    @Test
+    fun testSelect() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("select.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val select = main.bodyOrNull<SwitchStatement>(0)
+        assertNotNull(select)
+
+        val list = select.statement as? CompoundStatement
+        assertNotNull(list)
+
+        val cases = list.statements.filterIsInstance<CaseStatement>()
+        assertEquals(4, cases.size)
+
+        val send = cases[0].caseExpression as? BinaryOperator
+        assertNotNull(send)
+        assertEquals("<-", send.operatorCode)
+
+        val receive = cases[1].caseExpression as? UnaryOperator
+        assertNotNull(receive)
+        assertEquals("<-", receive.operatorCode)
+
+        // the variables of the receive are declared at the beginning of the block of the clause
+        val block = list.statements[list.statements.indexOf(cases[1]) + 1] as? CompoundStatement
+        assertNotNull(block)
+
+        val v =
+            (block.statements.firstOrNull() as? DeclarationStatement)?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(v)
+        assertEquals("v", v.name)
+        assertTrue(v.initializer is DestructureTupleExpression)
+
+        // a single value is received directly, and its variable is not visible in other clauses
+        val single = cases[3].caseExpression as? UnaryOperator
+        assertNotNull(single)
+
+        val other = list.statements[list.statements.indexOf(cases[3]) + 1] as? CompoundStatement
+        assertNotNull(other)
+
+        val w =
+            (other.statements.firstOrNull() as? DeclarationStatement)?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(w)
+        assertEquals("v", w.name)
+        assertNotSame(v, w)
+        assertNull(w.initializer)
+        assertTrue(w.prevDFG.contains(single))
+
+        val ref = other.allChildren<DeclaredReferenceExpression>().firstOrNull { it.name == "v" }
+        assertNotNull(ref)
+        assertSame(w, ref.refersTo)
+
+        assertEquals(1, list.statements.filterIsInstance<DefaultStatement>().size)
+    }
+
+    @Test
+    fun testLabels() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("label.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val outer = main.bodyOrNull<LabelStatement>(0)
+        assertNotNull(outer)
+        assertEquals("outer", outer.label)
+        assertTrue(outer.subStatement is ForStatement)
+
+        val cont = main.allChildren<ContinueStatement>().firstOrNull()
+        assertNotNull(cont)
+        assertEquals("outer", cont.label)
+
+        val br = main.allChildren<BreakStatement>().firstOrNull()
+        assertNotNull(br)
+        assertEquals("outer", br.label)
+
+        val end = main.bodyOrNull<LabelStatement>(1)
+        assertNotNull(end)
+        assertEquals("end", end.label)
+
+        val goto = main.bodyOrNull<GotoStatement>(0)
+        assertNotNull(goto)
+        assertEquals("end", goto.labelName)
+        assertSame(end, goto.targetLabel)
+    }
+
+    @Test
+    fun testTypeSwitch() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("type_switch.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+        val main = (p.flatMap { it.functions })["main"]
+        assertNotNull(main)
+
+        val switch = main.bodyOrNull<SwitchStatement>(0)
+        assertNotNull(switch)
+        assertEquals("x", switch.selector?.name)
+
+        val list = switch.statement as? CompoundStatement
+        assertNotNull(list)
+
+        val cases = list.statements.filterIsInstance<CaseStatement>()
+        assertEquals(3, cases.size)
+
+        val intCase = cases[0].caseExpression as? TypeExpression
+        assertNotNull(intCase)
+        assertEquals("int", intCase.name)
+
+        // the body of each clause declares its own v with the type of the clause
+        val block = list.statements[list.statements.indexOf(cases[0]) + 1] as? CompoundStatement
+        assertNotNull(block)
+
+        val v =
+            (block.statements.firstOrNull() as? DeclarationStatement)?.singleDeclaration
+                as? VariableDeclaration
+        assertNotNull(v)
+        assertEquals("v", v.name)
+        assertEquals(TypeParser.createFrom("int", GoLanguage()), v.type)
+
+        // the selector is only evaluated once, from which the value of each v flows
+        val selector = switch.selector
+        assertNotNull(selector)
+        assertEquals(
+            listOf(selector),
+            switch.allChildren<DeclaredReferenceExpression>().filter { it.name == "x" }
+        )
+
+        val cast = v.initializer as? CastExpression
+        assertNotNull(cast)
+        assertTrue(cast.prevDFG.contains(selector))
+
+        assertEquals(1, list.statements.filterIsInstance<DefaultStatement>().size)
+    }
+
+    @Test
+    fun testCommaOk() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("comma_ok.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val commaOk = (p.flatMap { it.functions })["commaOk"]
+        assertNotNull(commaOk)
+
+        val variables = commaOk.allChildren<VariableDeclaration>().associateBy { it.name }
+
+        for ((value, ok, type) in
+            listOf(
+                Triple("v", "ok", "int"),
+                Triple("s", "isString", "string"),
+                Triple("r", "open", "string")
+            )) {
+            val v = variables[value]
+            assertNotNull(v)
+            assertEquals(type, v.type.name)
+
+            // the data flows from the map, interface or channel to the value
+            val initializer = v.initializer as? DestructureTupleExpression
+            assertNotNull(initializer)
+            assertTrue(initializer.prevDFG.contains(initializer.refersTo))
+
+            val o = variables[ok]
+            assertNotNull(o)
+            assertEquals("bool", o.type.name)
+        }
+    }
+
+    @Test
+    fun testCompoundAssignment() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("compound.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val p = tu.namespaces.filter { it.name == "p" }
+
+        val compound = (p.flatMap { it.functions })["compound"]
+        assertNotNull(compound)
+
+        val operators = compound.allChildren<BinaryOperator>()
+        assertEquals(listOf("+=", "<<=", "&^="), operators.map { it.operatorCode })
+
+        // the bit clear of Go is a compound assignment as well
+        val clear = operators.last()
+        assertTrue(clear.prevDFG.contains(clear.lhs))
+        assertTrue(clear.nextDFG.contains(clear.lhs))
+
+        val add = operators.first()
+        assertEquals("sum", add.lhs.name)
+        assertEquals("x", add.rhs.name)
+    }
+
+    @Test
+    fun testRange() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("range.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val ranges = tu.functions["ranges"]
+        assertNotNull(ranges)
+
+        val loops = ranges.allChildren<ForEachStatement>()
+        assertEquals(3, loops.size)
+
+        // ranging over an integer yields values of its type
+        var variables = (loops[0].variable as DeclarationStatement).declarations
+        assertEquals(listOf("i"), variables.map { it.name })
+        assertEquals("uint8", (variables[0] as VariableDeclaration).type.name)
+
+        // which is int for untyped constants
+        variables = (loops[1].variable as DeclarationStatement).declarations
+        assertEquals(listOf("j"), variables.map { it.name })
+        assertEquals("int", (variables[0] as VariableDeclaration).type.name)
+
+        // ranging over an iterator function yields the parameters of yield
+        variables = (loops[2].variable as DeclarationStatement).declarations
+        assertEquals(listOf("name", "value"), variables.map { it.name })
+        assertEquals(
+            listOf("string", "float64"),
+            variables.map { (it as VariableDeclaration).type.name }
+        )
+
+        val constants = tu.functions["constants"]
+        assertNotNull(constants)
+
+        val call = loops[2].iterable as? CallExpression
+        assertNotNull(call)
+        assertEquals(listOf(constants), call.invokes)
+    }
+
+    @Test
+    fun testAssignmentTargets() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("targets.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val targets = tu.functions["targets"]
+        assertNotNull(targets)
+
+        // blank identifiers do not declare anything and err is only redeclared
+        val variables = targets.variables.map { it.name }
+        assertEquals(6, variables.size)
+        assertEquals(setOf("p", "m", "a", "err", "b", "v"), variables.toSet())
+
+        val err = targets.variables["err"]
+        assertNotNull(err)
+
+        val assignments = targets.allChildren<BinaryOperator>().filter { it.operatorCode == "=" }
+
+        // selectors and index expressions can be assigned to
+        assertIs<MemberExpression>(assignments[0].lhs)
+        assertEquals("First", assignments[0].lhs.name)
+        assertIs<ArraySubscriptionExpression>(assignments[1].lhs)
+
+        // as can be variables, which are redeclared in a short variable declaration
+        val redeclared = assignments.filter { it.lhs.name == "err" }
+        assertEquals(2, redeclared.size)
+        redeclared.forEach {
+            assertSame(err, (it.lhs as? DeclaredReferenceExpression)?.refersTo)
+        }
+
+        val loops = targets.allChildren<ForEachStatement>()
+        assertEquals(2, loops.size)
+
+        val declared = (loops[0].variable as DeclarationStatement).declarations
+        assertEquals(listOf("v"), declared.map { it.name })
+
+        val second = loops[1].variables.singleOrNull()
+        assertIs<MemberExpression>(second)
+        assertEquals("Second", second.name)
+    }
+
+    @Test
+    fun testSwitchCases() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("cases.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val classify = tu.functions["classify"]
+        assertNotNull(classify)
+
+        val switches = classify.allChildren<SwitchStatement>()
+        assertEquals(2, switches.size)
+
+        // a switch without a tag switches over true
+        val tagless = switches[0]
+        val selector = tagless.selector as? Literal<*>
+        assertNotNull(selector)
+        assertEquals(true, selector.value)
+        assertTrue(selector.isImplicit)
+
+        var cases =
+            (tagless.statement as CompoundStatement).statements.filterIsInstance<CaseStatement>()
+        assertEquals(
+            listOf("<", "=="),
+            cases.map { (it.caseExpression as BinaryOperator).operatorCode }
+        )
+
+        // every expression of a clause is a case of its own, directly followed by the next one
+        val statements = (switches[1].statement as CompoundStatement).statements
+        cases = statements.take(3).filterIsInstance<CaseStatement>()
+        assertEquals(listOf(1, 2, 3), cases.map { (it.caseExpression as Literal<*>).value })
+        assertIs<ReturnStatement>(statements[3])
+        assertIs<DefaultStatement>(statements[4])
+    }
+
+    @Test
+    fun testChannelFlows() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("channels.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        val secret = tu.literals.firstOrNull { it.value == "secret" }
+        assertNotNull(secret)
+
+        val public = tu.literals.firstOrNull { it.value == "public" }
+        assertNotNull(public)
+
+        // the channel is passed to the producer as parameter
+        val consume = tu.functions["consumeSecrets"]
+        assertNotNull(consume)
+
+        val receive = consume.allChildren<UnaryOperator> { it.operatorCode == "<-" }.firstOrNull()
+        assertNotNull(receive)
+        assertTrue(secret in receive.prevDFG)
+        assertFalse(public in receive.prevDFG)
+
+        val loop = consume.allChildren<ForEachStatement>().firstOrNull()
+        assertNotNull(loop)
+        assertTrue(secret in loop.iterable!!.prevDFG)
+
+        // values sent to other channels are not received
+        val unrelated = tu.functions["unrelatedChannel"]
+        assertNotNull(unrelated)
+
+        val other = unrelated.allChildren<UnaryOperator> { it.operatorCode == "<-" }.firstOrNull()
+        assertNotNull(other)
+        assertTrue(public in other.prevDFG)
+        assertFalse(secret in other.prevDFG)
+    }
+
+    @Test
+    fun testErrorHandling() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("errors.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun Node.annotation(name: String): Map<String, Any?>? {
+            val annotation = annotations.firstOrNull { it.name == name } ?: return null
+            return annotation.members.associate { it.name to (it.value as? Literal<*>)?.value }
+        }
+
+        // the positions of the error results
+        for (name in listOf("parseConfig", "loadConfig")) {
+            val f = tu.functions[name]
+            assertNotNull(f)
+            assertEquals(mapOf("index" to 1), f.annotation("errorResults"))
+        }
+
+        val mustLoadConfig = tu.functions["mustLoadConfig"]
+        assertNotNull(mustLoadConfig)
+        assertNull(mustLoadConfig.annotation("errorResults"))
+
+        // the comparison with an unrelated string is not an error check
+        val parseConfig = tu.functions["parseConfig"]
+        assertNotNull(parseConfig)
+
+        val checks = parseConfig.allChildren<IfStatement>()
+        assertEquals(2, checks.size)
+        assertNull(checks[0].annotation("errorCheck"))
+        assertEquals(
+            mapOf("error" to "err", "branch" to "then"),
+            checks[1].annotation("errorCheck")
+        )
+
+        // the returned sentinel error is not propagated, but the wrapped one is
+        val returns = parseConfig.allChildren<ReturnStatement>()
+        assertNull(returns[0].annotation("errorPropagation"))
+        assertEquals(
+            mapOf("index" to 1, "wrapped" to true),
+            returns[1].annotation("errorPropagation")
+        )
+        assertNull(returns[2].annotation("errorPropagation"))
+
+        val loadConfig = tu.functions["loadConfig"]
+        assertNotNull(loadConfig)
+
+        val propagation = loadConfig.allChildren<ReturnStatement>().first()
+        assertEquals(
+            mapOf("index" to 1, "wrapped" to false),
+            propagation.annotation("errorPropagation")
+        )
+
+        // the error path is the else branch
+        val check = mustLoadConfig.allChildren<IfStatement>().firstOrNull()
+        assertNotNull(check)
+        assertEquals(mapOf("error" to "err", "branch" to "else"), check.annotation("errorCheck"))
+    }
+
+    @Test
+    fun testNonReturningCalls() {
+        val topLevel = Path.of("src", "test", "resources", "golang")
+        val tu =
+            TestUtils.analyzeAndGetFirstTU(
+                listOf(topLevel.resolve("noreturn.go").toFile()),
+                topLevel,
+                true
+            ) { it.registerLanguage<GoLanguage>() }
+        assertNotNull(tu)
+
+        fun CallExpression.kind(): Any? {
+            val noreturn = annotations.firstOrNull { it.name == "noreturn" } ?: return null
+            return (noreturn.members.firstOrNull { it.name == "kind" }?.value as? Literal<*>)?.value
+        }
+
+        // a panic still runs the deferred calls, but nothing after it
+        val panic = tu.calls["panic"]
+        assertNotNull(panic)
+        assertEquals("panic", panic.kind())
+        assertEquals(listOf<Node>(tu.calls["releaseAll"]!!), panic.nextEOG)
+        assertTrue(tu.calls["afterPanic"]!!.prevEOG.isEmpty())
+
+        // os.Exit and log.Fatal terminate the program right away
+        for ((name, after) in listOf("Exit" to "afterExit", "Fatal" to "afterFatal")) {
+            val exit = tu.calls[name]
+            assertNotNull(exit)
+            assertEquals("exit", exit.kind())
+            assertTrue(exit.nextEOG.isEmpty())
+            assertTrue(tu.calls[after]!!.prevEOG.isEmpty())
+        }
+
+        // the deferred call itself always returns
+        assertNull(tu.calls["releaseAll"]?.kind())
+    }
+}
@@ -0,0 +1,5 @@
+package broken
+
+func good() {
+	other()
+}
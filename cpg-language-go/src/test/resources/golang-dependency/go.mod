@@ -0,0 +1,3 @@
+module example.io/greetings
+
+go 1.16
@@ -0,0 +1,10 @@
+// Package greetings is a dependency, which is located outside of the project.
+package greetings
+
+func Hello(name string) string {
+	return greeting() + name
+}
+
+func greeting() string {
+	return "Hello "
+}
@@ -0,0 +1,3 @@
+package ignore
+
+func main() {}
@@ -0,0 +1,7 @@
+package app
+
+import "example.io/greetings"
+
+func greet() string {
+	return greetings.Hello("world")
+}
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func prepareUser(ctx context.Context, conn *pgx.Conn) error {
+	_, err := conn.Prepare(ctx, "findUser", "SELECT name FROM users WHERE id = $1")
+
+	return err
+}
+
+func deleteUser(ctx context.Context, conn *pgx.Conn, id int) error {
+	_, err := conn.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+
+	return err
+}
@@ -0,0 +1,28 @@
+// Package echo is a stub of the echo API, which is used by the routes.
+package echo
+
+type Context interface{}
+
+type HandlerFunc func(c Context) error
+
+type MiddlewareFunc func(next HandlerFunc) HandlerFunc
+
+type Route struct{}
+
+type Echo struct{}
+
+func New() *Echo {
+	return &Echo{}
+}
+
+func (e *Echo) GET(path string, h HandlerFunc, m ...MiddlewareFunc) *Route {
+	return nil
+}
+
+func (e *Echo) CONNECT(path string, h HandlerFunc, m ...MiddlewareFunc) *Route {
+	return nil
+}
+
+func (e *Echo) Add(method, path string, handler HandlerFunc, middleware ...MiddlewareFunc) *Route {
+	return nil
+}
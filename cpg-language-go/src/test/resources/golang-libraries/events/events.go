@@ -0,0 +1,23 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type signup struct {
+	Email string `json:"email"`
+}
+
+func publish(ctx context.Context, w *kafka.Writer, email string) error {
+	value, err := json.Marshal(signup{Email: email})
+	if err != nil {
+		return err
+	}
+
+	defer w.Close()
+
+	return w.WriteMessages(ctx, kafka.Message{Key: []byte(email), Value: value})
+}
@@ -0,0 +1,37 @@
+// Package gin is a stub of the gin API, which is used by the routes.
+package gin
+
+type Context struct{}
+
+type HandlerFunc func(*Context)
+
+type IRoutes interface {
+	GET(relativePath string, handlers ...HandlerFunc) IRoutes
+	OPTIONS(relativePath string, handlers ...HandlerFunc) IRoutes
+}
+
+type RouterGroup struct{}
+
+func (group *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group
+}
+
+func (group *RouterGroup) POST(relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group
+}
+
+func (group *RouterGroup) OPTIONS(relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group
+}
+
+func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group
+}
+
+type Engine struct {
+	RouterGroup
+}
+
+func New() *Engine {
+	return &Engine{}
+}
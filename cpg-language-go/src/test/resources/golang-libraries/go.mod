@@ -0,0 +1,23 @@
+module example.io/libraries
+
+go 1.16
+
+require (
+	example.io/greetings v0.0.0
+	github.com/gin-gonic/gin v1.9.0
+	github.com/go-chi/chi/v5 v5.0.0
+	github.com/jackc/pgx/v5 v5.0.0
+	github.com/labstack/echo/v4 v4.10.0
+	github.com/segmentio/kafka-go v0.4.0
+	github.com/spf13/pflag v1.0.5
+)
+
+replace (
+	example.io/greetings => ../golang-dependency
+	github.com/gin-gonic/gin => ./gin
+	github.com/go-chi/chi/v5 => ./chi
+	github.com/jackc/pgx/v5 => ./pgx
+	github.com/labstack/echo/v4 => ./echo
+	github.com/segmentio/kafka-go => ./kafka
+	github.com/spf13/pflag => ./pflag
+)
@@ -0,0 +1,22 @@
+// Package kafka is a stub of the kafka-go API, which is used by the events.
+package kafka
+
+import "context"
+
+type Message struct {
+	Key   []byte
+	Value []byte
+}
+
+type Writer struct {
+	Addr  string
+	Topic string
+}
+
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...Message) error {
+	return nil
+}
+
+func (w *Writer) Close() error {
+	return nil
+}
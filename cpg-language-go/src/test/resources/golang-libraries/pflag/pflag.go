@@ -0,0 +1,20 @@
+// Package pflag is a stub of the pflag API, which is used by the settings.
+package pflag
+
+import "net"
+
+func IP(name string, value net.IP, usage string) *net.IP {
+	return nil
+}
+
+func IPP(name, shorthand string, value net.IP, usage string) *net.IP {
+	return nil
+}
+
+func IPVarP(p *net.IP, name, shorthand string, value net.IP, usage string) {}
+
+func StringP(name, shorthand string, value string, usage string) *string {
+	return nil
+}
+
+func Parse() {}
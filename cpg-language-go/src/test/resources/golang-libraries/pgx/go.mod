@@ -0,0 +1,3 @@
+module github.com/jackc/pgx/v5
+
+go 1.16
@@ -0,0 +1,17 @@
+package settings
+
+import (
+	"net"
+
+	"github.com/spf13/pflag"
+)
+
+func parse() {
+	var bind net.IP
+
+	pflag.IP("listen", nil, "the address to listen on")
+	pflag.IPP("advertise", "a", nil, "the address to advertise")
+	pflag.IPVarP(&bind, "bind", "b", nil, "the address to bind to")
+	pflag.StringP("name", "n", "", "the name of the node")
+	pflag.Parse()
+}
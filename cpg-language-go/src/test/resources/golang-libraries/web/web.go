@@ -0,0 +1,45 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/labstack/echo/v4"
+)
+
+func listUsers(c *gin.Context) {}
+
+func preflight(c *gin.Context) {}
+
+func authenticate(c *gin.Context) {}
+
+func gins() {
+	r := gin.New()
+	r.GET("/users", authenticate, listUsers)
+	r.OPTIONS("/users", preflight)
+}
+
+func getOrder(c echo.Context) error {
+	return nil
+}
+
+func tunnel(c echo.Context) error {
+	return nil
+}
+
+func echos() {
+	e := echo.New()
+	e.GET("/orders/:id", getOrder)
+	e.CONNECT("/tunnel", tunnel)
+}
+
+func listItems(w http.ResponseWriter, r *http.Request) {}
+
+func allowItems(w http.ResponseWriter, r *http.Request) {}
+
+func chis() {
+	r := chi.NewRouter()
+	r.Get("/items", listItems)
+	r.Options("/items", allowItems)
+}
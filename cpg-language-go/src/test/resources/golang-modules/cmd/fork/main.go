@@ -0,0 +1,7 @@
+package main
+
+import "example.io/forked"
+
+func main() {
+	println(forked.Fork())
+}
@@ -0,0 +1,5 @@
+package forked
+
+func Fork() string {
+	return "forked"
+}
@@ -0,0 +1,3 @@
+module github.com/someone/forked
+
+go 1.16
@@ -1,3 +1,7 @@
 module example.io/awesome
 
-go 1.16
\ No newline at end of file
+go 1.16
+
+require example.io/forked v0.0.0
+
+replace example.io/forked => ./forked
@@ -0,0 +1,3 @@
+module example.io/plugin
+
+go 1.16
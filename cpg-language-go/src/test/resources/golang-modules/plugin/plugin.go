@@ -0,0 +1,7 @@
+package plugin
+
+import "example.io/plugin/version"
+
+func Describe() string {
+	return "plugin " + version.Version()
+}
@@ -0,0 +1,5 @@
+package version
+
+func Version() string {
+	return "1.0"
+}
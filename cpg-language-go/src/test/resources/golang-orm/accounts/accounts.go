@@ -0,0 +1,25 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Account struct {
+	ID       uint `db:"account_id"`
+	Email    string
+	Password string `db:"-"`
+}
+
+func Find(ctx context.Context, db *sqlx.DB, id uint) (account Account, err error) {
+	err = db.GetContext(ctx, &account, "SELECT * FROM accounts WHERE account_id = $1", id)
+
+	return
+}
+
+func All(db *sqlx.DB) (accounts []*Account, err error) {
+	err = db.Select(&accounts, "SELECT * FROM accounts")
+
+	return
+}
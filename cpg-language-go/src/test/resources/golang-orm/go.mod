@@ -0,0 +1,13 @@
+module example.io/shop
+
+go 1.16
+
+require (
+	github.com/jmoiron/sqlx v0.0.0
+	gorm.io/gorm v0.0.0
+)
+
+replace (
+	github.com/jmoiron/sqlx => ./sqlx
+	gorm.io/gorm => ./gorm
+)
@@ -0,0 +1,40 @@
+// Package gorm is a stub of the GORM API, which is used by the models.
+package gorm
+
+import "time"
+
+type Model struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type DB struct{}
+
+func Open() (*DB, error) {
+	return &DB{}, nil
+}
+
+func (db *DB) AutoMigrate(dst ...interface{}) error {
+	return nil
+}
+
+func (db *DB) Model(value interface{}) *DB {
+	return db
+}
+
+func (db *DB) Where(query interface{}, args ...interface{}) *DB {
+	return db
+}
+
+func (db *DB) Update(column string, value interface{}) *DB {
+	return db
+}
+
+func (db *DB) Find(dest interface{}, conds ...interface{}) *DB {
+	return db
+}
+
+func (db *DB) Create(value interface{}) *DB {
+	return db
+}
@@ -0,0 +1,35 @@
+package models
+
+import "gorm.io/gorm"
+
+type Audited struct {
+	CreatedBy string
+}
+
+type UserProfile struct {
+	Audited
+	ID        uint
+	FullName  string
+	Email     string `gorm:"column:email_address;uniqueIndex"`
+	Password  string `gorm:"-"`
+	lastLogin string
+}
+
+type Order struct {
+	ID     uint
+	UserID uint
+}
+
+func (Order) TableName() string {
+	return "purchases"
+}
+
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&UserProfile{}, &Order{})
+}
+
+func Profiles(db *gorm.DB) (profiles []UserProfile) {
+	db.Where("full_name <> ?", "").Find(&profiles)
+
+	return
+}
@@ -0,0 +1,22 @@
+// Package sqlx is a stub of the sqlx API, which is used by the accounts.
+package sqlx
+
+import "context"
+
+type DB struct{}
+
+func Connect(driverName string, dataSourceName string) (*DB, error) {
+	return &DB{}, nil
+}
+
+func (db *DB) Get(dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) Select(dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
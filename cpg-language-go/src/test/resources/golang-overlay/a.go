@@ -0,0 +1,3 @@
+package overlay
+
+func a() {}
@@ -0,0 +1,5 @@
+package overlay
+
+func b() {
+	a()
+}
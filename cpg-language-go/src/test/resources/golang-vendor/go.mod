@@ -0,0 +1,5 @@
+module example.io/vendored
+
+go 1.19
+
+require example.io/quotes v1.0.0
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+
+	"example.io/quotes"
+)
+
+func main() {
+	fmt.Println(quotes.Quote())
+}
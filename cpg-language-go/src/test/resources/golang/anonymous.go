@@ -0,0 +1,14 @@
+package p
+
+type Config struct {
+	Server struct {
+		Host string
+		Port int
+	}
+}
+
+func main() {
+	var x struct{ A int }
+
+	x.A = 1
+}
@@ -0,0 +1,21 @@
+package p
+
+const size = 2
+
+var grid [3][4]int
+
+var lines [][]byte
+
+func arrays() int {
+	doubled := [size * 2]int{}
+	primes := [...]int{2, 3, 5}
+
+	return doubled[0] + primes[0]
+}
+
+func pair[T any](x T) [2]T {
+	var both [2]T
+	both[0], both[1] = x, x
+
+	return both
+}
@@ -0,0 +1,11 @@
+package p
+
+import "embed"
+
+// The templates of the application are embedded into its binary.
+//
+//go:embed embedded.txt
+var embeddedFiles embed.FS
+
+//go:embed embedded.txt
+var embeddedText string
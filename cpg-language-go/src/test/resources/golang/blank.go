@@ -0,0 +1,27 @@
+package p
+
+import "io"
+
+type Sink struct{}
+
+func (s *Sink) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+var _ io.Writer = (*Sink)(nil)
+
+func (_ Sink) Close() (_ int, err error) {
+	return 0, nil
+}
+
+func open() (*Sink, error) {
+	return &Sink{}, nil
+}
+
+func blank(xs []int) {
+	var _, _ = open()
+	var _ = len(xs)
+
+	for _ = range xs {
+	}
+}
@@ -0,0 +1,13 @@
+package p
+
+import _ "unsafe"
+
+// addAsm is implemented in assembly.
+func addAsm(a, b int) int
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+func callsBodyless() int64 {
+	return int64(addAsm(1, 2)) + nanotime()
+}
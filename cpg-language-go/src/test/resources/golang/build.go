@@ -0,0 +1,7 @@
+//go:build !windows
+
+package p
+
+func platform() string {
+	return "unix"
+}
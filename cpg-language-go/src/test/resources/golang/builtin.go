@@ -0,0 +1,8 @@
+package p
+
+func builtins(s []int, t []int) int {
+	s = append(s, 1, 2)
+	copy(t, s)
+
+	return len(s)
+}
@@ -0,0 +1,5 @@
+package builtins
+
+func size(m map[string]int) int {
+	return len(m)
+}
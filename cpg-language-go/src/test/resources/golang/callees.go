@@ -0,0 +1,18 @@
+package p
+
+func handler() func(int) int {
+	return func(i int) int {
+		return i + 1
+	}
+}
+
+func callees() {
+	func() {
+		println("now")
+	}()
+
+	handler()(1)
+
+	handlers := []func(int) int{handler()}
+	handlers[0](2)
+}
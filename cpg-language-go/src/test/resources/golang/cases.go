@@ -0,0 +1,17 @@
+package p
+
+func classify(i int) string {
+	switch {
+	case i < 0:
+		return "negative"
+	case i == 0:
+		return "zero"
+	}
+
+	switch i {
+	case 1, 2, 3:
+		return "small"
+	default:
+		return "large"
+	}
+}
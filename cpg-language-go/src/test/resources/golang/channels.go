@@ -0,0 +1,24 @@
+package p
+
+func produceSecret(out chan string) {
+	out <- "secret"
+}
+
+func consumeSecrets() {
+	secrets := make(chan string)
+	go produceSecret(secrets)
+
+	first := <-secrets
+
+	for next := range secrets {
+		_ = next
+	}
+
+	_ = first
+}
+
+func unrelatedChannel() {
+	other := make(chan string, 1)
+	other <- "public"
+	_ = <-other
+}
@@ -0,0 +1,13 @@
+package p
+
+var global = 1
+
+func counter() func(int) int {
+	count := 0
+	step := 1
+
+	return func(i int) int {
+		count += step + i + global
+		return count
+	}
+}
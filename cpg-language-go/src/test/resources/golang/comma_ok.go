@@ -0,0 +1,9 @@
+package p
+
+func commaOk(m map[string]int, i interface{}, ch chan string) {
+	v, ok := m["a"]
+	s, isString := i.(string)
+	r, open := <-ch
+
+	_, _, _, _, _, _ = v, ok, s, isString, r, open
+}
@@ -0,0 +1,15 @@
+package p
+
+type Point struct {
+	X, Y int
+}
+
+const last = 4
+
+func composite() {
+	primes := [5]int{2: 7, last: 11}
+
+	points := []Point{{1, 2}, {X: 3, Y: 4}}
+
+	names := map[int]string{last: "last"}
+}
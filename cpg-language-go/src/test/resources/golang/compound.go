@@ -0,0 +1,12 @@
+package p
+
+func compound(xs []int) (sum int) {
+	for _, x := range xs {
+		sum += x
+	}
+
+	sum <<= 1
+	sum &^= 1
+
+	return
+}
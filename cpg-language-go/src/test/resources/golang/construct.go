@@ -10,4 +10,6 @@ func main() {
 	m := make(map[string]string, 10)
 
 	ch := make(chan int)
+
+	bch := make(chan int, 5)
 }
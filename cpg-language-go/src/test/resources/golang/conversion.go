@@ -0,0 +1,10 @@
+package p
+
+type MyInt int
+
+func conversion(b []byte) (string, *MyInt) {
+	s := string(b)
+	i := MyInt(len(b))
+
+	return s, (*MyInt)(&i)
+}
@@ -0,0 +1,7 @@
+package p
+
+func cleanup() {}
+
+func main() {
+	defer cleanup()
+}
@@ -0,0 +1,17 @@
+package p
+
+func open()   {}
+func first()  {}
+func second() {}
+
+func tx(fail bool) int {
+	open()
+	defer first()
+	defer second()
+
+	if fail {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,42 @@
+package p
+
+// weakDigest computes a digest of data.
+//
+// Deprecated: MD5 is cryptographically broken,
+// use strongDigest instead.
+func weakDigest(data []byte) []byte {
+	return data
+}
+
+func strongDigest(data []byte) []byte {
+	return data
+}
+
+// Deprecated: the key size is too small.
+var (
+	shortKeySize = 512
+	shortKeyName = "rsa512"
+)
+
+const longKeySize = 4096
+
+// LegacyCipher encrypts data with a fixed mode.
+type LegacyCipher struct {
+	// Deprecated: ECB leaks patterns of the plaintext.
+	Mode string
+
+	Key []byte
+}
+
+type Encrypter interface {
+	// EncryptECB encrypts data block by block.
+	//
+	// Deprecated: use Encrypt.
+	EncryptECB(data []byte) []byte
+
+	Encrypt(data []byte) []byte
+}
+
+func usesDeprecated() []byte {
+	return weakDigest([]byte(shortKeyName))
+}
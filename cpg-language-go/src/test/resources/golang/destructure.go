@@ -0,0 +1,19 @@
+package p
+
+import "errors"
+
+var errUnknownUser = errors.New("unknown user")
+
+func lookupUser(id int) (string, error) {
+	if id < 0 {
+		return "", errUnknownUser
+	}
+
+	return "admin", nil
+}
+
+func useLookup() {
+	user, err := lookupUser(1)
+	_ = user
+	_ = err
+}
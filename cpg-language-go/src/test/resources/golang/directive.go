@@ -0,0 +1,14 @@
+//go:build go1.18
+
+package p
+
+//go:generate stringer -type=Color "a b"
+
+type Color int
+
+//go:noinline
+func directive() int {
+	return 1 //nolint:gomnd,revive // magic
+}
+
+var unused = 1 //nolint
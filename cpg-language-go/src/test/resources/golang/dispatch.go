@@ -0,0 +1,9 @@
+package p
+
+func totalArea(shapes []Shape) (total float64) {
+	for _, s := range shapes {
+		total += s.Area()
+	}
+
+	return
+}
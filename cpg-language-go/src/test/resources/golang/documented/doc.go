@@ -0,0 +1,6 @@
+// Copyright (c) 2022, Example Authors.
+
+// Package documented provides a documented service.
+//
+// It is used to test package documentation.
+package documented
@@ -0,0 +1,5 @@
+// This file contains the service.
+
+package documented
+
+func Serve() {}
@@ -0,0 +1,38 @@
+package p
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/smtp"
+)
+
+type profileUpdate struct {
+	Email string `json:"email"`
+}
+
+func publishProfile(client *http.Client, update profileUpdate) error {
+	body, err := json.Marshal(&update)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "https://example.com/profiles", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req)
+
+	return err
+}
+
+func notifyProfile(update profileUpdate) error {
+	msg, err := xml.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail("mail.example.com:25", nil, "noreply@example.com", []string{update.Email}, msg)
+}
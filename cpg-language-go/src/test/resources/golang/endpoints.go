@@ -0,0 +1,17 @@
+package p
+
+import "net/http"
+
+type userAPI struct{}
+
+func (api *userAPI) createUser(w http.ResponseWriter, r *http.Request) {}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+
+func registerRoutes(api *userAPI) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", listUsers)
+	mux.Handle("POST /users", http.HandlerFunc(api.createUser))
+
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+}
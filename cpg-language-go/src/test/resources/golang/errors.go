@@ -0,0 +1,40 @@
+package p
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var errEmptyConfig = errors.New("empty config")
+
+func parseConfig(s string) (int, error) {
+	if s == "" {
+		return 0, errEmptyConfig
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return n, nil
+}
+
+func loadConfig(s string) (int, error) {
+	n, err := parseConfig(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func mustLoadConfig(s string) int {
+	n, err := loadConfig(s)
+	if err == nil {
+		return n
+	} else {
+		panic(err)
+	}
+}
@@ -0,0 +1,13 @@
+package p
+
+type Coord struct {
+	X, Y int
+}
+
+func typed(c *Coord, names map[string]int, values []float64) bool {
+	sum := c.X + c.Y
+	ratio := values[0] / 2
+	count := names["a"]
+
+	return float64(sum+count) > ratio
+}
@@ -0,0 +1,22 @@
+package p
+
+// fmt prints the greeting.
+import "fmt"
+
+import (
+	// strconv formats the count.
+	"strconv"
+	"strings" // strings repeats the text.
+)
+
+// greeting is shown to visitors.
+type greeting struct {
+	// Text is the message.
+	Text string // must not be empty
+
+	Count int // how often to repeat the text
+}
+
+func (g greeting) render() {
+	fmt.Println(strings.Repeat(g.Text, g.Count), strconv.Itoa(g.Count))
+}
@@ -0,0 +1,26 @@
+package p
+
+func Map[T any, U any](xs []T, f func(T) U) []U {
+	var ys []U
+
+	for _, x := range xs {
+		ys = append(ys, f(x))
+	}
+
+	return ys
+}
+
+func Identity[T any](x T) T {
+	return x
+}
+
+func toString(i int) string {
+	return ""
+}
+
+func main() {
+	xs := []int{1, 2, 3}
+
+	Map[int, string](xs, toString)
+	Identity[int](1)
+}
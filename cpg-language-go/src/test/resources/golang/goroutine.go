@@ -0,0 +1,8 @@
+package p
+
+func work(i int) {}
+
+func main() {
+	go work(1)
+	work(2)
+}
@@ -0,0 +1,21 @@
+package p
+
+var (
+	a int
+	b string = "b"
+)
+
+const (
+	c = 1
+	d = "d"
+)
+
+func main() {
+	var (
+		e int
+		f = 2.0
+	)
+
+	_ = e
+	_ = f
+}
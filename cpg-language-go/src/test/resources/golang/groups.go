@@ -0,0 +1,10 @@
+package p
+
+type Vector struct {
+	// X and Y are the coordinates
+	X, Y float64
+}
+
+func add(a, b int) (sum, carry int) {
+	return a + b, 0
+}
@@ -0,0 +1,10 @@
+//go:build ignore
+
+// This file is only run by go generate, but it is never part of the package.
+package main
+
+func main() {
+	generate()
+}
+
+func generate() {}
@@ -0,0 +1,31 @@
+package p
+
+type Shape interface {
+	Area() float64
+}
+
+type Named interface {
+	Name() string
+}
+
+type Square struct {
+	length float64
+}
+
+func (s Square) Area() float64 {
+	return s.length * s.length
+}
+
+type Circle struct {
+	radius float64
+}
+
+func (c *Circle) Area() float64 {
+	return 3.14 * c.radius * c.radius
+}
+
+func (c *Circle) Name() string {
+	return "circle"
+}
+
+type Point struct{}
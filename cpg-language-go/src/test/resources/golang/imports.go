@@ -0,0 +1,10 @@
+package p
+
+import (
+	_ "embed"
+	. "strings"
+)
+
+func upper() string {
+	return ToUpper("a")
+}
@@ -0,0 +1,11 @@
+package main
+
+var total = count + 1
+
+func init() {
+	println("a")
+}
+
+func main() {
+	println(total)
+}
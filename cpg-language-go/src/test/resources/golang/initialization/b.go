@@ -0,0 +1,11 @@
+package main
+
+var count = compute()
+
+func compute() int {
+	return 41
+}
+
+func init() {
+	println("b")
+}
@@ -0,0 +1,20 @@
+package p
+
+func main() {
+outer:
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			if j == 5 {
+				continue outer
+			}
+
+			if i == 5 {
+				break outer
+			}
+		}
+	}
+
+	goto end
+end:
+	println("end")
+}
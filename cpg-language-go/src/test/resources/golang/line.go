@@ -0,0 +1,14 @@
+package p
+
+// The following function was generated from a grammar.
+
+//line grammar.y:10:1
+func lineDirective() int {
+	return 42
+}
+
+func spanning() int {
+	x := 1
+//line other.y:1:1
+	return x
+}
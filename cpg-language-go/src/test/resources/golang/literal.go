@@ -5,3 +5,14 @@ const s = "test"
 const f = 1.0
 const f32 float32 = 1.00
 var n *int = nil
+var esc = "line\n\"quoted\" \u00e4"
+var raw = `C:\path\n`
+const hex = 0xFF
+const bin = 0b1010
+const oct = 0o17
+const sep = 1_000_000
+const big = 5_000_000_000
+const max uint64 = 18446744073709551615
+const hf = 0x1p-2
+const im = 2.5i
+var sum = 1 +   2
@@ -0,0 +1,24 @@
+package p
+
+import (
+	"fmt"
+	"log"
+)
+
+type auditLog struct {
+	logger *log.Logger
+}
+
+// Printf is not a logger, although it is named like one.
+func (a *auditLog) Printf(format string, args ...interface{}) {
+	a.logger.Output(2, fmt.Sprintf(format, args...))
+}
+
+func logLogin(a *auditLog, email string, attempts int) {
+	log.Printf("login of %s", email)
+	a.Printf("%d attempts", attempts)
+
+	if attempts > 3 {
+		log.Panicln("too many attempts by", email)
+	}
+}
@@ -0,0 +1,21 @@
+package p
+
+type Tile struct {
+	Side int
+}
+
+type Frame struct {
+	W, H int
+}
+
+func (s Tile) Area() int {
+	return s.Side * s.Side
+}
+
+func (r *Frame) Area() int {
+	return r.W * r.H
+}
+
+func area(side int) int {
+	return side * side
+}
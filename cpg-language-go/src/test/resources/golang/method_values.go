@@ -0,0 +1,19 @@
+package p
+
+type Counter struct {
+	n int
+}
+
+func (c *Counter) Inc() {
+	c.n++
+}
+
+func count() {
+	c := &Counter{}
+
+	inc := c.Inc
+	inc()
+
+	incExpr := (*Counter).Inc
+	incExpr(c)
+}
@@ -0,0 +1,9 @@
+package methods
+
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+func (l *List[T]) Push(item T) {
+	l.items = append(l.items, item)
+}
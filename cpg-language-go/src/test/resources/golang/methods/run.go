@@ -0,0 +1,7 @@
+package methods
+
+var DefaultServer = &Server{}
+
+func Run() string {
+	return DefaultServer.Addr()
+}
@@ -0,0 +1,9 @@
+package methods
+
+type Server struct {
+	addr string
+}
+
+type List[T any] struct {
+	items []T
+}
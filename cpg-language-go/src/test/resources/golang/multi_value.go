@@ -0,0 +1,11 @@
+package p
+
+func pair() (int, string) {
+	return 1, "one"
+}
+
+var a, b int = 1, 2
+
+var c, d = pair()
+
+var e, f string
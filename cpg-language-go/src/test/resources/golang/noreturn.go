@@ -0,0 +1,27 @@
+package p
+
+import (
+	"log"
+	"os"
+)
+
+func releaseAll() {}
+func afterPanic() {}
+func afterExit()  {}
+func afterFatal() {}
+
+func unwind() {
+	defer releaseAll()
+	panic("unrecoverable")
+	afterPanic()
+}
+
+func terminate(code int) {
+	if code != 0 {
+		os.Exit(code)
+		afterExit()
+	}
+
+	log.Fatal("terminated")
+	afterFatal()
+}
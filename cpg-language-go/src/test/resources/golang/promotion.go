@@ -0,0 +1,24 @@
+package p
+
+type Base struct {
+	ID int
+}
+
+func (b *Base) Describe() string {
+	return "base"
+}
+
+type Middle struct {
+	*Base
+}
+
+type Outer struct {
+	Middle
+	Name string
+}
+
+func use(o Outer) {
+	_ = o.ID
+	_ = o.Name
+	o.Describe()
+}
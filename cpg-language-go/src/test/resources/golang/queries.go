@@ -0,0 +1,22 @@
+package p
+
+import (
+	"context"
+	"database/sql"
+)
+
+const userColumns = "id, name, email"
+
+func findUserByEmail(db *sql.DB, email string) *sql.Row {
+	return db.QueryRow("SELECT "+userColumns+" FROM users WHERE email = ?", email)
+}
+
+func renameUser(ctx context.Context, tx *sql.Tx, id int, name string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE users SET name = $1 WHERE id = $2`, name, id)
+
+	return err
+}
+
+func countRows(db *sql.DB, table string) (*sql.Rows, error) {
+	return db.Query("SELECT COUNT(*) FROM " + table)
+}
@@ -0,0 +1,25 @@
+package p
+
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+func constants() Seq2[string, float64] {
+	return func(yield func(string, float64) bool) {
+		yield("pi", 3.14)
+	}
+}
+
+func ranges() {
+	var n uint8 = 3
+
+	for i := range n {
+		println(i)
+	}
+
+	for j := range 10 {
+		println(j)
+	}
+
+	for name, value := range constants() {
+		println(name, value)
+	}
+}
@@ -0,0 +1,11 @@
+package p
+
+func prepare() {}
+
+func safe() {
+	prepare()
+	defer func() {
+		recover()
+	}()
+	panic("oops")
+}
@@ -0,0 +1,15 @@
+package rpc
+
+import "context"
+
+type greeter struct {
+	UnimplementedGreeterServer
+}
+
+func (g *greeter) SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
+	return &HelloReply{Message: "Hello " + req.Name}, nil
+}
+
+func (g *greeter) format(name string) string {
+	return "Hello " + name
+}
@@ -0,0 +1,16 @@
+package p
+
+import "testing"
+
+func TestSample(t *testing.T) {}
+
+func BenchmarkSample(b *testing.B) {}
+
+func FuzzSample(f *testing.F) {}
+
+func ExampleSample() {}
+
+// not tests, because of their names or signatures
+func Testsample(t *testing.T) {}
+
+func TestHelper() {}
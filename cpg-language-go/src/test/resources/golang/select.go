@@ -0,0 +1,19 @@
+package p
+
+func main() {
+	c := make(chan int)
+	quit := make(chan bool)
+
+	select {
+	case c <- 1:
+		println("sent")
+	case v, ok := <-c:
+		println(v, ok)
+	case <-quit:
+		return
+	case v := <-c:
+		println(v)
+	default:
+		println("idle")
+	}
+}
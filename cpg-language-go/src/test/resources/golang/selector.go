@@ -0,0 +1,16 @@
+package p
+
+import "fmt"
+
+type printer struct {
+	fmt string
+}
+
+func main() {
+	fmt.Println("a")
+
+	// shadows the import
+	fmt := printer{fmt: "b"}
+
+	_ = fmt.fmt
+}
@@ -0,0 +1,24 @@
+package services
+
+import "context"
+
+type EchoRequest struct {
+	Text string
+}
+
+type EchoServer interface {
+	Echo(context.Context, *EchoRequest) (*EchoRequest, error)
+}
+
+type serviceDesc struct {
+	ServiceName string
+	HandlerType interface{}
+}
+
+func RegisterEchoServer(desc *serviceDesc, srv EchoServer) {}
+
+// the descriptor is declared in another file than the implementation
+var Echo_ServiceDesc = serviceDesc{
+	ServiceName: "echo.v1.Echo",
+	HandlerType: (*EchoServer)(nil),
+}
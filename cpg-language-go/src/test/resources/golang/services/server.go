@@ -0,0 +1,9 @@
+package services
+
+import "context"
+
+type echo struct{}
+
+func (e *echo) Echo(ctx context.Context, req *EchoRequest) (*EchoRequest, error) {
+	return req, nil
+}
@@ -0,0 +1,21 @@
+package p
+
+import (
+	"flag"
+	"os"
+)
+
+const envPrefix = "APP_"
+
+var listenAddr = flag.String("listen", ":8080", "the address to listen on")
+
+func loadSettings(fs *flag.FlagSet) (dsn string, verbose bool) {
+	fs.BoolVar(&verbose, "verbose", false, "log more details")
+
+	dsn = os.Getenv(envPrefix + "DATABASE_URL")
+	if token, ok := os.LookupEnv(fs.Arg(0)); ok {
+		dsn += "?token=" + token
+	}
+
+	return
+}
@@ -0,0 +1,16 @@
+package p
+
+func main() {
+	x := 1
+
+	if true {
+		x := "inner"
+		_ = x
+	}
+
+	f := func() int {
+		return x
+	}
+
+	_ = f
+}
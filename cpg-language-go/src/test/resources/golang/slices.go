@@ -0,0 +1,9 @@
+package p
+
+func main() {
+	a := []int{1, 2, 3}
+
+	b := a[1:]
+	c := a[1:2]
+	d := a[1:2:3]
+}
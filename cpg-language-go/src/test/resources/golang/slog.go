@@ -0,0 +1,13 @@
+package p
+
+import (
+	"context"
+	"log/slog"
+)
+
+func logSignup(ctx context.Context, logger *slog.Logger, email string) {
+	logger = logger.With("email", email)
+	logger.InfoContext(ctx, "signup")
+
+	slog.Error("signup failed", "email", email)
+}
@@ -0,0 +1,11 @@
+package p
+
+var counter = 1
+
+type Box struct{}
+
+func (b Box) Open() {}
+
+func helper() int {
+	return counter
+}
@@ -0,0 +1,8 @@
+package p
+
+func useSymbols() int {
+	var b Box
+	b.Open()
+
+	return helper() + counter
+}
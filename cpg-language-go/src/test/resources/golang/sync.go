@@ -0,0 +1,31 @@
+package p
+
+import "sync"
+
+type guardedCounter struct {
+	sync.Mutex
+	value int
+}
+
+var initCounter sync.Once
+
+func countConcurrently(c *guardedCounter) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c.Lock()
+			c.value++
+			c.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	initCounter.Do(func() {
+		c.value = 0
+	})
+}
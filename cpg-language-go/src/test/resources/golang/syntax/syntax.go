@@ -0,0 +1,12 @@
+package syntax
+
+func valid() int {
+	return 1
+}
+
+func broken() int {
+	a := 1 +
+	return a
+}
+
+this is not a declaration
@@ -0,0 +1,7 @@
+package p
+
+type User struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty" gorm:"column:email"`
+	age   int
+}
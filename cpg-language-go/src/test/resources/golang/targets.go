@@ -0,0 +1,33 @@
+package p
+
+type Pair struct {
+	First, Second int
+}
+
+func pair() (int, int) {
+	return 1, 2
+}
+
+func parse() (int, error) {
+	return 1, nil
+}
+
+func targets(xs []int) {
+	var p Pair
+	m := map[string]int{}
+
+	p.First, m["a"] = pair()
+
+	a, err := parse()
+	b, err := parse()
+	_, err = parse()
+
+	for _, v := range xs {
+		println(v)
+	}
+
+	for _, p.Second = range xs {
+	}
+
+	println(a, b, err)
+}
@@ -0,0 +1,16 @@
+package p
+
+type MyStruct struct{}
+
+func main() {
+	var x interface{} = 1
+
+	switch v := x.(type) {
+	case int:
+		println(v)
+	case MyStruct, *MyStruct:
+		println(v)
+	default:
+		println(v)
+	}
+}
@@ -0,0 +1,21 @@
+package p
+
+type Names []string
+
+type Alias = Names
+
+func (n Names) Len() int {
+	return len(n)
+}
+
+func convert(s []string) Alias {
+	return Names(s)
+}
+
+type Fahrenheit float64
+
+type Celsius Fahrenheit
+
+func toCelsius(f Fahrenheit) Celsius {
+	return Celsius((f - 32) * 5 / 9)
+}
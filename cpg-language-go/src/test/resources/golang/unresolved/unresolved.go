@@ -0,0 +1,11 @@
+package unresolved
+
+import (
+	"strings"
+
+	"example.com/missing/v2"
+)
+
+func unresolved() string {
+	return strings.ToUpper(missing.Name)
+}
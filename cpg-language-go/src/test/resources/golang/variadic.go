@@ -0,0 +1,18 @@
+package p
+
+func sum(prefix string, numbers ...int) int {
+	var s = 0
+
+	for _, n := range numbers {
+		s += n
+	}
+
+	return s
+}
+
+func main() {
+	numbers := []int{1, 2, 3}
+
+	sum("a", 1, 2)
+	sum("b", numbers...)
+}